@@ -2,18 +2,30 @@ package main
 
 import (
 	"context"
+	"io/ioutil"
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/crosbymichael/boss/agent"
 	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/audit"
+	"github.com/crosbymichael/boss/auth"
 	"github.com/crosbymichael/boss/config"
 	"github.com/crosbymichael/boss/system"
 	raven "github.com/getsentry/raven-go"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
+	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
@@ -23,7 +35,7 @@ var agentCommand = cli.Command{
 	Usage: "run the boss agent",
 	Action: func(clix *cli.Context) error {
 		s := make(chan os.Signal, 32)
-		signal.Notify(s, os.Interrupt)
+		signal.Notify(s, os.Interrupt, unix.SIGTERM)
 
 		c, err := config.Load()
 		if err != nil {
@@ -38,48 +50,317 @@ var agentCommand = cli.Command{
 		if err != nil {
 			return err
 		}
-		a, err := agent.New(c, client, store)
+		log, err := audit.Open(c.Agent.Audit.Path, c.Agent.Audit.MaxSize*1024*1024, c.Agent.Audit.MaxFiles)
 		if err != nil {
 			return err
 		}
-		server := newServer()
-		v1.RegisterAgentServer(server, a)
+		defer log.Close()
+		a, err := agent.New(c, client, store, log)
+		if err != nil {
+			return err
+		}
+		if err := a.MountVolumes(Context()); err != nil {
+			return err
+		}
+		if err := a.Recover(Context()); err != nil {
+			return err
+		}
+		go a.Reconcile(Context(), c.Agent.ReconcileInterval.Duration)
+		go a.ScheduleCheckpoints(Context())
+		i := &interceptors{
+			audit:    log,
+			timeouts: c.Agent.Timeouts,
+		}
+		if c.Agent.Auth != nil {
+			if i.auth, err = auth.New(c.Agent.Auth); err != nil {
+				return err
+			}
+		}
+		addresses := c.Agent.Listen
+		if len(addresses) == 0 {
+			tcp := clix.GlobalString("agent")
+			if tcp == "" {
+				tcp = v1.DefaultAddress
+			}
+			addresses = []string{tcp, "unix://" + v1.DefaultSocket}
+		}
+		var (
+			servers []*grpc.Server
+			errCh   = make(chan error, len(addresses))
+		)
+		for _, address := range addresses {
+			l, creds, err := listen(c, address)
+			if err != nil {
+				return err
+			}
+			defer l.Close()
+			server := newServer(i, creds)
+			v1.RegisterAgentServer(server, a)
+			servers = append(servers, server)
+			go func(server *grpc.Server, l net.Listener) {
+				errCh <- server.Serve(l)
+			}(server, l)
+		}
 		go func() {
 			<-s
-			server.Stop()
+			shutdown(servers, c.Agent.ShutdownTimeout.Duration)
 		}()
-		l, err := net.Listen("tcp", clix.GlobalString("agent"))
-		if err != nil {
-			return err
+		// wait for every server to be drained, a server that fails stops the others
+		var serveErr error
+		for range servers {
+			if err := <-errCh; err != nil && serveErr == nil {
+				serveErr = err
+				go shutdown(servers, c.Agent.ShutdownTimeout.Duration)
+			}
 		}
-		defer l.Close()
-		return server.Serve(l)
+		return serveErr
 	},
 }
 
-func newServer() *grpc.Server {
-	s := grpc.NewServer(
-		grpc.UnaryInterceptor(unary),
-		grpc.StreamInterceptor(stream),
+// shutdown lets in-flight rpcs finish until the timeout before stopping the servers
+func shutdown(servers []*grpc.Server, timeout time.Duration) {
+	logrus.Infof("draining agent rpcs for up to %s", timeout)
+	var (
+		wg   sync.WaitGroup
+		done = make(chan struct{})
 	)
+	for _, server := range servers {
+		wg.Add(1)
+		go func(server *grpc.Server) {
+			defer wg.Done()
+			server.GracefulStop()
+		}(server)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		logrus.Warn("shutdown timeout reached, stopping in-flight rpcs")
+		for _, server := range servers {
+			server.Stop()
+		}
+	}
+}
+
+// listen on the address returning the credentials for the listener
+func listen(c *config.Config, address string) (net.Listener, credentials.TransportCredentials, error) {
+	if strings.HasPrefix(address, "unix://") {
+		l, err := listenUnix(strings.TrimPrefix(address, "unix://"), c.Agent.SocketMode, c.Agent.SocketGID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return l, auth.UnixCredentials(), nil
+	}
+	var creds credentials.TransportCredentials
+	if c.Agent.TLS != nil {
+		tlsConfig, err := c.Agent.TLS.ServerConfig()
+		if err != nil {
+			return nil, nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	l, err := net.Listen("tcp", strings.TrimPrefix(address, "tcp://"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, creds, nil
+}
+
+func listenUnix(path, mode string, gid int) (net.Listener, error) {
+	perm := uint64(0600)
+	if mode != "" {
+		p, err := strconv.ParseUint(mode, 8, 32)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse socket_mode")
+		}
+		perm = p
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0711); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	// create the socket in a private directory so that it cannot be connected to
+	// before its owner and permissions are set, then move it into place
+	dir, err := ioutil.TempDir(filepath.Dir(path), ".socket-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	tmp := filepath.Join(dir, filepath.Base(path))
+	l, err := net.Listen("unix", tmp)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chown(tmp, 0, gid); err != nil {
+		l.Close()
+		return nil, err
+	}
+	if err := os.Chmod(tmp, os.FileMode(perm)); err != nil {
+		l.Close()
+		return nil, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+func newServer(i *interceptors, creds credentials.TransportCredentials) *grpc.Server {
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(i.unary),
+		grpc.StreamInterceptor(i.stream),
+	}
+	if creds != nil {
+		opts = append(opts, grpc.Creds(creds))
+	}
+	s := grpc.NewServer(opts...)
 
 	hs := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(s, hs)
 	return s
 }
 
-func unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+type interceptors struct {
+	auth     *auth.Authorizer
+	audit    *audit.Log
+	timeouts map[string]config.Duration
+}
+
+func (i *interceptors) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	start := time.Now()
+	ctx, cancel := i.withTimeout(ctx, info.FullMethod)
+	defer cancel()
+	if err := i.authorize(ctx, info.FullMethod, req); err != nil {
+		i.record(ctx, info.FullMethod, req, start, err)
+		return nil, err
+	}
+	ctx = i.withCaller(ctx, info.FullMethod)
 	r, err := grpc_prometheus.UnaryServerInterceptor(ctx, req, info, handler)
 	if err != nil {
 		raven.CaptureError(err, nil)
 	}
+	i.record(ctx, info.FullMethod, req, start, err)
 	return r, err
 }
 
-func stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-	err := grpc_prometheus.StreamServerInterceptor(srv, ss, info, handler)
+func (i *interceptors) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, cancel := i.withTimeout(ss.Context(), info.FullMethod)
+	defer cancel()
+	if err := i.authorize(ctx, info.FullMethod, nil); err != nil {
+		i.record(ctx, info.FullMethod, nil, start, err)
+		return err
+	}
+	s := &serverStream{
+		ServerStream: ss,
+		ctx:          i.withCaller(ctx, info.FullMethod),
+		i:            i,
+		method:       info.FullMethod,
+	}
+	err := grpc_prometheus.StreamServerInterceptor(srv, s, info, handler)
 	if err != nil {
 		raven.CaptureError(err, nil)
 	}
+	i.record(ctx, info.FullMethod, s.first, start, err)
 	return err
 }
+
+// withTimeout applies the configured deadline for the rpc
+func (i *interceptors) withTimeout(ctx context.Context, method string) (context.Context, context.CancelFunc) {
+	rpc, ok := auth.AgentRPC(method)
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	d, ok := i.timeouts[rpc]
+	if !ok {
+		d = i.timeouts["default"]
+	}
+	if d.Duration <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d.Duration)
+}
+
+// record writes mutating rpcs to the audit log
+func (i *interceptors) record(ctx context.Context, method string, req interface{}, start time.Time, err error) {
+	rpc, ok := auth.AgentRPC(method)
+	if i.audit == nil || !ok || !audit.Mutating(rpc) {
+		return
+	}
+	e := &audit.Entry{
+		Timestamp: start,
+		Identity:  auth.Identity(ctx),
+		RPC:       rpc,
+		ID:        requestID(req),
+		Request:   audit.Summary(req),
+		Duration:  time.Since(start),
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	if err := i.audit.Write(e); err != nil {
+		logrus.WithError(err).Error("write audit log")
+	}
+}
+
+// requestID returns the id of the container or volume the request operates on
+func requestID(req interface{}) string {
+	if id := auth.VolumeID(req); id != "" {
+		return id
+	}
+	return auth.ContainerID(req)
+}
+
+func (i *interceptors) authorize(ctx context.Context, method string, req interface{}) error {
+	if i.auth == nil {
+		return nil
+	}
+	identity := auth.Identity(ctx)
+	if err := i.auth.Authorize(identity, method, req); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"identity": identity,
+			"rpc":      auth.RPC(method),
+		}).WithError(err).Warn("permission denied")
+		return err
+	}
+	return nil
+}
+
+// withCaller adds the caller to the context for rpcs that authorize more containers
+// than the one named in their request
+func (i *interceptors) withCaller(ctx context.Context, method string) context.Context {
+	if i.auth == nil {
+		return ctx
+	}
+	return i.auth.WithCaller(ctx, auth.Identity(ctx), method)
+}
+
+// serverStream authorizes each received message against the container it names
+// and keeps the first message for the audit log
+type serverStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	i      *interceptors
+	method string
+	first  interface{}
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.first == nil {
+		s.first = m
+	}
+	return s.i.authorize(s.Context(), s.method, m)
+}
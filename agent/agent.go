@@ -3,6 +3,7 @@ package agent
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,7 +11,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/containerd/cgroups"
@@ -21,7 +24,9 @@ import (
 	"github.com/containerd/containerd/diff"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/mount"
 	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/remotes"
 	"github.com/containerd/containerd/remotes/docker"
 	"github.com/containerd/containerd/rootfs"
 	"github.com/containerd/containerd/runtime/v2/runc/options"
@@ -29,10 +34,12 @@ import (
 	"github.com/containerd/typeurl"
 	"github.com/crosbymichael/boss/api"
 	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/audit"
+	"github.com/crosbymichael/boss/auth"
 	"github.com/crosbymichael/boss/config"
 	"github.com/crosbymichael/boss/flux"
 	"github.com/crosbymichael/boss/opts"
-	"github.com/crosbymichael/boss/systemd"
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	ver "github.com/opencontainers/image-spec/specs-go"
 	is "github.com/opencontainers/image-spec/specs-go/v1"
@@ -53,7 +60,7 @@ const (
 	MediaTypeContainerInfo = "application/vnd.boss.container.info.v1+json"
 )
 
-func New(c *config.Config, client *containerd.Client, store config.ConfigStore) (*Agent, error) {
+func New(c *config.Config, client *containerd.Client, store config.ConfigStore, log *audit.Log) (*Agent, error) {
 	register, err := c.GetRegister()
 	if err != nil {
 		return nil, err
@@ -61,11 +68,27 @@ func New(c *config.Config, client *containerd.Client, store config.ConfigStore)
 	for _, r := range c.Agent.PlainRemotes {
 		plainRemotes[r] = true
 	}
+	var tlsConfig *tls.Config
+	if c.Agent.TLS != nil {
+		// the agent's certificate is used as the client cert when talking to other agents
+		if tlsConfig, err = c.Agent.TLS.ClientConfig(); err != nil {
+			return nil, err
+		}
+	}
 	return &Agent{
 		c:        c,
 		client:   client,
 		store:    store,
 		register: register,
+		tls:      tlsConfig,
+		audit:    log,
+		journal: &journal{
+			root: filepath.Join(v1.Root, "journal"),
+		},
+		groups: &groups{
+			root: filepath.Join(v1.Root, "groups"),
+		},
+		scheduled: &scheduled{},
 	}, nil
 }
 
@@ -74,77 +97,205 @@ type Agent struct {
 	client   *containerd.Client
 	store    config.ConfigStore
 	register v1.Register
+	tls      *tls.Config
+	audit    *audit.Log
+	locks    locks
+	journal  *journal
+	groups   *groups
+
+	scheduled *scheduled
 }
 
 func (a *Agent) Create(ctx context.Context, req *v1.CreateRequest) (*types.Empty, error) {
 	ctx = relayContext(ctx)
-	image, err := a.client.Pull(ctx, req.Container.Image, containerd.WithPullUnpack, withPlainRemote(req.Container.Image))
+	if err := validateKind(req.Container); err != nil {
+		return nil, err
+	}
+	if err := validateReplicas(req.Container); err != nil {
+		return nil, err
+	}
+	if err := validateHelpers(req.Container); err != nil {
+		return nil, err
+	}
+	if err := a.validateNetworks(req.Container); err != nil {
+		return nil, err
+	}
+	if err := a.validatePorts(req.Container); err != nil {
+		return nil, err
+	}
+	if err := validateCheckpoint(req.Container); err != nil {
+		return nil, err
+	}
+	unlock, err := a.locks.lock(ctx, req.Container.ID)
 	if err != nil {
 		return nil, err
 	}
-	if _, err := a.client.LoadContainer(ctx, req.Container.ID); err == nil {
+	defer unlock()
+	unlockPorts, err := a.checkPorts(ctx, req.Container)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockPorts()
+	if req.Container.Replicas > 0 {
+		replicas, err := a.replicas(ctx, req.Container.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(replicas) == 0 {
+			if err := a.notExists(ctx, req.Container.ID); err != nil {
+				return nil, err
+			}
+			return empty, a.scale(ctx, req.Container, nil)
+		}
 		if !req.Update {
 			return nil, errors.Errorf("container %s already exists", req.Container.ID)
 		}
-		_, err = a.Update(ctx, &v1.UpdateRequest{
+		_, err = a.updateReplicas(ctx, &v1.UpdateRequest{
 			Container: req.Container,
 		})
 		return empty, err
 	}
-	container, err := a.client.NewContainer(ctx,
-		req.Container.ID,
-		flux.WithNewSnapshot(image),
-		opts.WithBossConfig(a.c.Agent.VolumeRoot, req.Container, image),
-	)
+	image, err := a.client.Pull(ctx, req.Container.Image, containerd.WithPullUnpack, withPlainRemote(req.Container.Image))
 	if err != nil {
 		return nil, err
 	}
-	if err := a.store.Write(ctx, req.Container); err != nil {
-		container.Delete(ctx, containerd.WithSnapshotCleanup)
-		return nil, err
+	if _, err := a.load(ctx, req.Container.ID); err == nil {
+		if !req.Update {
+			return nil, errors.Errorf("container %s already exists", req.Container.ID)
+		}
+		_, err = a.update(ctx, &v1.UpdateRequest{
+			Container: req.Container,
+		})
+		return empty, err
 	}
-	if err := systemd.Enable(ctx, container.ID()); err != nil {
+	op, err := a.journal.begin(req.Container.ID, opCreate, nil)
+	if err != nil {
 		return nil, err
 	}
-	if err := systemd.Start(ctx, container.ID()); err != nil {
+	if err := a.create(ctx, op, req.Container, func() error {
+		_, err := a.client.NewContainer(ctx,
+			req.Container.ID,
+			flux.WithNewSnapshot(image),
+			opts.WithBossConfig(a.c.Agent.VolumeRoot, req.Container, image),
+		)
+		return err
+	}); err != nil {
 		return nil, err
 	}
 	return empty, nil
 }
 
+// create runs the journaled steps of creating a container, rolling back on failure.
+// newContainer creates the containerd container with the operation's id
+func (a *Agent) create(ctx context.Context, op *operation, c *v1.Container, newContainer func() error) error {
+	err := a.pullHelpers(ctx, c)
+	if err == nil {
+		err = op.do(stepContainer, newContainer)
+	}
+	if err == nil {
+		err = op.do(stepConfigs, func() error {
+			return a.store.Write(ctx, c)
+		})
+	}
+	if err == nil {
+		err = op.do(stepEnable, func() error {
+			return enableUnit(ctx, op.ID, c)
+		})
+	}
+	if err == nil {
+		err = op.do(stepStart, func() error {
+			return startUnit(ctx, op.ID, c)
+		})
+	}
+	if err == nil {
+		err = op.commit()
+	}
+	if err != nil {
+		if rerr := a.rollback(ctx, op); rerr != nil {
+			logrus.WithError(rerr).Errorf("rollback create %s", c.ID)
+		}
+		return err
+	}
+	return op.done()
+}
+
 func (a *Agent) Delete(ctx context.Context, req *v1.DeleteRequest) (*types.Empty, error) {
 	ctx = relayContext(ctx)
 	id := req.ID
 	if id == "" {
 		return nil, ErrNoID
 	}
-	container, err := a.client.LoadContainer(ctx, id)
+	unlock, err := a.locks.lock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	container, err := a.load(ctx, id)
 	if err != nil {
+		if errdefs.IsNotFound(err) {
+			replicas, rerr := a.replicas(ctx, id)
+			if rerr != nil {
+				return nil, rerr
+			}
+			if len(replicas) > 0 {
+				return a.deleteReplicas(ctx, id, replicas)
+			}
+		}
 		return nil, errors.Wrap(err, "load container")
 	}
-	if err := systemd.Stop(ctx, id); err != nil {
-		return nil, errors.Wrap(err, "stop service")
+	return a.delete(ctx, container.ID())
+}
+
+// delete is journaled so that an interrupted delete is finished when the agent starts
+func (a *Agent) delete(ctx context.Context, id string) (*types.Empty, error) {
+	op, err := a.journal.begin(id, opDelete, nil)
+	if err != nil {
+		return nil, err
 	}
-	if err := systemd.Disable(ctx, id); err != nil {
-		return nil, errors.Wrap(err, "disable service")
+	if err := a.remove(ctx, id); err != nil {
+		if errdefs.IsNotFound(errors.Cause(err)) {
+			op.done()
+		}
+		return nil, err
+	}
+	return empty, op.done()
+}
+
+func (a *Agent) remove(ctx context.Context, id string) error {
+	container, err := a.client.LoadContainer(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "load container")
 	}
 	config, err := opts.GetConfig(ctx, container)
 	if err != nil {
-		return nil, errors.Wrap(err, "load config")
+		return errors.Wrap(err, "load config")
 	}
-	network, err := a.c.GetNetwork(config.Network)
+	if err := stopUnit(ctx, id, config); err != nil {
+		return errors.Wrap(err, "stop service")
+	}
+	if err := disableUnit(ctx, id, config); err != nil {
+		return errors.Wrap(err, "disable service")
+	}
+	network, err := a.c.GetNetwork(config)
 	if err != nil {
-		return nil, errors.Wrap(err, "get network")
+		return errors.Wrap(err, "get network")
 	}
 	if err := network.Remove(ctx, container); err != nil {
-		return nil, err
+		return err
 	}
-	for name := range config.Services {
-		if err := a.register.Deregister(id, name); err != nil {
-			logrus.WithError(err).Errorf("de-register %s-%s", id, name)
+	labels, err := container.Labels(ctx)
+	if err != nil {
+		return err
+	}
+	// a replaced blue/green container's services are registered for its sibling
+	if !opts.Unregistered(labels) {
+		for name := range config.Services {
+			if err := a.register.Deregister(config.ID, name); err != nil {
+				logrus.WithError(err).Errorf("de-register %s-%s", config.ID, name)
+			}
 		}
 	}
-	return empty, container.Delete(ctx, flux.WithRevisionCleanup)
+	return container.Delete(ctx, flux.WithRevisionCleanup)
 }
 
 func (a *Agent) Get(ctx context.Context, req *v1.GetRequest) (*v1.GetResponse, error) {
@@ -153,7 +304,7 @@ func (a *Agent) Get(ctx context.Context, req *v1.GetRequest) (*v1.GetResponse, e
 	if id == "" {
 		return nil, ErrNoID
 	}
-	container, err := a.client.LoadContainer(ctx, req.ID)
+	container, err := a.load(ctx, req.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -209,12 +360,14 @@ func (a *Agent) info(ctx context.Context, c containerd.Container) (*v1.Container
 	if err != nil {
 		if errdefs.IsNotFound(err) {
 			return &v1.ContainerInfo{
-				ID:        c.ID(),
-				Image:     info.Image,
-				Status:    string(containerd.Stopped),
-				FsSize:    usage.Size + bindSizes,
-				Config:    cfg,
-				Snapshots: ss,
+				ID:         opts.ID(info),
+				Image:      info.Image,
+				Status:     string(containerd.Stopped),
+				FsSize:     usage.Size + bindSizes,
+				Config:     cfg,
+				Snapshots:  ss,
+				Group:      info.Labels[opts.GroupLabel],
+				Checkpoint: a.scheduled.get(opts.ID(info)),
 			}, nil
 		}
 		return nil, err
@@ -231,6 +384,10 @@ func (a *Agent) info(ctx context.Context, c containerd.Container) (*v1.Container
 	if err != nil {
 		return nil, err
 	}
+	interfaces, err := opts.Interfaces(info.Labels)
+	if err != nil {
+		return nil, err
+	}
 	var (
 		cg     = v.(*cgroups.Metrics)
 		cpu    = cg.CPU.Usage.Total
@@ -238,10 +395,12 @@ func (a *Agent) info(ctx context.Context, c containerd.Container) (*v1.Container
 		limit  = float64(cg.Memory.Usage.Limit)
 	)
 	return &v1.ContainerInfo{
-		ID:          c.ID(),
+		ID:          opts.ID(info),
 		Image:       info.Image,
 		Status:      string(status.Status),
 		IP:          info.Labels[opts.IPLabel],
+		IP6:         info.Labels[opts.IP6Label],
+		Interfaces:  interfaces,
 		Cpu:         cpu,
 		MemoryUsage: memory,
 		MemoryLimit: limit,
@@ -250,13 +409,15 @@ func (a *Agent) info(ctx context.Context, c containerd.Container) (*v1.Container
 		FsSize:      usage.Size + bindSizes,
 		Config:      cfg,
 		Snapshots:   ss,
+		Group:       info.Labels[opts.GroupLabel],
+		Checkpoint:  a.scheduled.get(opts.ID(info)),
 	}, nil
 }
 
 func (a *Agent) List(ctx context.Context, req *v1.ListRequest) (*v1.ListResponse, error) {
 	var resp v1.ListResponse
 	ctx = relayContext(ctx)
-	containers, err := a.client.Containers(ctx)
+	containers, err := a.containers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -281,7 +442,12 @@ func (a *Agent) Kill(ctx context.Context, req *v1.KillRequest) (*types.Empty, er
 	if id == "" {
 		return nil, ErrNoID
 	}
-	container, err := a.client.LoadContainer(ctx, id)
+	unlock, err := a.locks.lock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	container, err := a.load(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -290,8 +456,8 @@ func (a *Agent) Kill(ctx context.Context, req *v1.KillRequest) (*types.Empty, er
 		return nil, err
 	}
 	for name := range config.Services {
-		if err := a.register.EnableMaintainance(id, name, "manual kill"); err != nil {
-			logrus.WithError(err).Errorf("enable maintaince %s-%s", id, name)
+		if err := a.register.EnableMaintainance(config.ID, name, "manual kill"); err != nil {
+			logrus.WithError(err).Errorf("enable maintaince %s-%s", config.ID, name)
 		}
 	}
 	task, err := container.Task(ctx, nil)
@@ -310,7 +476,20 @@ func (a *Agent) Start(ctx context.Context, req *v1.StartRequest) (*types.Empty,
 	if id == "" {
 		return nil, ErrNoID
 	}
-	return empty, systemd.Start(ctx, req.ID)
+	unlock, err := a.locks.lock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	container, err := a.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	config, err := opts.GetConfig(ctx, container)
+	if err != nil {
+		return nil, err
+	}
+	return empty, startUnit(ctx, container.ID(), config)
 }
 
 func (a *Agent) Stop(ctx context.Context, req *v1.StopRequest) (*types.Empty, error) {
@@ -319,17 +498,65 @@ func (a *Agent) Stop(ctx context.Context, req *v1.StopRequest) (*types.Empty, er
 	if id == "" {
 		return nil, ErrNoID
 	}
-	return empty, systemd.Stop(ctx, req.ID)
+	unlock, err := a.locks.lock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	container, err := a.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	config, err := opts.GetConfig(ctx, container)
+	if err != nil {
+		return nil, err
+	}
+	return empty, stopUnit(ctx, container.ID(), config)
 }
 
 func (a *Agent) Update(ctx context.Context, req *v1.UpdateRequest) (*v1.UpdateResponse, error) {
 	ctx = relayContext(ctx)
+	if err := validateKind(req.Container); err != nil {
+		return nil, err
+	}
+	if err := validateReplicas(req.Container); err != nil {
+		return nil, err
+	}
+	if err := validateHelpers(req.Container); err != nil {
+		return nil, err
+	}
+	if err := a.validateNetworks(req.Container); err != nil {
+		return nil, err
+	}
+	if err := a.validatePorts(req.Container); err != nil {
+		return nil, err
+	}
+	if err := validateCheckpoint(req.Container); err != nil {
+		return nil, err
+	}
+	unlock, err := a.locks.lock(ctx, req.Container.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	unlockPorts, err := a.checkPorts(ctx, req.Container)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockPorts()
+	if req.Container.Replicas > 0 {
+		return a.updateReplicas(ctx, req)
+	}
+	return a.update(ctx, req)
+}
+
+func (a *Agent) update(ctx context.Context, req *v1.UpdateRequest) (*v1.UpdateResponse, error) {
 	ctx, done, err := a.client.WithLease(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer done(ctx)
-	container, err := a.client.LoadContainer(ctx, req.Container.ID)
+	container, err := a.load(ctx, req.Container.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -337,10 +564,36 @@ func (a *Agent) Update(ctx context.Context, req *v1.UpdateRequest) (*v1.UpdateRe
 	if err != nil {
 		return nil, err
 	}
+	if v1.IsJob(current) != v1.IsJob(req.Container) {
+		return nil, errKindChange
+	}
+	diff, err := a.diff(ctx, container, current, req.Container)
+	if err != nil {
+		return nil, err
+	}
+	if req.DryRun {
+		return &v1.UpdateResponse{
+			Diff: diff,
+		}, nil
+	}
+	strategy, err := strategy(req.Container)
+	if err != nil {
+		return nil, err
+	}
+	if strategy == strategyBlueGreen {
+		return a.blueGreen(ctx, container, current, req.Container, diff)
+	}
+	if err := a.pullHelpers(ctx, req.Container); err != nil {
+		return nil, err
+	}
+	prev, err := getPrevious(ctx, container)
+	if err != nil {
+		return nil, err
+	}
 	// set all current services into maintaince mode
 	for name := range current.Services {
-		if err := a.register.EnableMaintainance(container.ID(), name, "update container configuration"); err != nil {
-			logrus.WithError(err).Errorf("enable maintaince %s-%s", container.ID(), name)
+		if err := a.register.EnableMaintainance(current.ID, name, "update container configuration"); err != nil {
+			logrus.WithError(err).Errorf("enable maintaince %s-%s", current.ID, name)
 		}
 	}
 	var changes []change
@@ -349,6 +602,7 @@ func (a *Agent) Update(ctx context.Context, req *v1.UpdateRequest) (*v1.UpdateRe
 			// if the new config does not have a service, deregister the old one
 			changes = append(changes, &deregisterChange{
 				register: a.register,
+				id:       current.ID,
 				name:     name,
 			})
 		}
@@ -375,6 +629,10 @@ func (a *Agent) Update(ctx context.Context, req *v1.UpdateRequest) (*v1.UpdateRe
 			return nil, err
 		}
 	}
+	if v1.IsJob(req.Container) {
+		// a running job finishes with the config it started with
+		task = nil
+	}
 	if task != nil {
 		if wait, err = task.Wait(ctx); err != nil {
 			return nil, err
@@ -384,33 +642,59 @@ func (a *Agent) Update(ctx context.Context, req *v1.UpdateRequest) (*v1.UpdateRe
 		wait = c
 		close(c)
 	}
-	err = pauseAndRun(ctx, container, func() error {
-		for _, ch := range changes {
-			if err := ch.update(ctx, container); err != nil {
+	op, err := a.journal.begin(container.ID(), opUpdate, prev)
+	if err != nil {
+		return nil, err
+	}
+	err = op.do(stepPause, func() error {
+		return pauseAndRun(ctx, container, func() error {
+			if err := op.do(stepApply, func() error {
+				for _, ch := range changes {
+					if err := ch.update(ctx, container); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
 				return err
 			}
-		}
-		if task == nil {
-			return nil
-		}
-		return task.Kill(ctx, unix.SIGTERM)
+			if task == nil {
+				return nil
+			}
+			return op.do(stepRestart, func() error {
+				return task.Kill(ctx, unix.SIGTERM)
+			})
+		})
 	})
 	if err != nil {
+		if rerr := a.rollback(ctx, op); rerr != nil {
+			logrus.WithError(rerr).Errorf("rollback update %s", container.ID())
+		}
 		return nil, err
 	}
-	wctx, _ := context.WithTimeout(ctx, 10*time.Second)
-	for {
-		select {
-		case <-wctx.Done():
-			if task != nil {
-				return &v1.UpdateResponse{}, task.Kill(ctx, unix.SIGKILL)
-			}
-			return nil, wctx.Err()
-		case <-wait:
-			return &v1.UpdateResponse{}, nil
+	if err := op.done(); err != nil {
+		return nil, err
+	}
+	if v1.IsJob(req.Container) && req.Container.Schedule != current.Schedule {
+		if err := enableUnit(ctx, container.ID(), req.Container); err != nil {
+			return nil, errors.Wrap(err, "update schedule")
 		}
 	}
-	return &v1.UpdateResponse{}, nil
+	wctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	select {
+	case <-wctx.Done():
+		if task != nil {
+			return &v1.UpdateResponse{
+				Diff: diff,
+			}, task.Kill(ctx, unix.SIGKILL)
+		}
+		return nil, wctx.Err()
+	case <-wait:
+		return &v1.UpdateResponse{
+			Diff: diff,
+		}, nil
+	}
 }
 
 func (a *Agent) Rollback(ctx context.Context, req *v1.RollbackRequest) (*v1.RollbackResponse, error) {
@@ -418,15 +702,30 @@ func (a *Agent) Rollback(ctx context.Context, req *v1.RollbackRequest) (*v1.Roll
 	if req.ID == "" {
 		return nil, ErrNoID
 	}
+	unlock, err := a.locks.lock(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 	ctx, done, err := a.client.WithLease(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer done(ctx)
-	container, err := a.client.LoadContainer(ctx, req.ID)
+	container, err := a.load(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	config, err := opts.GetConfig(ctx, container)
 	if err != nil {
 		return nil, err
 	}
+	if s, err := strategy(config); err == nil && s == strategyBlueGreen {
+		if err := a.rollbackBlueGreen(ctx, container, config); err != nil {
+			return nil, err
+		}
+		return &v1.RollbackResponse{}, nil
+	}
 	err = pauseAndRun(ctx, container, func() error {
 		if err := container.Update(ctx, flux.WithRollback, opts.WithRollback); err != nil {
 			return err
@@ -474,12 +773,22 @@ func (a *Agent) Checkpoint(ctx context.Context, req *v1.CheckpointRequest) (*v1.
 	if req.ID == "" {
 		return nil, ErrNoID
 	}
+	unlock, err := a.locks.lock(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return a.checkpoint(ctx, req, nil)
+}
+
+// checkpoint the container, a live checkpoint with pre-dumps only contains the memory changed since the last of them
+func (a *Agent) checkpoint(ctx context.Context, req *v1.CheckpointRequest, pre *preCopy) (*v1.CheckpointResponse, error) {
 	ctx, done, err := a.client.WithLease(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer done(ctx)
-	container, err := a.client.LoadContainer(ctx, req.ID)
+	container, err := a.load(ctx, req.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -487,11 +796,23 @@ func (a *Agent) Checkpoint(ctx context.Context, req *v1.CheckpointRequest) (*v1.
 	if err != nil {
 		return nil, err
 	}
+	d := info.Extensions[opts.CurrentConfig]
+	config, err := opts.UnmarshalConfig(&d)
+	if err != nil {
+		return nil, err
+	}
+	volumeIDs, err := checkpointVolumes(config, req.Volumes, req.ExcludeVolumes)
+	if err != nil {
+		return nil, err
+	}
 	index := is.Index{
 		Versioned: ver.Versioned{
 			SchemaVersion: 2,
 		},
-		Annotations: make(map[string]string),
+		Annotations: map[string]string{
+			AnnotationCheckpointNode:    a.c.ID,
+			AnnotationCheckpointVersion: strconv.Itoa(CheckpointVersion),
+		},
 	}
 	data, err := json.Marshal(info)
 	if err != nil {
@@ -540,9 +861,21 @@ func (a *Agent) Checkpoint(ctx context.Context, req *v1.CheckpointRequest) (*v1.
 			Architecture: runtime.GOARCH,
 		}
 		index.Manifests = append(index.Manifests, rw)
-		if req.Live {
+		layers, err := a.writeVolumeLayers(ctx, volumeIDs)
+		if err != nil {
+			return err
+		}
+		index.Manifests = append(index.Manifests, layers...)
+		if req.Live && pre != nil {
+			desc, err := a.finalDump(ctx, pre, container.ID(), req.Exit)
+			if err != nil {
+				return err
+			}
+			index.Manifests = append(index.Manifests, pre.dumps...)
+			index.Manifests = append(index.Manifests, desc)
+		} else if req.Live {
 			task, err := a.client.TaskService().Checkpoint(ctx, &tasks.CheckpointTaskRequest{
-				ContainerID: req.ID,
+				ContainerID: container.ID(),
 				Options:     any,
 			})
 			if err != nil {
@@ -569,18 +902,23 @@ func (a *Agent) Checkpoint(ctx context.Context, req *v1.CheckpointRequest) (*v1.
 	if err != nil {
 		return nil, err
 	}
-	if desc, err = a.writeIndex(ctx, &index, req.ID+"index"); err != nil {
+	if desc, err = writeIndex(ctx, a.client.ContentStore(), &index, req.ID+"index"); err != nil {
 		return nil, err
 	}
-	i := images.Image{
-		Name:   req.Ref,
-		Target: desc,
+	if pre != nil {
+		// the ref points at the pushed pre-dumps
+		err = a.saveImage(ctx, req.Ref, desc)
+	} else {
+		_, err = a.client.ImageService().Create(ctx, images.Image{
+			Name:   req.Ref,
+			Target: desc,
+		})
 	}
-	if _, err := a.client.ImageService().Create(ctx, i); err != nil {
+	if err != nil {
 		return nil, err
 	}
 	if req.Exit {
-		if err := systemd.Stop(ctx, req.ID); err != nil {
+		if err := stopUnit(ctx, container.ID(), config); err != nil {
 			return nil, errors.Wrap(err, "stop service")
 		}
 	}
@@ -608,6 +946,12 @@ func (a *Agent) Restore(ctx context.Context, req *v1.RestoreRequest) (*v1.Restor
 	if err != nil {
 		return nil, err
 	}
+	if err := validateVersion(index); err != nil {
+		return nil, err
+	}
+	if err := validatePlatform(index, req.Live); err != nil {
+		return nil, err
+	}
 	configDesc, err := getByMediaType(index, MediaTypeContainerInfo)
 	if err != nil {
 		return nil, err
@@ -620,10 +964,51 @@ func (a *Agent) Restore(ctx context.Context, req *v1.RestoreRequest) (*v1.Restor
 	if err := json.Unmarshal(data, &c); err != nil {
 		return nil, err
 	}
-	config, err := opts.GetConfigFromInfo(ctx, c)
+	source, err := opts.GetConfigFromInfo(ctx, c)
 	if err != nil {
 		return nil, err
 	}
+	config := proto.Clone(source).(*v1.Container)
+	if err := overrideConfig(config, req); err != nil {
+		return nil, err
+	}
+	// the request only names the id when it restores a clone
+	if err := auth.Container(ctx, config.ID); err != nil {
+		return nil, err
+	}
+	if err := validateKind(config); err != nil {
+		return nil, err
+	}
+	if err := validateReplicas(config); err != nil {
+		return nil, err
+	}
+	if err := validateHelpers(config); err != nil {
+		return nil, err
+	}
+	if err := a.validateNetworks(config); err != nil {
+		return nil, err
+	}
+	if err := a.validatePorts(config); err != nil {
+		return nil, err
+	}
+	if err := validateCheckpoint(config); err != nil {
+		return nil, err
+	}
+	unlock, err := a.locks.lock(ctx, config.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if _, err := a.client.LoadContainer(ctx, config.ID); err == nil {
+		return nil, errContainerExists
+	} else if !errdefs.IsNotFound(err) {
+		return nil, err
+	}
+	unlockPorts, err := a.checkPorts(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockPorts()
 	image, err := a.client.Pull(ctx, config.Image, containerd.WithPullUnpack, withPlainRemote(config.Image))
 	if err != nil {
 		return nil, err
@@ -637,92 +1022,307 @@ func (a *Agent) Restore(ctx context.Context, req *v1.RestoreRequest) (*v1.Restor
 		if err != nil {
 			return nil, err
 		}
+		if desc, err = a.withParents(ctx, checkpoint.Target(), index, *desc); err != nil {
+			return nil, errors.Wrap(err, "merge pre-dumps")
+		}
+		if config.ID != source.ID {
+			if desc, err = a.withHostname(ctx, checkpoint.Target(), *desc, config.ID); err != nil {
+				return nil, errors.Wrap(err, "set hostname")
+			}
+		}
 		o = append(o, opts.WithRestore(desc))
 	}
-	container, err := a.client.NewContainer(ctx,
-		config.ID,
-		o...,
-	)
+	rw, err := rwLayer(index)
 	if err != nil {
 		return nil, err
 	}
-	// apply rw layer
-	info, err := container.Info(ctx)
+	op, err := a.journal.begin(config.ID, opCreate, nil)
 	if err != nil {
 		return nil, err
 	}
-	rw, err := getByMediaType(index, is.MediaTypeImageLayerGzip)
-	if err != nil {
+	if err := op.do(stepVolumes, func() error {
+		return a.restoreVolumes(ctx, op, index, volumeMapping(source, config))
+	}); err != nil {
+		if rerr := a.rollback(ctx, op); rerr != nil {
+			logrus.WithError(rerr).Errorf("rollback restore %s", config.ID)
+		}
 		return nil, err
 	}
-	mounts, err := a.client.SnapshotService(info.Snapshotter).Mounts(ctx, info.SnapshotKey)
-	if err != nil {
+	if err := a.create(ctx, op, config, func() error {
+		container, err := a.client.NewContainer(ctx,
+			config.ID,
+			o...,
+		)
+		if err != nil {
+			return err
+		}
+		// apply rw layer
+		info, err := container.Info(ctx)
+		if err != nil {
+			return err
+		}
+		mounts, err := a.client.SnapshotService(info.Snapshotter).Mounts(ctx, info.SnapshotKey)
+		if err != nil {
+			return err
+		}
+		return a.applyRwLayer(ctx, *rw, mounts)
+	}); err != nil {
 		return nil, err
 	}
-	if _, err := a.client.DiffService().Apply(ctx, *rw, mounts); err != nil {
-		return nil, err
+	var resp v1.RestoreResponse
+	if req.Healthy {
+		container, err := a.client.LoadContainer(ctx, config.ID)
+		if err != nil {
+			return nil, err
+		}
+		if resp.IP, err = waitRestored(ctx, container, config); err != nil {
+			if _, derr := a.delete(ctx, config.ID); derr != nil {
+				logrus.WithError(derr).Errorf("delete unhealthy container %s", config.ID)
+			}
+			return nil, errors.Wrapf(err, "%s did not become healthy", config.ID)
+		}
 	}
-	if err := a.store.Write(ctx, config); err != nil {
-		container.Delete(ctx, containerd.WithSnapshotCleanup)
-		return nil, err
+	return &resp, nil
+}
+
+// applyRwLayer applies the checkpoint's rw layer to the container's snapshot,
+// zstd compressed layers are decompressed first as the diff service cannot apply them
+func (a *Agent) applyRwLayer(ctx context.Context, rw is.Descriptor, mounts []mount.Mount) error {
+	ctx, done, err := a.client.WithLease(ctx)
+	if err != nil {
+		return err
 	}
-	if err := systemd.Enable(ctx, container.ID()); err != nil {
-		return nil, err
+	defer done(ctx)
+	layer := rw
+	if rw.MediaType == MediaTypeImageLayerZstd {
+		if layer, err = decompressZstd(ctx, a.client.ContentStore(), rw); err != nil {
+			return errors.Wrap(err, "decompress zstd rw layer")
+		}
 	}
-	if err := systemd.Start(ctx, container.ID()); err != nil {
-		return nil, err
+	if _, err := a.client.DiffService().Apply(ctx, layer, mounts); err != nil {
+		return errors.Wrapf(err, "apply %s rw layer", rw.MediaType)
 	}
-	return &v1.RestoreResponse{}, nil
+	return nil
 }
 
-func (a *Agent) Migrate(ctx context.Context, req *v1.MigrateRequest) (*v1.MigrateResponse, error) {
-	ctx = relayContext(ctx)
+func (a *Agent) Migrate(req *v1.MigrateRequest, stream v1.Agent_MigrateServer) error {
+	ctx := relayContext(stream.Context())
 	if req.ID == "" {
-		return nil, ErrNoID
+		return ErrNoID
+	}
+	if req.PreDumps > 0 && !req.Live {
+		return errPreDumpNotLive
 	}
-	to, err := api.Agent(req.To)
+	unlock, err := a.locks.lock(ctx, req.ID)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	defer unlock()
+	to, err := api.Agent(req.To, a.tls)
+	if err != nil {
+		return err
 	}
 	defer to.Close()
 	if _, err := to.Get(ctx, &v1.GetRequest{
 		ID: req.ID,
 	}); err == nil {
-		return nil, errServiceExistsOnTarget
+		return errServiceExistsOnTarget
 	}
-	if _, err := a.Checkpoint(ctx, &v1.CheckpointRequest{
-		ID:   req.ID,
-		Live: req.Live,
-		Ref:  req.Ref,
-		Exit: req.Stop || req.Delete,
-	}); err != nil {
-		return nil, err
+	ctx, done, err := a.client.WithLease(ctx)
+	if err != nil {
+		return err
+	}
+	defer done(ctx)
+	if _, err := a.client.ImageService().Get(ctx, req.Ref); err == nil {
+		return errors.Wrapf(errdefs.ErrAlreadyExists, "image %s", req.Ref)
+	}
+	container, err := a.load(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	config, err := opts.GetConfig(ctx, container)
+	if err != nil {
+		return err
+	}
+	phase := func(name string, fn func() error) error {
+		start := time.Now()
+		if err := fn(); err != nil {
+			return errors.Wrap(err, name)
+		}
+		return stream.Send(&v1.MigrateResponse{
+			Phase: &v1.MigratePhase{
+				Name:     name,
+				Duration: time.Since(start),
+			},
+		})
+	}
+	direct := !req.Registry
+	// the target only lets this migration replace the ref on each transfer
+	migration := fmt.Sprintf("%s/%s/%d", a.c.ID, req.ID, time.Now().UnixNano())
+	transfer := func() error {
+		if direct {
+			err := a.send(ctx, to, req.Ref, migration)
+			if !isUnimplemented(err) {
+				return err
+			}
+			logrus.WithField("to", req.To).Warn("target cannot receive checkpoints, pushing to registry")
+			direct = false
+		}
+		_, err := a.Push(ctx, &v1.PushRequest{
+			Ref: req.Ref,
+		})
+		return err
 	}
 	defer a.client.ImageService().Delete(ctx, req.Ref)
-	if _, err := a.Push(ctx, &v1.PushRequest{
-		Ref: req.Ref,
+	// the source is handed off when it does not keep running, it is frozen from the checkpoint
+	// until the target is healthy so that its services stay registered and it can resume on failure
+	handoff := req.Stop || req.Delete
+	var frozen containerd.Task
+	defer func() {
+		if frozen != nil {
+			if err := frozen.Resume(relayContext(context.Background())); err != nil {
+				logrus.WithError(err).Errorf("resume %s after failed migration", req.ID)
+			}
+		}
+	}()
+	var pre *preCopy
+	if req.PreDumps > 0 {
+		if pre, err = newPreCopy(); err != nil {
+			return err
+		}
+		defer pre.Close()
+	}
+	for i := 1; i <= int(req.PreDumps); i++ {
+		if err := phase(fmt.Sprintf("pre-dump %d", i), func() error {
+			return a.preDump(ctx, pre, req.ID)
+		}); err != nil {
+			return err
+		}
+		if err := phase(fmt.Sprintf("transfer pre-dump %d", i), func() error {
+			if err := a.savePreDumps(ctx, pre, req.Ref); err != nil {
+				return err
+			}
+			return transfer()
+		}); err != nil {
+			return err
+		}
+	}
+	if handoff {
+		if err := phase("freeze", func() error {
+			task, err := container.Task(ctx, nil)
+			if err != nil {
+				if errdefs.IsNotFound(err) {
+					return nil
+				}
+				return err
+			}
+			if err := task.Pause(ctx); err != nil {
+				return err
+			}
+			frozen = task
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	if err := phase("checkpoint", func() error {
+		_, err := a.checkpoint(ctx, &v1.CheckpointRequest{
+			ID:             req.ID,
+			Live:           req.Live,
+			Ref:            req.Ref,
+			Volumes:        req.Volumes,
+			ExcludeVolumes: req.ExcludeVolumes,
+		}, pre)
+		return err
 	}); err != nil {
-		return nil, err
+		return err
+	}
+	if err := phase("transfer", transfer); err != nil {
+		return err
 	}
-	if _, err := to.Restore(ctx, &v1.RestoreRequest{
-		Ref:  req.Ref,
-		Live: req.Live,
+	if err := phase("restore", func() error {
+		_, err := to.Restore(ctx, &v1.RestoreRequest{
+			Ref:     req.Ref,
+			Live:    req.Live,
+			Healthy: handoff,
+		})
+		return err
 	}); err != nil {
-		return nil, err
+		return err
 	}
-	if req.Delete {
-		if _, err := a.Delete(ctx, &v1.DeleteRequest{
-			ID: req.ID,
-		}); err != nil {
-			return nil, err
+	if !handoff {
+		return nil
+	}
+	// the target is running from here on so a failed handoff leaves the source frozen
+	// and reports the error instead of resuming it next to the target
+	source := frozen
+	frozen = nil
+	// the services are placed in maintenance once the target is healthy, the unit is disabled
+	// and its stop queued before the frozen task is killed, so that the unit does not restart it,
+	// and the services are deregistered before the unit can change them
+	if err := phase("handoff", func() error {
+		for name := range config.Services {
+			if err := a.register.EnableMaintainance(config.ID, name, "migrated to "+req.To); err != nil {
+				return err
+			}
+		}
+		if err := disableUnit(ctx, container.ID(), config); err != nil {
+			return err
+		}
+		if err := queueStopUnit(ctx, container.ID(), config); err != nil {
+			return err
+		}
+		for name := range config.Services {
+			if err := a.register.Deregister(config.ID, name); err != nil {
+				return err
+			}
+		}
+		if source != nil {
+			// killed while frozen so that the source does not run again after the checkpoint
+			if err := source.Kill(ctx, syscall.SIGKILL); err != nil {
+				return err
+			}
+			if err := source.Resume(ctx); err != nil {
+				return err
+			}
+		}
+		// waits for the queued stop
+		return stopUnit(ctx, container.ID(), config)
+	}); err != nil {
+		if source != nil {
+			return errors.Wrapf(err, "%s is running on %s and left frozen here", req.ID, req.To)
+		}
+		return err
+	}
+	if !req.Delete {
+		return nil
+	}
+	return phase("delete", func() error {
+		_, err := a.delete(ctx, container.ID())
+		return err
+	})
+}
+
+func (a *Agent) Audit(ctx context.Context, req *v1.AuditRequest) (*v1.AuditResponse, error) {
+	var resp v1.AuditResponse
+	if err := a.audit.Read(req.Since, func(e *audit.Entry) error {
+		if req.ID != "" && e.ID != req.ID {
+			return nil
 		}
+		resp.Entries = append(resp.Entries, e.Proto())
+		return nil
+	}); err != nil {
+		return nil, err
 	}
-	return &v1.MigrateResponse{}, nil
+	return &resp, nil
 }
 
 var (
 	errServiceExistsOnTarget = errors.New("service exists on target")
+	errPreDumpNotLive        = errors.New("pre-dumps require a live migration")
+	errContainerExists       = errors.New("container exists")
 	errMediaTypeNotFound     = errors.New("media type not found in index")
+	errOverrideID            = errors.New("overrides cannot set the id, restore with an id instead")
 )
 
 func getByMediaType(index *is.Index, mt string) (*is.Descriptor, error) {
@@ -735,16 +1335,21 @@ func getByMediaType(index *is.Index, mt string) (*is.Descriptor, error) {
 }
 
 func withPlainRemote(ref string) containerd.RemoteOpt {
-	remote := strings.SplitN(ref, "/", 2)[0]
 	return func(_ *containerd.Client, ctx *containerd.RemoteContext) error {
-		ctx.Resolver = docker.NewResolver(docker.ResolverOptions{
-			PlainHTTP: plainRemotes[remote],
-			Client:    http.DefaultClient,
-		})
+		ctx.Resolver = resolver(ref)
 		return nil
 	}
 }
 
+// resolver for the ref's registry, using plain http for configured remotes
+func resolver(ref string) remotes.Resolver {
+	remote := strings.SplitN(ref, "/", 2)[0]
+	return docker.NewResolver(docker.ResolverOptions{
+		PlainHTTP: plainRemotes[remote],
+		Client:    http.DefaultClient,
+	})
+}
+
 func getBindSizes(c *v1.Container) (size int64, _ error) {
 	for _, m := range c.Mounts {
 		f, err := os.Open(m.Source)
@@ -779,11 +1384,29 @@ func getBindSizes(c *v1.Container) (size int64, _ error) {
 	return size, nil
 }
 
+// load the containerd container running the boss container.
+// Containers that do not use their boss id, like blue/green siblings, are found by their id label
+func (a *Agent) load(ctx context.Context, id string) (containerd.Container, error) {
+	container, err := a.client.LoadContainer(ctx, id)
+	if err == nil || !errdefs.IsNotFound(err) {
+		return container, err
+	}
+	containers, cerr := a.client.Containers(ctx, fmt.Sprintf("labels.%q==%q", opts.IDLabel, id))
+	if cerr != nil {
+		return nil, cerr
+	}
+	if len(containers) == 0 {
+		return nil, err
+	}
+	return containers[0], nil
+}
+
 func relayContext(ctx context.Context) context.Context {
 	return namespaces.WithNamespace(ctx, v1.DefaultNamespace)
 }
 
-func (a *Agent) writeIndex(ctx context.Context, index *is.Index, ref string) (d is.Descriptor, err error) {
+// writeIndex writes the index with gc references to each of its manifests
+func writeIndex(ctx context.Context, store content.Ingester, index *is.Index, ref string) (d is.Descriptor, err error) {
 	labels := map[string]string{}
 	for i, m := range index.Manifests {
 		labels[fmt.Sprintf("containerd.io/gc.ref.content.%d", i)] = m.Digest.String()
@@ -792,7 +1415,7 @@ func (a *Agent) writeIndex(ctx context.Context, index *is.Index, ref string) (d
 	if err != nil {
 		return is.Descriptor{}, err
 	}
-	return writeContent(ctx, a.client.ContentStore(), is.MediaTypeImageIndex, ref, bytes.NewReader(data), content.WithLabels(labels))
+	return writeContent(ctx, store, is.MediaTypeImageIndex, ref, bytes.NewReader(data), content.WithLabels(labels))
 }
 
 func writeContent(ctx context.Context, store content.Ingester, mediaType, ref string, r io.Reader, opts ...content.Opt) (d is.Descriptor, err error) {
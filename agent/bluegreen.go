@@ -0,0 +1,220 @@
+package agent
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/flux"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	strategyInPlace   = "in-place"
+	strategyBlueGreen = "blue-green"
+
+	defaultHealthTimeout = time.Minute
+	defaultDrain         = 10 * time.Second
+)
+
+var errBlueGreenNetwork = errors.New("blue/green updates require a cni network")
+
+// strategy returns the update strategy of the container config
+func strategy(c *v1.Container) (string, error) {
+	if c.Update == nil || c.Update.Strategy == "" {
+		return strategyInPlace, nil
+	}
+	switch c.Update.Strategy {
+	case strategyInPlace, strategyBlueGreen:
+		return c.Update.Strategy, nil
+	}
+	return "", errors.Errorf("unknown update strategy %q", c.Update.Strategy)
+}
+
+// blueGreen starts the new config as a sibling container with its own network namespace,
+// waits for it to pass its health checks, and then moves the services over to it and drains
+// and removes the old container.
+// The sibling is labeled with the boss id so it is still managed by it and services are
+// registered under the boss id so the sibling's registration replaces the old one
+func (a *Agent) blueGreen(ctx context.Context, old containerd.Container, current, next *v1.Container, diff *v1.UpdateDiff) (*v1.UpdateResponse, error) {
+	if !v1.IsCNI(next.Network) {
+		return nil, errBlueGreenNetwork
+	}
+	image, err := a.client.Pull(ctx, next.Image, containerd.WithPullUnpack, withPlainRemote(next.Image))
+	if err != nil {
+		return nil, err
+	}
+	labels, err := old.Labels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	o := []containerd.NewContainerOpts{
+		flux.WithNewSnapshot(image),
+		opts.WithBossConfig(a.c.Agent.VolumeRoot, next, image),
+		// the config is kept for rollbacks as the old container's revisions are removed with it
+		containerd.WithContainerExtension(opts.LastConfig, current),
+		opts.WithID(next.ID),
+		// the sibling's unit must not register its services before it is healthy
+		opts.WithUnregistered,
+	}
+	if group := labels[opts.GroupLabel]; group != "" {
+		replica, err := strconv.Atoi(labels[opts.ReplicaLabel])
+		if err != nil {
+			return nil, err
+		}
+		o = append(o, opts.WithReplica(group, replica))
+	}
+	id := sibling(old.ID(), next.ID)
+	op, err := a.journal.begin(id, opCreate, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.create(ctx, op, next, func() error {
+		_, err := a.client.NewContainer(ctx, id, o...)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	container, err := a.client.LoadContainer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := waitHealthy(ctx, container, next, healthTimeout(next)); err != nil {
+		if _, derr := a.delete(ctx, id); derr != nil {
+			logrus.WithError(derr).Errorf("delete unhealthy container %s", id)
+		}
+		return nil, errors.Wrapf(err, "%s did not become healthy", id)
+	}
+	if err := a.takeOver(ctx, old, container, current, next); err != nil {
+		if _, derr := a.delete(ctx, id); derr != nil {
+			logrus.WithError(derr).Errorf("delete container %s", id)
+		}
+		return nil, err
+	}
+	drain := defaultDrain
+	if next.Update != nil {
+		drain = seconds(next.Update.Drain, defaultDrain)
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(drain):
+	}
+	if _, err := a.delete(ctx, old.ID()); err != nil {
+		return nil, errors.Wrapf(err, "delete %s", old.ID())
+	}
+	return &v1.UpdateResponse{
+		Diff: diff,
+	}, nil
+}
+
+// takeOver registers the services of the healthy sibling, replacing the registrations of the
+// old container which stops registering them, and deregisters the services the sibling does not have
+func (a *Agent) takeOver(ctx context.Context, old, container containerd.Container, current, next *v1.Container) (err error) {
+	if err := old.Update(ctx, opts.WithUnregistered); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if uerr := container.Update(ctx, opts.WithUnregistered); uerr != nil {
+				logrus.WithError(uerr).Errorf("unregister services of %s", container.ID())
+			}
+			if uerr := old.Update(ctx, opts.WithRegistered); uerr != nil {
+				logrus.WithError(uerr).Errorf("register services of %s", old.ID())
+			}
+			// the sibling is deleted so its registrations are moved back to the old container
+			if rerr := a.registerOld(ctx, old, current, next); rerr != nil {
+				logrus.WithError(rerr).Errorf("register services of %s", old.ID())
+			}
+		}
+	}()
+	if err := container.Update(ctx, opts.WithRegistered); err != nil {
+		return err
+	}
+	labels, err := container.Labels(ctx)
+	if err != nil {
+		return err
+	}
+	for name, srv := range next.Services {
+		ips, err := opts.ServiceIPs(labels, srv)
+		if err != nil {
+			return err
+		}
+		if err := a.register.Register(next.ID, name, ips, srv); err != nil {
+			return err
+		}
+		if err := a.register.DisableMaintainance(next.ID, name); err != nil {
+			return err
+		}
+	}
+	for name := range current.Services {
+		if _, ok := next.Services[name]; ok {
+			continue
+		}
+		if err := a.register.Deregister(current.ID, name); err != nil {
+			logrus.WithError(err).Errorf("de-register %s-%s", current.ID, name)
+		}
+	}
+	return nil
+}
+
+// registerOld registers the old container's services at its ips again
+// and deregisters the services only the next config added
+func (a *Agent) registerOld(ctx context.Context, old containerd.Container, current, next *v1.Container) error {
+	labels, err := old.Labels(ctx)
+	if err != nil {
+		return err
+	}
+	for name, srv := range current.Services {
+		ips, err := opts.ServiceIPs(labels, srv)
+		if err != nil {
+			return err
+		}
+		if err := a.register.Register(current.ID, name, ips, srv); err != nil {
+			return err
+		}
+	}
+	for name := range next.Services {
+		if _, ok := current.Services[name]; ok {
+			continue
+		}
+		if err := a.register.Deregister(next.ID, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollbackBlueGreen updates the container back to the config it was updated from,
+// blue/green updates replace the container so there is no previous revision of it to roll back to
+func (a *Agent) rollbackBlueGreen(ctx context.Context, container containerd.Container, current *v1.Container) error {
+	prev, err := opts.GetLastConfig(ctx, container)
+	if err != nil {
+		return err
+	}
+	if prev == nil {
+		return errors.Errorf("%s has no previous config to roll back to", current.ID)
+	}
+	_, err = a.blueGreen(ctx, container, current, prev, nil)
+	return err
+}
+
+// sibling returns the containerd id for the next blue/green container
+func sibling(current, id string) string {
+	if strings.HasSuffix(current, ".green") {
+		return id + ".blue"
+	}
+	return id + ".green"
+}
+
+func seconds(s int64, d time.Duration) time.Duration {
+	if s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return d
+}
@@ -31,11 +31,13 @@ func (c *imageUpdateChange) update(ctx context.Context, container containerd.Con
 
 type deregisterChange struct {
 	register v1.Register
-	name     string
+	// id is the boss id that the services are registered under
+	id   string
+	name string
 }
 
 func (c *deregisterChange) update(ctx context.Context, container containerd.Container) error {
-	return c.register.Deregister(container.ID(), c.name)
+	return c.register.Deregister(c.id, c.name)
 }
 
 type configChange struct {
@@ -69,6 +71,14 @@ func pauseAndRun(ctx context.Context, container containerd.Container, fn func()
 		}
 		return err
 	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		return err
+	}
+	// a task frozen by the caller is left frozen
+	if status.Status == containerd.Paused {
+		return fn()
+	}
 	if err := task.Pause(ctx); err != nil {
 		return err
 	}
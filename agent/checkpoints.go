@@ -0,0 +1,310 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/images/oci"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/auth"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/gogo/protobuf/types"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// AnnotationCheckpointNode is the id of the node a checkpoint was taken on
+const AnnotationCheckpointNode = "io.boss.checkpoint.node"
+
+// importPrefix names imported images until they are validated
+const importPrefix = "boss-import/"
+
+var errNotCheckpoint = errors.New("image is not a checkpoint")
+
+func (a *Agent) ListCheckpoints(ctx context.Context, req *v1.ListCheckpointsRequest) (*v1.ListCheckpointsResponse, error) {
+	ctx = relayContext(ctx)
+	list, err := a.client.ImageService().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resp v1.ListCheckpointsResponse
+	for _, i := range list {
+		if strings.HasPrefix(i.Name, importPrefix) {
+			continue
+		}
+		info, err := a.checkpointInfo(ctx, i)
+		if err != nil {
+			if errors.Cause(err) == errNotCheckpoint {
+				continue
+			}
+			return nil, errors.Wrapf(err, "checkpoint %s", i.Name)
+		}
+		if req.ID != "" && info.ID != req.ID {
+			continue
+		}
+		// only the checkpoints of containers the caller may list are returned
+		if err := auth.Container(ctx, info.ID); err != nil {
+			continue
+		}
+		info.Config = nil
+		resp.Checkpoints = append(resp.Checkpoints, info)
+	}
+	sort.Slice(resp.Checkpoints, func(i, j int) bool {
+		return resp.Checkpoints[i].Created.After(resp.Checkpoints[j].Created)
+	})
+	return &resp, nil
+}
+
+func (a *Agent) InspectCheckpoint(ctx context.Context, req *v1.InspectCheckpointRequest) (*v1.InspectCheckpointResponse, error) {
+	ctx = relayContext(ctx)
+	if req.Ref == "" {
+		return nil, ErrNoRef
+	}
+	image, err := a.client.ImageService().Get(ctx, req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	info, err := a.checkpointInfo(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.Container(ctx, info.ID); err != nil {
+		return nil, err
+	}
+	return &v1.InspectCheckpointResponse{
+		Checkpoint: info,
+	}, nil
+}
+
+func (a *Agent) DeleteCheckpoint(ctx context.Context, req *v1.DeleteCheckpointRequest) (*types.Empty, error) {
+	ctx = relayContext(ctx)
+	if req.Ref == "" {
+		return nil, ErrNoRef
+	}
+	image, err := a.client.ImageService().Get(ctx, req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	info, err := a.checkpointInfo(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.Container(ctx, info.ID); err != nil {
+		return nil, err
+	}
+	return empty, a.client.ImageService().Delete(ctx, req.Ref)
+}
+
+// ExportCheckpoint streams the checkpoint as an oci image layout tar
+func (a *Agent) ExportCheckpoint(req *v1.ExportCheckpointRequest, stream v1.Agent_ExportCheckpointServer) error {
+	ctx := relayContext(stream.Context())
+	if req.Ref == "" {
+		return ErrNoRef
+	}
+	image, err := a.client.ImageService().Get(ctx, req.Ref)
+	if err != nil {
+		return err
+	}
+	info, err := a.checkpointInfo(ctx, image)
+	if err != nil {
+		return err
+	}
+	if err := auth.Container(ctx, info.ID); err != nil {
+		return err
+	}
+	desc := image.Target
+	desc.Annotations = map[string]string{
+		is.AnnotationRefName: req.Ref,
+	}
+	r, err := a.client.Export(ctx, desc, oci.WithAllPlatforms(true))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	buf := make([]byte, exportChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&v1.ExportCheckpointResponse{
+				Data: buf[:n],
+			}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ImportCheckpoint loads the checkpoints of an oci image layout tar.
+// The images are imported under temporary names and only named by their refs
+// once all of them are checkpoints the caller may import
+func (a *Agent) ImportCheckpoint(stream v1.Agent_ImportCheckpointServer) error {
+	ctx := relayContext(stream.Context())
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	var (
+		prefix = fmt.Sprintf("%s%d/", importPrefix, time.Now().UnixNano())
+		refs   = make(map[string]string)
+	)
+	defer func() {
+		for temp := range refs {
+			if err := a.client.ImageService().Delete(ctx, temp); err != nil && !errdefs.IsNotFound(err) {
+				logrus.WithError(err).Errorf("delete imported image %s", temp)
+			}
+		}
+	}()
+	imported, err := a.client.Import(ctx, &importReader{
+		stream: stream,
+		data:   req.Data,
+	}, containerd.WithImageRefTranslator(func(ref string) string {
+		if req.Ref != "" {
+			ref = req.Ref
+		}
+		temp := prefix + strconv.Itoa(len(refs))
+		refs[temp] = ref
+		return temp
+	}))
+	if err != nil {
+		return err
+	}
+	if req.Ref != "" && len(imported) > 1 {
+		return errors.Errorf("layout has %d images, a ref can only name one", len(imported))
+	}
+	for _, i := range imported {
+		info, err := a.checkpointInfo(ctx, i)
+		if err != nil {
+			return err
+		}
+		if err := auth.Container(ctx, info.ID); err != nil {
+			return err
+		}
+		// replacing a checkpoint requires access to the container it belongs to
+		if err := a.authorizeCheckpoint(ctx, refs[i.Name]); err != nil {
+			return err
+		}
+	}
+	var resp v1.ImportCheckpointResponse
+	for _, i := range imported {
+		ref := refs[i.Name]
+		if err := a.saveImage(ctx, ref, i.Target); err != nil {
+			return err
+		}
+		resp.Refs = append(resp.Refs, ref)
+	}
+	return stream.SendAndClose(&resp)
+}
+
+// authorizeCheckpoint checks that the caller may operate on the container of an existing checkpoint
+func (a *Agent) authorizeCheckpoint(ctx context.Context, ref string) error {
+	image, err := a.client.ImageService().Get(ctx, ref)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	info, err := a.checkpointInfo(ctx, image)
+	if err != nil {
+		if errors.Cause(err) == errNotCheckpoint {
+			return nil
+		}
+		return err
+	}
+	return auth.Container(ctx, info.ID)
+}
+
+// importReader reads the data of an import stream
+type importReader struct {
+	stream v1.Agent_ImportCheckpointServer
+	data   []byte
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.data = req.Data
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// checkpointInfo returns the info of a checkpoint image, errNotCheckpoint is returned for other images
+func (a *Agent) checkpointInfo(ctx context.Context, image images.Image) (*v1.CheckpointInfo, error) {
+	if image.Target.MediaType != is.MediaTypeImageIndex {
+		return nil, errors.Wrap(errNotCheckpoint, image.Name)
+	}
+	store := a.client.ContentStore()
+	index, err := decodeIndex(ctx, store, image.Target)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, errors.Wrap(errNotCheckpoint, image.Name)
+		}
+		return nil, err
+	}
+	desc, err := getByMediaType(index, MediaTypeContainerInfo)
+	if err != nil {
+		return nil, errors.Wrap(errNotCheckpoint, image.Name)
+	}
+	data, err := content.ReadBlob(ctx, store, *desc)
+	if err != nil {
+		return nil, err
+	}
+	var c containers.Container
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	config, err := opts.GetConfigFromInfo(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	version, err := checkpointVersion(index)
+	if err != nil {
+		return nil, err
+	}
+	info := &v1.CheckpointInfo{
+		Ref:     image.Name,
+		ID:      config.ID,
+		Node:    index.Annotations[AnnotationCheckpointNode],
+		Created: image.CreatedAt,
+		Size_:   image.Target.Size,
+		Config:  config,
+		Version: version,
+	}
+	for _, m := range index.Manifests {
+		info.Size_ += m.Size
+		switch m.MediaType {
+		case is.MediaTypeImageLayer, is.MediaTypeImageLayerGzip, MediaTypeImageLayerZstd:
+			info.RwSize = m.Size
+		case images.MediaTypeContainerd1Checkpoint:
+			info.Live = true
+		case images.MediaTypeContainerd1CheckpointPreDump:
+			info.PreDumps++
+		case MediaTypeVolumeLayer:
+			info.Volumes = append(info.Volumes, m.Annotations[AnnotationVolumeID])
+		}
+	}
+	return info, nil
+}
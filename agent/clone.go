@@ -0,0 +1,202 @@
+package agent
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/containerd/containerd/content"
+	"github.com/crosbymichael/boss/api/v1"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// criuUTSPrefix is the name prefix of the criu images holding a uts namespace
+	criuUTSPrefix = "utsns-"
+	// criuHeaderSize is the size of the magic numbers at the start of a criu image
+	criuHeaderSize = 8
+	// utsNodename is the protobuf field of the hostname in a uts namespace entry
+	utsNodename = 1
+)
+
+// overrideConfig applies the restore request's id, network and overrides to the checkpointed config.
+// Only the fields set in the overrides are merged, so a bool cannot be overridden to false
+func overrideConfig(config *v1.Container, req *v1.RestoreRequest) error {
+	if req.Overrides != nil {
+		// the restored id is authorized from the request's id
+		if req.Overrides.ID != "" {
+			return errOverrideID
+		}
+		data, err := json.Marshal(req.Overrides)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, config); err != nil {
+			return errors.Wrap(err, "apply overrides")
+		}
+	}
+	if req.ID != "" {
+		config.ID = req.ID
+	}
+	if req.Network != "" {
+		config.Network = req.Network
+	}
+	return nil
+}
+
+// volumeMapping maps the volumes of the checkpointed config to the volumes mounted at the
+// same destination by the restored config, so that overridden volumes receive the checkpointed data
+func volumeMapping(from, to *v1.Container) map[string]string {
+	destinations := make(map[string]string)
+	for _, v := range to.Volumes {
+		destinations[v.Destination] = v.ID
+	}
+	ids := make(map[string]string)
+	for _, v := range from.Volumes {
+		if id, ok := destinations[v.Destination]; ok {
+			ids[v.ID] = id
+		}
+	}
+	return ids
+}
+
+// withHostname returns the live checkpoint with the hostname of its uts namespace replaced,
+// criu restores the namespace as it was dumped so a clone would otherwise keep its source's hostname.
+// The rewritten checkpoint is referenced from the checkpoint's index so that it is kept with the checkpoint
+func (a *Agent) withHostname(ctx context.Context, target, checkpoint is.Descriptor, hostname string) (*is.Descriptor, error) {
+	ctx, done, err := a.client.WithLease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done(ctx)
+	store := a.client.ContentStore()
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(rewriteHostname(ctx, store, checkpoint, hostname, w))
+	}()
+	desc, err := writeContent(ctx, store, checkpoint.MediaType, checkpoint.Digest.String()+"-"+hostname, r)
+	r.CloseWithError(err)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.keepWithCheckpoint(ctx, target, "restore."+hostname, desc); err != nil {
+		return nil, err
+	}
+	return &desc, nil
+}
+
+// keepWithCheckpoint adds a gc reference from the checkpoint's index to a blob derived from it
+func (a *Agent) keepWithCheckpoint(ctx context.Context, target is.Descriptor, key string, desc is.Descriptor) error {
+	label := "containerd.io/gc.ref.content." + key
+	_, err := a.client.ContentStore().Update(ctx, content.Info{
+		Digest: target.Digest,
+		Labels: map[string]string{
+			label: desc.Digest.String(),
+		},
+	}, "labels."+label)
+	return err
+}
+
+func rewriteHostname(ctx context.Context, store content.Provider, desc is.Descriptor, hostname string, w io.Writer) error {
+	ra, err := store.ReaderAt(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+	tr := tar.NewReader(content.NewReader(ra))
+	tw := tar.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return tw.Close()
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(hdr.Name)
+		if hdr.Typeflag != tar.TypeReg || path.Dir(name) != "." || !strings.HasPrefix(name, criuUTSPrefix) {
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if data, err = setNodename(data, hostname); err != nil {
+			return errors.Wrap(err, name)
+		}
+		hdr.Size = int64(len(data))
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+}
+
+// setNodename replaces the hostname in a criu uts namespace image, the image is the magic numbers
+// followed by the size and protobuf encoding of an entry with the nodename and domainname strings
+func setNodename(data []byte, hostname string) ([]byte, error) {
+	if len(data) < criuHeaderSize+4 {
+		return nil, errors.New("short uts namespace image")
+	}
+	size := int(binary.LittleEndian.Uint32(data[criuHeaderSize:]))
+	start := criuHeaderSize + 4
+	if size > len(data)-start {
+		return nil, errors.New("truncated uts namespace entry")
+	}
+	var (
+		in    = data[start : start+size]
+		out   []byte
+		found bool
+	)
+	for len(in) > 0 {
+		key, n := binary.Uvarint(in)
+		if n <= 0 {
+			return nil, errors.New("invalid uts namespace field")
+		}
+		in = in[n:]
+		if key&7 != 2 {
+			return nil, errors.Errorf("unexpected wire type %d in uts namespace entry", key&7)
+		}
+		l, n := binary.Uvarint(in)
+		if n <= 0 || l > uint64(len(in)-n) {
+			return nil, errors.New("invalid uts namespace field")
+		}
+		value := in[n : n+int(l)]
+		in = in[n+int(l):]
+		if key>>3 == utsNodename {
+			value = []byte(hostname)
+			found = true
+		}
+		out = appendField(out, key, value)
+	}
+	// the nodename is required in the entry, every occurrence is replaced as the last one wins
+	if !found {
+		return nil, errors.New("no nodename in uts namespace entry")
+	}
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(len(out)))
+	rewritten := append(append([]byte{}, data[:criuHeaderSize]...), b[:]...)
+	rewritten = append(rewritten, out...)
+	return append(rewritten, data[start+size:]...), nil
+}
+
+func appendField(out []byte, key uint64, value []byte) []byte {
+	var b [binary.MaxVarintLen64]byte
+	out = append(out, b[:binary.PutUvarint(b[:], key)]...)
+	out = append(out, b[:binary.PutUvarint(b[:], uint64(len(value)))]...)
+	return append(out, value...)
+}
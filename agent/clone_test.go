@@ -0,0 +1,147 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+type utsField struct {
+	key   uint64
+	value string
+}
+
+// utsImage returns a criu uts namespace image with the fields encoded in order
+func utsImage(fields ...utsField) []byte {
+	var entry []byte
+	for _, f := range fields {
+		entry = appendField(entry, f.key<<3|2, []byte(f.value))
+	}
+	data := []byte{0x54, 0x8f, 0x59, 0x2a, 0x97, 0x5c, 0x57, 0x58}
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(len(entry)))
+	data = append(data, b[:]...)
+	return append(data, entry...)
+}
+
+func TestSetNodename(t *testing.T) {
+	const domainname = 2
+	var (
+		long     = strings.Repeat("a", 200)
+		longer   = strings.Repeat("b", 300)
+		trailing = []byte{0xde, 0xad}
+	)
+	for _, tc := range []struct {
+		name     string
+		data     []byte
+		hostname string
+		expected []byte
+	}{
+		{
+			name:     "shorter",
+			data:     utsImage(utsField{utsNodename, "redis-primary"}, utsField{domainname, "(none)"}),
+			hostname: "redis",
+			expected: utsImage(utsField{utsNodename, "redis"}, utsField{domainname, "(none)"}),
+		},
+		{
+			name:     "longer",
+			data:     utsImage(utsField{utsNodename, "redis"}, utsField{domainname, "(none)"}),
+			hostname: "redis-primary",
+			expected: utsImage(utsField{utsNodename, "redis-primary"}, utsField{domainname, "(none)"}),
+		},
+		{
+			name:     "equal",
+			data:     utsImage(utsField{utsNodename, "redis-1"}, utsField{domainname, "(none)"}),
+			hostname: "redis-2",
+			expected: utsImage(utsField{utsNodename, "redis-2"}, utsField{domainname, "(none)"}),
+		},
+		{
+			name:     "multi-byte length to single byte",
+			data:     utsImage(utsField{utsNodename, long}, utsField{domainname, "(none)"}),
+			hostname: "redis",
+			expected: utsImage(utsField{utsNodename, "redis"}, utsField{domainname, "(none)"}),
+		},
+		{
+			name:     "single byte length to multi-byte",
+			data:     utsImage(utsField{utsNodename, "redis"}, utsField{domainname, long}),
+			hostname: longer,
+			expected: utsImage(utsField{utsNodename, longer}, utsField{domainname, long}),
+		},
+		{
+			name:     "multi-byte lengths",
+			data:     utsImage(utsField{domainname, long}, utsField{utsNodename, long}),
+			hostname: longer,
+			expected: utsImage(utsField{domainname, long}, utsField{utsNodename, longer}),
+		},
+		{
+			name:     "duplicate nodename",
+			data:     utsImage(utsField{utsNodename, "redis"}, utsField{domainname, "(none)"}, utsField{utsNodename, "redis-primary"}),
+			hostname: "clone",
+			expected: utsImage(utsField{utsNodename, "clone"}, utsField{domainname, "(none)"}, utsField{utsNodename, "clone"}),
+		},
+		{
+			name:     "trailing data",
+			data:     append(utsImage(utsField{utsNodename, "redis"}), trailing...),
+			hostname: "redis-primary",
+			expected: append(utsImage(utsField{utsNodename, "redis-primary"}), trailing...),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := setNodename(tc.data, tc.hostname)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, tc.expected) {
+				t.Errorf("rewritten image %x != %x", data, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSetNodenameErrors(t *testing.T) {
+	image := utsImage(utsField{utsNodename, "redis"})
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "missing nodename",
+			data: utsImage(utsField{2, "(none)"}),
+		},
+		{
+			name: "empty entry",
+			data: utsImage(),
+		},
+		{
+			name: "short image",
+			data: image[:criuHeaderSize+2],
+		},
+		{
+			name: "truncated entry",
+			data: image[:len(image)-1],
+		},
+		{
+			name: "truncated field",
+			data: func() []byte {
+				data := append([]byte{}, image...)
+				data[criuHeaderSize+4+1] = 0x7f
+				return data
+			}(),
+		},
+		{
+			name: "varint field",
+			data: func() []byte {
+				data := append([]byte{}, image...)
+				data[criuHeaderSize+4] = utsNodename << 3
+				return data
+			}(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := setNodename(tc.data, "clone"); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/containerd/containerd"
+	"github.com/crosbymichael/boss/api/v1"
+)
+
+// diff returns what an update from the current config to the new one changes
+func (a *Agent) diff(ctx context.Context, container containerd.Container, current, next *v1.Container) (*v1.UpdateDiff, error) {
+	changes, err := diffContainers(current, next)
+	if err != nil {
+		return nil, err
+	}
+	var d v1.UpdateDiff
+	d.Changes = changes
+	info, err := container.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if image, err := a.client.GetImage(ctx, info.Image); err == nil {
+		d.ImageDigest = image.Target().Digest.String()
+	}
+	d.NewImageDigest = d.ImageDigest
+	// only a changed image is resolved, an update of the same ref still pulls it when applied
+	if next.Image != current.Image {
+		_, desc, err := resolver(next.Image).Resolve(ctx, next.Image)
+		if err != nil {
+			return nil, err
+		}
+		d.NewImageDigest = desc.Digest.String()
+	}
+	for name := range current.Services {
+		if _, ok := next.Services[name]; !ok {
+			d.Deregister = append(d.Deregister, name)
+		}
+	}
+	sort.Strings(d.Deregister)
+	// updates save the rw layer into a new revision and restart the task
+	// so that image and config rollbacks stay in step
+	d.NewRevision = len(d.Changes) > 0 || d.ImageDigest != d.NewImageDigest
+	if d.NewRevision && !v1.IsJob(next) {
+		if d.Restart, err = isRunning(ctx, container); err != nil {
+			return nil, err
+		}
+	}
+	return &d, nil
+}
+
+// diffContainers compares the flattened fields of the two containers
+func diffContainers(current, next *v1.Container) ([]*v1.FieldChange, error) {
+	o, err := flatten(current)
+	if err != nil {
+		return nil, err
+	}
+	n, err := flatten(next)
+	if err != nil {
+		return nil, err
+	}
+	var changes []*v1.FieldChange
+	for path, v := range o {
+		if nv, ok := n[path]; !ok || nv != v {
+			changes = append(changes, &v1.FieldChange{
+				Path: path,
+				Old:  v,
+				New:  nv,
+			})
+		}
+	}
+	for path, v := range n {
+		if _, ok := o[path]; !ok {
+			changes = append(changes, &v1.FieldChange{
+				Path: path,
+				New:  v,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// flatten returns the json values of the container by field path
+func flatten(c *v1.Container) (map[string]string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	out := make(map[string]string)
+	if err := flattenValue("", v, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func flattenValue(path string, v interface{}, out map[string]string) error {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, vv := range t {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			if err := flattenValue(p, vv, out); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, vv := range t {
+			if err := flattenValue(fmt.Sprintf("%s[%d]", path, i), vv, out); err != nil {
+				return err
+			}
+		}
+	default:
+		data, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		out[path] = string(data)
+	}
+	return nil
+}
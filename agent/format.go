@@ -0,0 +1,116 @@
+package agent
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/klauspost/compress/zstd"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AnnotationCheckpointVersion is the format version of a checkpoint's index,
+	// checkpoints written before the format was versioned are version 0
+	AnnotationCheckpointVersion = "io.boss.checkpoint.version"
+	// CheckpointVersion is the newest checkpoint format this agent writes and restores
+	CheckpointVersion = 1
+
+	// MediaTypeImageLayerZstd is a zstd compressed rw layer, it is decompressed by the agent as containerd's diff service cannot apply it
+	MediaTypeImageLayerZstd = "application/vnd.oci.image.layer.v1.tar+zstd"
+)
+
+// rwLayerTypes are the media types accepted for a checkpoint's rw layer
+var rwLayerTypes = map[string]bool{
+	is.MediaTypeImageLayer:     true,
+	is.MediaTypeImageLayerGzip: true,
+	MediaTypeImageLayerZstd:    true,
+}
+
+// checkpointVersion returns the format version of the checkpoint
+func checkpointVersion(index *is.Index) (int64, error) {
+	v, ok := index.Annotations[AnnotationCheckpointVersion]
+	if !ok {
+		return 0, nil
+	}
+	version, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || version < 0 {
+		return 0, errors.Errorf("invalid checkpoint version %q", v)
+	}
+	return version, nil
+}
+
+// validateVersion returns an error for checkpoints written in a newer format than this agent supports
+func validateVersion(index *is.Index) error {
+	version, err := checkpointVersion(index)
+	if err != nil {
+		return err
+	}
+	if version > CheckpointVersion {
+		return errors.Errorf("checkpoint version %d is newer than the supported version %d", version, CheckpointVersion)
+	}
+	return nil
+}
+
+// rwLayer returns the checkpoint's rw layer, uncompressed or gzip or zstd compressed
+func rwLayer(index *is.Index) (*is.Descriptor, error) {
+	for _, d := range index.Manifests {
+		if rwLayerTypes[d.MediaType] {
+			return &d, nil
+		}
+	}
+	return nil, errors.Wrap(errMediaTypeNotFound, "rw layer")
+}
+
+// decompressZstd writes the zstd compressed rw layer uncompressed to the store so that the diff
+// service can apply it, the caller must hold a lease until the returned layer is applied
+func decompressZstd(ctx context.Context, store interface {
+	content.Provider
+	content.Ingester
+}, desc is.Descriptor) (is.Descriptor, error) {
+	ra, err := store.ReaderAt(ctx, desc)
+	if err != nil {
+		return is.Descriptor{}, err
+	}
+	defer ra.Close()
+	zr, err := zstd.NewReader(content.NewReader(ra))
+	if err != nil {
+		return is.Descriptor{}, err
+	}
+	defer zr.Close()
+	return writeContent(ctx, store, is.MediaTypeImageLayer, desc.Digest.String()+"-uncompressed", zr)
+}
+
+// validatePlatform checks that the checkpoint can be restored on this node's platform.
+// Criu state only restores on the platform it was dumped on, while the rw layer of a
+// filesystem checkpoint may only hold data so restoring it elsewhere is logged
+func validatePlatform(index *is.Index, live bool) error {
+	var (
+		matcher  = platforms.NewMatcher(platforms.DefaultSpec())
+		mismatch *is.Platform
+	)
+	for _, d := range index.Manifests {
+		if d.Platform == nil || matcher.Match(*d.Platform) {
+			continue
+		}
+		switch d.MediaType {
+		case images.MediaTypeContainerd1Checkpoint, images.MediaTypeContainerd1CheckpointPreDump:
+			if live {
+				return errors.Errorf("checkpoint taken on %s cannot be restored live on %s", platforms.Format(*d.Platform), platforms.DefaultString())
+			}
+		default:
+			mismatch = d.Platform
+		}
+	}
+	if mismatch != nil {
+		logrus.WithFields(logrus.Fields{
+			"checkpoint": platforms.Format(*mismatch),
+			"node":       platforms.DefaultString(),
+		}).Warn("restoring checkpoint taken on another platform")
+	}
+	return nil
+}
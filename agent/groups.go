@@ -0,0 +1,492 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/auth"
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// groupLock is the lock prefix for group operations so they do not collide with container ids
+const groupLock = "group/"
+
+// groups stores the definitions of container groups so they can be updated and deleted as a whole
+type groups struct {
+	root string
+}
+
+func (g *groups) path(id string) string {
+	return filepath.Join(g.root, id+".json")
+}
+
+func (g *groups) get(id string) (*v1.Group, error) {
+	data, err := ioutil.ReadFile(g.path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(errdefs.ErrNotFound, "group %s", id)
+		}
+		return nil, err
+	}
+	var group v1.Group
+	if err := json.Unmarshal(data, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (g *groups) list() ([]*v1.Group, error) {
+	files, err := ioutil.ReadDir(g.root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []*v1.Group
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		group, err := g.get(strings.TrimSuffix(f.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, group)
+	}
+	return out, nil
+}
+
+func (g *groups) save(group *v1.Group) error {
+	data, err := json.Marshal(group)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(g.root, 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(g.root, ".group")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), g.path(group.ID))
+}
+
+func (g *groups) remove(id string) error {
+	if err := os.Remove(g.path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// groupOrder returns the members of the group in start order.
+// Members start after the members they list in after and otherwise in the order they are declared
+func groupOrder(g *v1.Group) ([]*v1.GroupMember, error) {
+	if g == nil || g.ID == "" {
+		return nil, ErrNoID
+	}
+	members := make(map[string]*v1.GroupMember)
+	for _, m := range g.Members {
+		if m.Container == nil || m.Container.ID == "" {
+			return nil, errors.Errorf("group %s has a member without an id", g.ID)
+		}
+		if _, ok := members[m.Container.ID]; ok {
+			return nil, errors.Errorf("duplicate member %s in group %s", m.Container.ID, g.ID)
+		}
+		members[m.Container.ID] = m
+	}
+	for _, m := range g.Members {
+		for _, dep := range m.After {
+			if _, ok := members[dep]; !ok {
+				return nil, errors.Errorf("%s starts after %s which is not in group %s", m.Container.ID, dep, g.ID)
+			}
+		}
+	}
+	var (
+		order   []*v1.GroupMember
+		visited = make(map[string]bool)
+		visit   func(m *v1.GroupMember, path []string) error
+	)
+	visit = func(m *v1.GroupMember, path []string) error {
+		id := m.Container.ID
+		for _, p := range path {
+			if p == id {
+				return errors.Errorf("members of group %s start after each other: %s", g.ID, strings.Join(append(path, id), " -> "))
+			}
+		}
+		if visited[id] {
+			return nil
+		}
+		for _, dep := range m.After {
+			if err := visit(members[dep], append(path, id)); err != nil {
+				return err
+			}
+		}
+		visited[id] = true
+		order = append(order, m)
+		return nil
+	}
+	for _, m := range g.Members {
+		if err := visit(m, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+func (a *Agent) CreateGroup(ctx context.Context, req *v1.CreateGroupRequest) (*types.Empty, error) {
+	ctx = relayContext(ctx)
+	order, err := groupOrder(req.Group)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeMembers(ctx, req.Group); err != nil {
+		return nil, err
+	}
+	unlock, err := a.locks.lock(ctx, groupLock+req.Group.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if _, err := a.groups.get(req.Group.ID); err == nil {
+		return nil, errors.Errorf("group %s already exists", req.Group.ID)
+	} else if !errdefs.IsNotFound(err) {
+		return nil, err
+	}
+	var created []string
+	for _, m := range order {
+		if err := a.createMember(ctx, req.Group, m); err != nil {
+			a.rollbackGroup(ctx, created, nil)
+			return nil, err
+		}
+		created = append(created, m.Container.ID)
+	}
+	return empty, a.groups.save(req.Group)
+}
+
+func (a *Agent) UpdateGroup(ctx context.Context, req *v1.UpdateGroupRequest) (*v1.UpdateGroupResponse, error) {
+	ctx = relayContext(ctx)
+	order, err := groupOrder(req.Group)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := a.locks.lock(ctx, groupLock+req.Group.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	current, err := a.groups.get(req.Group.ID)
+	if err != nil {
+		return nil, err
+	}
+	// removed members are deleted so they are authorized with the new ones
+	if err := authorizeMembers(ctx, req.Group, current); err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for _, m := range current.Members {
+		existing[m.Container.ID] = true
+	}
+	var (
+		resp = &v1.UpdateGroupResponse{
+			Diffs: make(map[string]*v1.UpdateDiff),
+		}
+		created, updated []string
+	)
+	for _, m := range order {
+		id := m.Container.ID
+		if !existing[id] {
+			resp.Created = append(resp.Created, id)
+			if req.DryRun {
+				continue
+			}
+			if err := a.createMember(ctx, req.Group, m); err != nil {
+				a.rollbackGroup(ctx, created, updated)
+				return nil, err
+			}
+			created = append(created, id)
+			continue
+		}
+		if !req.DryRun {
+			if err := a.waitMembers(ctx, req.Group, m.After); err != nil {
+				a.rollbackGroup(ctx, created, updated)
+				return nil, errors.Wrapf(err, "update %s", id)
+			}
+		}
+		r, err := a.Update(ctx, &v1.UpdateRequest{
+			Container: m.Container,
+			DryRun:    req.DryRun,
+		})
+		if err != nil {
+			a.rollbackGroup(ctx, created, updated)
+			return nil, errors.Wrapf(err, "update %s", id)
+		}
+		if r == nil || r.Diff == nil {
+			continue
+		}
+		resp.Diffs[id] = r.Diff
+		if r.Diff.NewRevision && !req.DryRun {
+			updated = append(updated, id)
+		}
+	}
+	keep := make(map[string]bool)
+	for _, m := range req.Group.Members {
+		keep[m.Container.ID] = true
+	}
+	group := *req.Group
+	// members removed from the group are deleted last, in reverse start order.
+	// Members that fail to delete stay in the group so that they are retried
+	removed, err := groupOrder(current)
+	if err != nil {
+		return nil, err
+	}
+	var derr error
+	for i := len(removed) - 1; i >= 0; i-- {
+		m := removed[i]
+		if keep[m.Container.ID] {
+			continue
+		}
+		resp.Deleted = append(resp.Deleted, m.Container.ID)
+		if req.DryRun {
+			continue
+		}
+		if err := a.deleteMember(ctx, m.Container.ID); err != nil {
+			if derr == nil {
+				derr = errors.Wrapf(err, "delete %s", m.Container.ID)
+			}
+			group.Members = append(group.Members, &v1.GroupMember{
+				Container: m.Container,
+			})
+		}
+	}
+	if req.DryRun {
+		return resp, nil
+	}
+	if err := a.groups.save(&group); err != nil {
+		return nil, err
+	}
+	if derr != nil {
+		return nil, derr
+	}
+	return resp, nil
+}
+
+func (a *Agent) DeleteGroup(ctx context.Context, req *v1.DeleteGroupRequest) (*types.Empty, error) {
+	ctx = relayContext(ctx)
+	if req.ID == "" {
+		return nil, ErrNoID
+	}
+	unlock, err := a.locks.lock(ctx, groupLock+req.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	group, err := a.groups.get(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeMembers(ctx, group); err != nil {
+		return nil, err
+	}
+	order, err := groupOrder(group)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		remaining []*v1.GroupMember
+		derr      error
+	)
+	for i := len(order) - 1; i >= 0; i-- {
+		m := order[i]
+		if err := a.deleteMember(ctx, m.Container.ID); err != nil {
+			if derr == nil {
+				derr = errors.Wrapf(err, "delete %s", m.Container.ID)
+			}
+			remaining = append(remaining, &v1.GroupMember{
+				Container: m.Container,
+			})
+		}
+	}
+	if derr != nil {
+		group.Members = remaining
+		if err := a.groups.save(group); err != nil {
+			logrus.WithError(err).Errorf("save group %s", group.ID)
+		}
+		return nil, derr
+	}
+	return empty, a.groups.remove(req.ID)
+}
+
+func (a *Agent) GroupStatus(ctx context.Context, req *v1.GroupStatusRequest) (*v1.GroupStatusResponse, error) {
+	ctx = relayContext(ctx)
+	var groups []*v1.Group
+	if req.ID != "" {
+		group, err := a.groups.get(req.ID)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	} else {
+		var err error
+		if groups, err = a.groups.list(); err != nil {
+			return nil, err
+		}
+	}
+	list, err := a.List(ctx, &v1.ListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var resp v1.GroupStatusResponse
+	for _, g := range groups {
+		status := &v1.GroupStatus{
+			ID: g.ID,
+		}
+		for _, m := range g.Members {
+			found := false
+			for _, c := range list.Containers {
+				if c.ID == m.Container.ID || c.Group == m.Container.ID {
+					status.Containers = append(status.Containers, c)
+					found = true
+				}
+			}
+			if !found {
+				status.Missing = append(status.Missing, m.Container.ID)
+			}
+		}
+		resp.Groups = append(resp.Groups, status)
+	}
+	return &resp, nil
+}
+
+// authorizeMembers checks that the caller may operate on every member of the groups,
+// group rpcs are only authorized against the group id by the interceptor
+func authorizeMembers(ctx context.Context, groups ...*v1.Group) error {
+	for _, g := range groups {
+		for _, m := range g.Members {
+			if err := auth.Container(ctx, m.Container.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// createMember creates the member once the members it starts after are ready
+func (a *Agent) createMember(ctx context.Context, g *v1.Group, m *v1.GroupMember) error {
+	if err := a.waitMembers(ctx, g, m.After); err != nil {
+		return errors.Wrapf(err, "create %s", m.Container.ID)
+	}
+	if _, err := a.Create(ctx, &v1.CreateRequest{
+		Container: m.Container,
+	}); err != nil {
+		return errors.Wrapf(err, "create %s", m.Container.ID)
+	}
+	return nil
+}
+
+func (a *Agent) deleteMember(ctx context.Context, id string) error {
+	if _, err := a.Delete(ctx, &v1.DeleteRequest{
+		ID: id,
+	}); err != nil && !errdefs.IsNotFound(errors.Cause(err)) {
+		return err
+	}
+	return nil
+}
+
+// rollbackGroup deletes the created members and rolls back the updated ones, latest first
+func (a *Agent) rollbackGroup(ctx context.Context, created, updated []string) {
+	for i := len(created) - 1; i >= 0; i-- {
+		if err := a.deleteMember(ctx, created[i]); err != nil {
+			logrus.WithError(err).Errorf("rollback create %s", created[i])
+		}
+	}
+	for i := len(updated) - 1; i >= 0; i-- {
+		if _, err := a.Rollback(ctx, &v1.RollbackRequest{
+			ID: updated[i],
+		}); err != nil {
+			logrus.WithError(err).Errorf("rollback update %s", updated[i])
+		}
+	}
+}
+
+// waitMembers waits for the members to be ready.
+// Members on the cni network must pass their health checks, others only need to be running
+func (a *Agent) waitMembers(ctx context.Context, g *v1.Group, ids []string) error {
+	for _, id := range ids {
+		var config *v1.Container
+		for _, m := range g.Members {
+			if m.Container.ID == id {
+				config = m.Container
+			}
+		}
+		// jobs run to completion and are never ready
+		if config == nil || v1.IsJob(config) {
+			continue
+		}
+		var containers []containerd.Container
+		if config.Replicas > 0 {
+			replicas, err := a.replicas(ctx, id)
+			if err != nil {
+				return err
+			}
+			for _, i := range replicaIndexes(replicas) {
+				containers = append(containers, replicas[i])
+			}
+		} else {
+			container, err := a.load(ctx, id)
+			if err != nil {
+				return err
+			}
+			containers = append(containers, container)
+		}
+		for _, container := range containers {
+			if err := waitReady(ctx, container, config); err != nil {
+				return errors.Wrapf(err, "%s is not ready", id)
+			}
+		}
+	}
+	return nil
+}
+
+func waitReady(ctx context.Context, container containerd.Container, config *v1.Container) error {
+	timeout := healthTimeout(config)
+	if v1.IsCNI(config.Network) {
+		_, err := waitHealthy(ctx, container, config, timeout)
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		running, err := isRunning(ctx, container)
+		if err != nil {
+			return err
+		}
+		if running {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.New("task is not running")
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package agent
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const defaultCheckTimeout = 5 * time.Second
+
+// waitHealthy waits for the container to be running with an ip and for all of its
+// service checks to pass, returning the container's ip
+func waitHealthy(ctx context.Context, container containerd.Container, config *v1.Container, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var last error
+	for {
+		ip, err := healthy(ctx, container, config)
+		if err == nil {
+			return ip, nil
+		}
+		last = err
+		select {
+		case <-ctx.Done():
+			return "", errors.Wrap(last, "health check")
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// waitRestored waits for a restored container to be running and, on the cni network where its
+// services are registered, for its service checks to pass, returning the container's ip
+func waitRestored(ctx context.Context, container containerd.Container, config *v1.Container) (string, error) {
+	if v1.IsCNI(config.Network) {
+		return waitHealthy(ctx, container, config, healthTimeout(config))
+	}
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout(config))
+	defer cancel()
+	for {
+		running, err := isRunning(ctx, container)
+		if err != nil {
+			return "", err
+		}
+		if running {
+			return "", nil
+		}
+		select {
+		case <-ctx.Done():
+			return "", errors.New("task is not running")
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func healthy(ctx context.Context, container containerd.Container, config *v1.Container) (string, error) {
+	running, err := isRunning(ctx, container)
+	if err != nil {
+		return "", err
+	}
+	if !running {
+		return "", errors.New("task is not running")
+	}
+	labels, err := container.Labels(ctx)
+	if err != nil {
+		return "", err
+	}
+	ip := labels[opts.IPLabel]
+	if ip == "" {
+		return "", errors.New("no ip assigned")
+	}
+	for name, s := range config.Services {
+		if s.Check == nil {
+			continue
+		}
+		// services are probed at the ips they are registered with, which are on their own network
+		ips, err := opts.ServiceIPs(labels, s)
+		if err != nil {
+			return "", err
+		}
+		if len(ips) == 0 {
+			return "", errors.Errorf("service %s: no ip assigned", name)
+		}
+		for _, sip := range ips {
+			if err := probe(ctx, sip, s); err != nil {
+				return "", errors.Wrapf(err, "service %s on %s", name, sip)
+			}
+		}
+	}
+	return ip, nil
+}
+
+// probe runs the service's health check from the agent
+func probe(ctx context.Context, ip string, s *v1.Service) error {
+	timeout := defaultCheckTimeout
+	if s.Check.Timeout > 0 {
+		timeout = time.Duration(s.Check.Timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	addr := net.JoinHostPort(ip, strconv.FormatInt(s.Port, 10))
+	switch s.Check.Type {
+	case "http":
+		method := s.Check.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+		url := "http://" + addr
+		if s.Url != "" {
+			url += path.Join("/", s.Url)
+		}
+		req, err := http.NewRequest(method, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return errors.Errorf("http status %d", resp.StatusCode)
+		}
+		return nil
+	case "grpc":
+		conn, err := grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithBlock())
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		resp, err := health.NewHealthClient(conn).Check(ctx, &health.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+		if resp.Status != health.HealthCheckResponse_SERVING {
+			return errors.Errorf("grpc status %s", resp.Status)
+		}
+		return nil
+	default:
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
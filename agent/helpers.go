@@ -0,0 +1,75 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/containerd/containerd"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/pkg/errors"
+)
+
+// validateHelpers checks the init and sidecar containers of the container
+func validateHelpers(c *v1.Container) error {
+	volumes := make(map[string]bool)
+	for _, v := range c.Volumes {
+		volumes[v.ID] = true
+	}
+	for kind, helpers := range map[string][]*v1.Helper{
+		opts.HelperInit:    c.Init,
+		opts.HelperSidecar: c.Sidecars,
+	} {
+		names := make(map[string]bool)
+		for _, h := range helpers {
+			if h.Name == "" {
+				return errors.Errorf("%s container without a name", kind)
+			}
+			if names[h.Name] {
+				return errors.Errorf("duplicate %s container %s", kind, h.Name)
+			}
+			names[h.Name] = true
+			if h.Image == "" {
+				return errors.Errorf("%s container %s has no image", kind, h.Name)
+			}
+			for _, v := range h.Volumes {
+				if !volumes[v.ID] {
+					return errors.Errorf("%s container %s uses volume %s that the container does not have", kind, h.Name, v.ID)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// pullHelpers pulls the images of the init and sidecar containers so the
+// unit can create them when it starts
+func (a *Agent) pullHelpers(ctx context.Context, c *v1.Container) error {
+	for _, helpers := range [][]*v1.Helper{c.Init, c.Sidecars} {
+		for _, h := range helpers {
+			if _, err := a.client.Pull(ctx, h.Image, containerd.WithPullUnpack, withPlainRemote(h.Image)); err != nil {
+				return errors.Wrapf(err, "pull %s", h.Image)
+			}
+		}
+	}
+	return nil
+}
+
+// containers returns the containers managed by boss, without their init and sidecar containers
+func (a *Agent) containers(ctx context.Context) ([]containerd.Container, error) {
+	containers, err := a.client.Containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []containerd.Container
+	for _, c := range containers {
+		labels, err := c.Labels(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if labels[opts.HelperLabel] != "" {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
@@ -0,0 +1,296 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/platforms"
+	"github.com/klauspost/compress/zstd"
+	digest "github.com/opencontainers/go-digest"
+	ver "github.com/opencontainers/image-spec/specs-go"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// memoryStore is a content store that keeps blobs and their info in memory
+type memoryStore struct {
+	blobs map[digest.Digest][]byte
+	info  map[digest.Digest]content.Info
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		blobs: make(map[digest.Digest][]byte),
+		info:  make(map[digest.Digest]content.Info),
+	}
+}
+
+func (s *memoryStore) Writer(ctx context.Context, opts ...content.WriterOpt) (content.Writer, error) {
+	var wo content.WriterOpts
+	for _, o := range opts {
+		if err := o(&wo); err != nil {
+			return nil, err
+		}
+	}
+	return &memoryWriter{
+		store: s,
+		ref:   wo.Ref,
+	}, nil
+}
+
+func (s *memoryStore) ReaderAt(ctx context.Context, desc is.Descriptor) (content.ReaderAt, error) {
+	data, ok := s.blobs[desc.Digest]
+	if !ok {
+		return nil, errdefs.ErrNotFound
+	}
+	return &memoryReader{
+		Reader: bytes.NewReader(data),
+	}, nil
+}
+
+type memoryWriter struct {
+	store *memoryStore
+	ref   string
+	buf   bytes.Buffer
+	dgst  digest.Digest
+}
+
+func (w *memoryWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *memoryWriter) Close() error {
+	return nil
+}
+
+func (w *memoryWriter) Digest() digest.Digest {
+	return w.dgst
+}
+
+func (w *memoryWriter) Commit(ctx context.Context, size int64, expected digest.Digest, opts ...content.Opt) error {
+	data := w.buf.Bytes()
+	if size > 0 && size != int64(len(data)) {
+		return fmt.Errorf("size %d != %d", size, len(data))
+	}
+	w.dgst = digest.FromBytes(data)
+	if expected != "" && expected != w.dgst {
+		return fmt.Errorf("digest %s != %s", w.dgst, expected)
+	}
+	info := content.Info{
+		Digest: w.dgst,
+		Size:   int64(len(data)),
+	}
+	for _, o := range opts {
+		if err := o(&info); err != nil {
+			return err
+		}
+	}
+	w.store.blobs[w.dgst] = append([]byte(nil), data...)
+	w.store.info[w.dgst] = info
+	return nil
+}
+
+func (w *memoryWriter) Status() (content.Status, error) {
+	return content.Status{
+		Ref:    w.ref,
+		Offset: int64(w.buf.Len()),
+	}, nil
+}
+
+func (w *memoryWriter) Truncate(size int64) error {
+	w.buf.Truncate(int(size))
+	return nil
+}
+
+type memoryReader struct {
+	*bytes.Reader
+}
+
+func (r *memoryReader) Close() error {
+	return nil
+}
+
+func testCheckpointIndex() *is.Index {
+	platform := platforms.DefaultSpec()
+	return &is.Index{
+		Versioned: ver.Versioned{
+			SchemaVersion: 2,
+		},
+		Annotations: map[string]string{
+			AnnotationCheckpointNode:    "node-01",
+			AnnotationCheckpointVersion: strconv.Itoa(CheckpointVersion),
+		},
+		Manifests: []is.Descriptor{
+			{
+				MediaType: MediaTypeContainerInfo,
+				Digest:    digest.FromString("info"),
+				Size:      4,
+				Platform:  &platform,
+			},
+			{
+				MediaType: is.MediaTypeImageLayer,
+				Digest:    digest.FromString("rw"),
+				Size:      2,
+				Platform:  &platform,
+			},
+			{
+				MediaType: MediaTypeVolumeLayer,
+				Digest:    digest.FromString("volume"),
+				Size:      6,
+				Annotations: map[string]string{
+					AnnotationVolumeID: "data",
+				},
+			},
+			{
+				MediaType: images.MediaTypeContainerd1Checkpoint,
+				Digest:    digest.FromString("criu"),
+				Size:      4,
+				Platform:  &platform,
+			},
+		},
+	}
+}
+
+func TestIndexRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	index := testCheckpointIndex()
+	desc, err := writeIndex(ctx, store, index, "test-index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desc.MediaType != is.MediaTypeImageIndex {
+		t.Errorf("media type %s != %s", desc.MediaType, is.MediaTypeImageIndex)
+	}
+	decoded, err := decodeIndex(ctx, store, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(index, decoded) {
+		t.Errorf("decoded index %+v != %+v", decoded, index)
+	}
+	labels := store.info[desc.Digest].Labels
+	for i, m := range index.Manifests {
+		key := fmt.Sprintf("containerd.io/gc.ref.content.%d", i)
+		if labels[key] != m.Digest.String() {
+			t.Errorf("label %s is %q, expected %s", key, labels[key], m.Digest)
+		}
+	}
+	version, err := checkpointVersion(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != CheckpointVersion {
+		t.Errorf("version %d != %d", version, CheckpointVersion)
+	}
+	if err := validatePlatform(decoded, true); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRwLayerMediaTypes(t *testing.T) {
+	for _, mt := range []string{is.MediaTypeImageLayer, is.MediaTypeImageLayerGzip, MediaTypeImageLayerZstd} {
+		index := testCheckpointIndex()
+		index.Manifests[1].MediaType = mt
+		rw, err := rwLayer(index)
+		if err != nil {
+			t.Fatalf("%s: %v", mt, err)
+		}
+		if rw.Digest != index.Manifests[1].Digest {
+			t.Errorf("%s: rw layer %s != %s", mt, rw.Digest, index.Manifests[1].Digest)
+		}
+	}
+	index := testCheckpointIndex()
+	index.Manifests = index.Manifests[:1]
+	if _, err := rwLayer(index); err == nil {
+		t.Error("expected an error for a checkpoint without an rw layer")
+	}
+}
+
+func TestCheckpointVersion(t *testing.T) {
+	for _, tc := range []struct {
+		annotation string
+		version    int64
+		err        bool
+	}{
+		{"", 0, false},
+		{"1", 1, false},
+		{strconv.Itoa(CheckpointVersion + 1), CheckpointVersion + 1, true},
+		{"one", 0, true},
+		{"-1", 0, true},
+	} {
+		index := testCheckpointIndex()
+		delete(index.Annotations, AnnotationCheckpointVersion)
+		if tc.annotation != "" {
+			index.Annotations[AnnotationCheckpointVersion] = tc.annotation
+		}
+		if version, err := checkpointVersion(index); err == nil && version != tc.version {
+			t.Errorf("%q: version %d != %d", tc.annotation, version, tc.version)
+		}
+		if err := validateVersion(index); (err != nil) != tc.err {
+			t.Errorf("%q: unexpected error %v", tc.annotation, err)
+		}
+	}
+}
+
+func TestValidatePlatform(t *testing.T) {
+	index := testCheckpointIndex()
+	other := is.Platform{
+		OS:           "linux",
+		Architecture: "s390x",
+	}
+	if platforms.NewMatcher(platforms.DefaultSpec()).Match(other) {
+		t.Skip("test platform matches the node")
+	}
+	for i := range index.Manifests {
+		if index.Manifests[i].Platform != nil {
+			index.Manifests[i].Platform = &other
+		}
+	}
+	if err := validatePlatform(index, true); err == nil {
+		t.Error("expected an error restoring criu state from another platform")
+	}
+	if err := validatePlatform(index, false); err != nil {
+		t.Errorf("filesystem restore from another platform: %v", err)
+	}
+}
+
+func TestDecompressZstd(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	layer := bytes.Repeat([]byte("rw layer "), 1024)
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := zw.Write(layer); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	compressed, err := writeContent(ctx, store, MediaTypeImageLayerZstd, "rw-zstd", &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc, err := decompressZstd(ctx, store, compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desc.MediaType != is.MediaTypeImageLayer {
+		t.Errorf("media type %s != %s", desc.MediaType, is.MediaTypeImageLayer)
+	}
+	if desc.Digest != digest.FromBytes(layer) || desc.Size != int64(len(layer)) {
+		t.Errorf("decompressed layer %s (%d bytes) does not match the original layer", desc.Digest, desc.Size)
+	}
+	if !bytes.Equal(store.blobs[desc.Digest], layer) {
+		t.Error("decompressed layer does not match the original layer")
+	}
+}
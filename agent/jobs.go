@@ -0,0 +1,180 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/jobs"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/crosbymichael/boss/systemd"
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const jobLogLines = 50
+
+var (
+	errNotJob     = errors.New("container is not a job")
+	errKindChange = errors.New("the kind of a container cannot be updated")
+)
+
+// validateKind checks the container's kind and the options that do not apply to jobs
+func validateKind(c *v1.Container) error {
+	switch c.Kind {
+	case "", v1.KindService:
+		if c.Schedule != "" {
+			return errors.New("only jobs can have a schedule")
+		}
+		return nil
+	case v1.KindJob:
+		if c.Replicas > 0 {
+			return errors.New("jobs cannot have replicas")
+		}
+		if c.Update != nil && c.Update.Strategy == strategyBlueGreen {
+			return errors.New("jobs cannot be updated blue/green")
+		}
+		if c.Schedule != "" {
+			if _, err := systemd.Calendar(c.Schedule); err != nil {
+				return errors.Wrap(err, "schedule")
+			}
+		}
+		return nil
+	}
+	return errors.Errorf("unknown container kind %q", c.Kind)
+}
+
+// enableUnit enables the service, or the timer of a scheduled job
+func enableUnit(ctx context.Context, id string, c *v1.Container) error {
+	if v1.IsJob(c) {
+		return systemd.EnableJob(ctx, id, c.Schedule)
+	}
+	return systemd.Enable(ctx, id)
+}
+
+// startUnit starts the service, or the first run of an unscheduled job
+func startUnit(ctx context.Context, id string, c *v1.Container) error {
+	if v1.IsJob(c) {
+		if c.Schedule != "" {
+			return nil
+		}
+		return systemd.RunJob(ctx, id)
+	}
+	return systemd.Start(ctx, id)
+}
+
+func stopUnit(ctx context.Context, id string, c *v1.Container) error {
+	if v1.IsJob(c) {
+		return systemd.StopJob(ctx, id)
+	}
+	return systemd.Stop(ctx, id)
+}
+
+// queueStopUnit stops the unit from restarting its task without waiting for the task to exit
+func queueStopUnit(ctx context.Context, id string, c *v1.Container) error {
+	if v1.IsJob(c) {
+		return systemd.QueueStopJob(ctx, id)
+	}
+	return systemd.QueueStop(ctx, id)
+}
+
+func disableUnit(ctx context.Context, id string, c *v1.Container) error {
+	if v1.IsJob(c) {
+		if err := systemd.DisableJob(ctx, id); err != nil {
+			return err
+		}
+		return jobs.Remove(id)
+	}
+	return systemd.Disable(ctx, id)
+}
+
+// unitEnabled returns true if the service, or the timer of a scheduled job, is enabled.
+// Jobs without a schedule have nothing to enable
+func unitEnabled(ctx context.Context, id string, c *v1.Container) (bool, error) {
+	if v1.IsJob(c) {
+		if c.Schedule == "" {
+			return true, nil
+		}
+		return systemd.JobEnabled(ctx, id)
+	}
+	return systemd.Enabled(ctx, id)
+}
+
+func (a *Agent) Jobs(ctx context.Context, req *v1.JobsRequest) (*v1.JobsResponse, error) {
+	ctx = relayContext(ctx)
+	containers, err := a.containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resp v1.JobsResponse
+	for _, c := range containers {
+		info, err := c.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if req.ID != "" && opts.ID(info) != req.ID {
+			continue
+		}
+		d := info.Extensions[opts.CurrentConfig]
+		config, err := opts.UnmarshalConfig(&d)
+		if err != nil {
+			return nil, err
+		}
+		if !v1.IsJob(config) {
+			continue
+		}
+		job := &v1.Job{
+			ID:       opts.ID(info),
+			Schedule: config.Schedule,
+		}
+		if config.Schedule != "" {
+			if job.NextRun, err = systemd.NextRun(ctx, c.ID()); err != nil {
+				logrus.WithError(err).Errorf("next run of %s", c.ID())
+			}
+		}
+		runs, err := jobs.Runs(c.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range runs {
+			run := &v1.JobRun{
+				Started:  r.Started,
+				Finished: r.Finished,
+				ExitCode: int64(r.ExitCode),
+				Error:    r.Error,
+			}
+			if req.Logs {
+				if run.Logs, err = jobs.Logs(ctx, r, jobLogLines); err != nil {
+					return nil, err
+				}
+			}
+			job.Runs = append(job.Runs, run)
+		}
+		resp.Jobs = append(resp.Jobs, job)
+	}
+	return &resp, nil
+}
+
+func (a *Agent) Run(ctx context.Context, req *v1.RunRequest) (*types.Empty, error) {
+	ctx = relayContext(ctx)
+	if req.ID == "" {
+		return nil, ErrNoID
+	}
+	unlock, err := a.locks.lock(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	container, err := a.load(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	config, err := opts.GetConfig(ctx, container)
+	if err != nil {
+		return nil, err
+	}
+	if !v1.IsJob(config) {
+		return nil, errNotJob
+	}
+	return empty, systemd.RunJob(ctx, container.ID())
+}
@@ -0,0 +1,173 @@
+package agent
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+)
+
+const (
+	opCreate = "create"
+	opUpdate = "update"
+	opDelete = "delete"
+
+	stepVolumes   = "volumes"
+	stepContainer = "container"
+	stepConfigs   = "configs"
+	stepEnable    = "enable"
+	stepStart     = "start"
+	stepPause     = "pause"
+	stepApply     = "apply"
+	stepRestart   = "restart"
+	// stepStarted is recorded once a created container and its task are started,
+	// it marks the create as committed so that recovery finishes it instead of rolling it back
+	stepStarted = "started"
+)
+
+// journal is a write-ahead log of multi-step agent operations.
+// Each step is recorded before it is run so that an operation interrupted by
+// an agent crash can be finished or rolled back when the agent starts again
+type journal struct {
+	root string
+}
+
+// operation is an in-flight operation on a container.
+// Container locks ensure there is only one operation per container
+type operation struct {
+	ID      string    `json:"id"`
+	Op      string    `json:"op"`
+	Started time.Time `json:"started"`
+	// Steps that have been started, in order
+	Steps []string `json:"steps"`
+	// Previous state of the container used to roll back an update
+	Previous *previous `json:"previous,omitempty"`
+	// Volumes created by the operation, recorded before they are created
+	Volumes []string `json:"volumes,omitempty"`
+
+	path string
+}
+
+// previous is the container record before an update
+type previous struct {
+	Image       string               `json:"image"`
+	SnapshotKey string               `json:"snapshot_key"`
+	Spec        *types.Any           `json:"spec"`
+	Extensions  map[string]types.Any `json:"extensions"`
+}
+
+// begin records a new operation for the container
+func (j *journal) begin(id, op string, prev *previous) (*operation, error) {
+	if err := os.MkdirAll(j.root, 0700); err != nil {
+		return nil, err
+	}
+	o := &operation{
+		ID:       id,
+		Op:       op,
+		Started:  time.Now(),
+		Previous: prev,
+		path:     filepath.Join(j.root, id+".json"),
+	}
+	if err := o.write(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// pending returns all operations that did not finish
+func (j *journal) pending() ([]*operation, error) {
+	files, err := ioutil.ReadDir(j.root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var ops []*operation
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(j.root, f.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var o operation
+		if err := json.Unmarshal(data, &o); err != nil {
+			return nil, err
+		}
+		o.path = path
+		ops = append(ops, &o)
+	}
+	return ops, nil
+}
+
+// do records the step before running it
+func (o *operation) do(step string, fn func() error) error {
+	o.Steps = append(o.Steps, step)
+	if err := o.write(); err != nil {
+		return err
+	}
+	return fn()
+}
+
+// commit records that the create's container and task are started
+func (o *operation) commit() error {
+	o.Steps = append(o.Steps, stepStarted)
+	return o.write()
+}
+
+// createVolume records the volume before it is created so that it is removed on rollback
+func (o *operation) createVolume(id string) error {
+	o.Volumes = append(o.Volumes, id)
+	return o.write()
+}
+
+// started returns true if the step was started
+func (o *operation) started(step string) bool {
+	for _, s := range o.Steps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// done removes the finished operation from the journal
+func (o *operation) done() error {
+	if err := os.Remove(o.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (o *operation) write() error {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(o.path), ".op")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), o.path)
+}
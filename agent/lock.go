@@ -0,0 +1,44 @@
+package agent
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// locks serializes operations on the same container id
+type locks struct {
+	mu  sync.Mutex
+	ids map[string]chan struct{}
+}
+
+// lock waits for any in-flight operation on the id to finish or for the
+// context to be done.
+// The returned func must be called to release the lock
+func (l *locks) lock(ctx context.Context, id string) (func(), error) {
+	for {
+		l.mu.Lock()
+		if l.ids == nil {
+			l.ids = make(map[string]chan struct{})
+		}
+		ch, ok := l.ids[id]
+		if !ok {
+			ch = make(chan struct{})
+			l.ids[id] = ch
+			l.mu.Unlock()
+			return func() {
+				l.mu.Lock()
+				delete(l.ids, id)
+				l.mu.Unlock()
+				close(ch)
+			}, nil
+		}
+		l.mu.Unlock()
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "wait for operation on %s", id)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package agent
+
+import (
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/pkg/errors"
+)
+
+// validateNetworks checks that the container's networks exist in the system config
+// and that its services are registered on one of them
+func (a *Agent) validateNetworks(c *v1.Container) error {
+	if v1.IsCNI(c.Network) {
+		if err := a.validateNetwork(c.Network); err != nil {
+			return err
+		}
+	}
+	networks := map[string]bool{
+		c.Network: true,
+	}
+	for _, n := range c.Networks {
+		if !v1.IsCNI(n) {
+			return errors.Errorf("%s cannot be attached as an additional network", n)
+		}
+		if !v1.IsCNI(c.Network) {
+			return errors.Errorf("additional networks require a cni network, not %q", c.Network)
+		}
+		if networks[n] {
+			return errors.Errorf("network %s is attached more than once", n)
+		}
+		if err := a.validateNetwork(n); err != nil {
+			return err
+		}
+		networks[n] = true
+	}
+	for name, s := range c.Services {
+		if s.Network != "" && !networks[s.Network] {
+			return errors.Errorf("service %s is registered on network %s that the container is not attached to", name, s.Network)
+		}
+	}
+	return nil
+}
+
+func (a *Agent) validateNetwork(name string) error {
+	if name == "cni" {
+		if a.c.CNI == nil {
+			return errors.New("[cni] is not enabled in the system config")
+		}
+		return nil
+	}
+	if _, ok := a.c.Networks[name]; !ok {
+		return errors.Errorf("network %s does not exist", name)
+	}
+	return nil
+}
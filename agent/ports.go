@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/pkg/errors"
+)
+
+// portsLock serializes the operations that publish ports so that two containers
+// cannot both pass checkPorts for the same host port
+const portsLock = "ports/"
+
+// validatePorts checks the ports that the container publishes on the host,
+// they are published by the portmap plugin which is only chained to bridge networks
+func (a *Agent) validatePorts(c *v1.Container) error {
+	if len(c.Ports) == 0 {
+		return nil
+	}
+	if !v1.IsCNI(c.Network) {
+		return errors.New("published ports require a cni network")
+	}
+	conf := a.c.Networks[c.Network]
+	if c.Network == "cni" {
+		conf = a.c.CNI
+	}
+	if conf == nil {
+		return errors.Errorf("network %s does not exist", c.Network)
+	}
+	if conf.Type != "bridge" {
+		return errors.Errorf("published ports require a bridge network, %s is %s", c.Network, conf.Type)
+	}
+	if c.Replicas > 0 {
+		return errors.New("published ports cannot be used with replicas")
+	}
+	published := make(map[string]bool)
+	for _, p := range c.Ports {
+		if p.Host < 1 || p.Host > 65535 || p.Container < 1 || p.Container > 65535 {
+			return errors.Errorf("invalid port %d:%d", p.Host, p.Container)
+		}
+		switch p.Protocol {
+		case "", "tcp", "udp":
+		default:
+			return errors.Errorf("invalid protocol %q for port %d", p.Protocol, p.Host)
+		}
+		key := hostPort(p)
+		if published[key] {
+			return errors.Errorf("host port %s is published more than once", key)
+		}
+		published[key] = true
+	}
+	return nil
+}
+
+// checkPorts rejects host ports that are already published by another container,
+// the blue/green sibling of the container has the same id and is not a conflict.
+// The returned func releases the ports lock and must be called once the container is created or updated
+func (a *Agent) checkPorts(ctx context.Context, c *v1.Container) (func(), error) {
+	if len(c.Ports) == 0 {
+		return func() {}, nil
+	}
+	unlock, err := a.locks.lock(ctx, portsLock)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.publishedPorts(ctx, c); err != nil {
+		unlock()
+		return nil, err
+	}
+	return unlock, nil
+}
+
+func (a *Agent) publishedPorts(ctx context.Context, c *v1.Container) error {
+	published := make(map[string]bool)
+	for _, p := range c.Ports {
+		published[hostPort(p)] = true
+	}
+	containers, err := a.containers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, container := range containers {
+		info, err := container.Info(ctx)
+		if err != nil {
+			return err
+		}
+		id := opts.ID(info)
+		if id == c.ID {
+			continue
+		}
+		config, err := opts.GetConfigFromInfo(ctx, info)
+		if err != nil {
+			return err
+		}
+		for _, p := range config.Ports {
+			if key := hostPort(p); published[key] {
+				return errors.Wrapf(errdefs.ErrFailedPrecondition, "host port %s is published by %s", key, id)
+			}
+		}
+	}
+	return nil
+}
+
+// hostPort returns the host port and protocol of the published port, i.e. 8080/tcp
+func hostPort(p *v1.Port) string {
+	protocol := p.Protocol
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	return fmt.Sprintf("%d/%s", p.Host, protocol)
+}
@@ -0,0 +1,273 @@
+package agent
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/containerd/archive"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/crosbymichael/boss/api/v1"
+	digest "github.com/opencontainers/go-digest"
+	ver "github.com/opencontainers/image-spec/specs-go"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// AnnotationCheckpointParent is the digest of the pre-dump that a checkpoint's memory pages are relative to
+	AnnotationCheckpointParent = "io.boss.checkpoint.parent"
+
+	// runcRoot is the state directory of the runc shim, runc is called directly for the
+	// checkpoint options that the task service does not expose
+	runcRoot = "/run/containerd/runc"
+	// parentsDir holds the pre-dumps of a restored checkpoint so that criu can follow the parent links
+	parentsDir = ".parents"
+	// criuParent is the link criu creates to the images of the parent dump
+	criuParent = "parent"
+)
+
+// preCopy holds the memory pre-dumps of a container taken while it keeps running
+type preCopy struct {
+	dir   string
+	dumps []is.Descriptor
+}
+
+func newPreCopy() (*preCopy, error) {
+	dir, err := ioutil.TempDir("", "boss-precopy-")
+	if err != nil {
+		return nil, err
+	}
+	return &preCopy{
+		dir: dir,
+	}, nil
+}
+
+func (p *preCopy) Close() error {
+	return os.RemoveAll(p.dir)
+}
+
+func (p *preCopy) path(name string) string {
+	return filepath.Join(p.dir, name)
+}
+
+// parent returns the path of the last pre-dump relative to the next dump and its descriptor
+func (p *preCopy) parent() (string, *is.Descriptor) {
+	if len(p.dumps) == 0 {
+		return "", nil
+	}
+	i := len(p.dumps) - 1
+	return filepath.Join("..", strconv.Itoa(i)), &p.dumps[i]
+}
+
+// preDump dumps the container's memory while it keeps running, tracking the pages that
+// are dirtied afterwards so that the next dump only contains those
+func (a *Agent) preDump(ctx context.Context, p *preCopy, id string) error {
+	desc, err := a.dump(ctx, p, id, strconv.Itoa(len(p.dumps)), images.MediaTypeContainerd1CheckpointPreDump, "--pre-dump")
+	if err != nil {
+		return errors.Wrap(err, "pre-dump")
+	}
+	p.dumps = append(p.dumps, desc)
+	return nil
+}
+
+// finalDump checkpoints the container relative to its last pre-dump
+func (a *Agent) finalDump(ctx context.Context, p *preCopy, id string, exit bool) (is.Descriptor, error) {
+	var args []string
+	if !exit {
+		args = append(args, "--leave-running")
+	}
+	return a.dump(ctx, p, id, "final", images.MediaTypeContainerd1Checkpoint, args...)
+}
+
+func (a *Agent) dump(ctx context.Context, p *preCopy, id, name, mediaType string, args ...string) (is.Descriptor, error) {
+	path := p.path(name)
+	args = append([]string{"checkpoint", "--file-locks", "--image-path", path}, args...)
+	parentPath, parent := p.parent()
+	if parent != nil {
+		args = append(args, "--parent-path", parentPath)
+	}
+	if err := runc(ctx, append(args, id)...); err != nil {
+		return is.Descriptor{}, err
+	}
+	desc, err := a.writeDir(ctx, path, mediaType, fmt.Sprintf("%s-%s-%s", id, filepath.Base(p.dir), name))
+	if err != nil {
+		return is.Descriptor{}, err
+	}
+	desc.Platform = &is.Platform{
+		OS:           runtime.GOOS,
+		Architecture: runtime.GOARCH,
+	}
+	if parent != nil {
+		desc.Annotations = map[string]string{
+			AnnotationCheckpointParent: parent.Digest.String(),
+		}
+	}
+	return desc, nil
+}
+
+// savePreDumps points the ref at an index of the pre-dumps so that they can be transferred
+// to the target before the final checkpoint
+func (a *Agent) savePreDumps(ctx context.Context, p *preCopy, ref string) error {
+	index := is.Index{
+		Versioned: ver.Versioned{
+			SchemaVersion: 2,
+		},
+		Manifests: p.dumps,
+	}
+	desc, err := writeIndex(ctx, a.client.ContentStore(), &index, filepath.Base(p.dir)+"-index")
+	if err != nil {
+		return err
+	}
+	return a.saveImage(ctx, ref, desc)
+}
+
+// writeDir writes the directory into the content store as an uncompressed tar,
+// the same format the task service uses for checkpoints
+func (a *Agent) writeDir(ctx context.Context, dir, mediaType, ref string) (is.Descriptor, error) {
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(archive.WriteDiff(ctx, w, "", dir))
+	}()
+	desc, err := writeContent(ctx, a.client.ContentStore(), mediaType, ref, r)
+	r.CloseWithError(err)
+	return desc, err
+}
+
+// withParents returns the checkpoint with the pre-dumps it is relative to under parentsDir
+// and the parent links rewritten to them, as criu needs the whole chain in one image directory.
+// The merged checkpoint is referenced from the checkpoint's index so that it is kept with the checkpoint
+func (a *Agent) withParents(ctx context.Context, target is.Descriptor, index *is.Index, checkpoint is.Descriptor) (*is.Descriptor, error) {
+	chain, err := parents(index, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) == 0 {
+		return &checkpoint, nil
+	}
+	ctx, done, err := a.client.WithLease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done(ctx)
+	store := a.client.ContentStore()
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(mergeDumps(ctx, store, checkpoint, chain, w))
+	}()
+	merged, err := writeContent(ctx, store, checkpoint.MediaType, checkpoint.Digest.String()+"-merged", r)
+	r.CloseWithError(err)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.keepWithCheckpoint(ctx, target, "restore", merged); err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+// parents returns the pre-dumps that the checkpoint is relative to, oldest first
+func parents(index *is.Index, checkpoint is.Descriptor) ([]is.Descriptor, error) {
+	dumps := make(map[digest.Digest]is.Descriptor)
+	for _, d := range index.Manifests {
+		if d.MediaType == images.MediaTypeContainerd1CheckpointPreDump {
+			dumps[d.Digest] = d
+		}
+	}
+	var chain []is.Descriptor
+	for d := checkpoint; d.Annotations[AnnotationCheckpointParent] != ""; {
+		parent, ok := dumps[digest.Digest(d.Annotations[AnnotationCheckpointParent])]
+		if !ok {
+			return nil, errors.Errorf("parent %s of %s not found in index", d.Annotations[AnnotationCheckpointParent], d.Digest)
+		}
+		if len(chain) > len(dumps) {
+			return nil, errors.New("checkpoint parents form a cycle")
+		}
+		chain = append([]is.Descriptor{parent}, chain...)
+		d = parent
+	}
+	return chain, nil
+}
+
+func mergeDumps(ctx context.Context, store content.Provider, checkpoint is.Descriptor, chain []is.Descriptor, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	if err := copyDump(ctx, store, checkpoint, tw, "", path.Join(parentsDir, strconv.Itoa(len(chain)-1))); err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(dirHeader(parentsDir)); err != nil {
+		return err
+	}
+	for i, d := range chain {
+		prefix := path.Join(parentsDir, strconv.Itoa(i))
+		if err := tw.WriteHeader(dirHeader(prefix)); err != nil {
+			return err
+		}
+		if err := copyDump(ctx, store, d, tw, prefix, path.Join("..", strconv.Itoa(i-1))); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// copyDump copies the dump's tar entries under the prefix, pointing its parent link at parent
+func copyDump(ctx context.Context, store content.Provider, desc is.Descriptor, tw *tar.Writer, prefix, parent string) error {
+	ra, err := store.ReaderAt(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+	tr := tar.NewReader(content.NewReader(ra))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(hdr.Name)
+		if name == criuParent && hdr.Typeflag == tar.TypeSymlink {
+			hdr.Linkname = parent
+		}
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = path.Join(prefix, path.Clean(hdr.Linkname))
+		}
+		hdr.Name = path.Join(prefix, name)
+		if hdr.Typeflag == tar.TypeDir {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func dirHeader(name string) *tar.Header {
+	return &tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     name + "/",
+		Mode:     0700,
+	}
+}
+
+func runc(ctx context.Context, args ...string) error {
+	args = append([]string{"--root", filepath.Join(runcRoot, v1.DefaultNamespace)}, args...)
+	out, err := exec.CommandContext(ctx, "runc", args...).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "runc: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package agent
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/containerd/containerd/images"
+	digest "github.com/opencontainers/go-digest"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func testPreDump(name, parent string) is.Descriptor {
+	d := is.Descriptor{
+		MediaType: images.MediaTypeContainerd1CheckpointPreDump,
+		Digest:    digest.FromString(name),
+	}
+	if parent != "" {
+		d.Annotations = map[string]string{
+			AnnotationCheckpointParent: digest.FromString(parent).String(),
+		}
+	}
+	return d
+}
+
+func TestParents(t *testing.T) {
+	var (
+		first  = testPreDump("first", "")
+		second = testPreDump("second", "first")
+		third  = testPreDump("third", "second")
+	)
+	checkpoint := testPreDump("checkpoint", "third")
+	checkpoint.MediaType = images.MediaTypeContainerd1Checkpoint
+	index := testCheckpointIndex()
+	// the chain is followed through the parent annotations, not the order in the index
+	index.Manifests = append(index.Manifests, third, first, second)
+	chain, err := parents(index, checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []is.Descriptor{first, second, third}; !reflect.DeepEqual(chain, expected) {
+		t.Errorf("chain %v != %v", chain, expected)
+	}
+	if chain, err = parents(index, testPreDump("checkpoint", "")); err != nil || len(chain) != 0 {
+		t.Errorf("expected no parents for a checkpoint without pre-dumps, got %v: %v", chain, err)
+	}
+	if _, err := parents(index, testPreDump("checkpoint", "missing")); err == nil {
+		t.Error("expected an error for a missing parent")
+	}
+	// the checkpoint itself cannot be a parent, only pre-dumps are followed
+	if _, err := parents(index, testPreDump("checkpoint", "checkpoint")); err == nil {
+		t.Error("expected an error for a checkpoint that is its own parent")
+	}
+}
+
+func TestParentsCycle(t *testing.T) {
+	for name, dumps := range map[string][]is.Descriptor{
+		"self":  {testPreDump("first", "first")},
+		"pair":  {testPreDump("first", "second"), testPreDump("second", "first")},
+		"chain": {testPreDump("first", "third"), testPreDump("second", "first"), testPreDump("third", "second")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			index := testCheckpointIndex()
+			index.Manifests = append(index.Manifests, dumps...)
+			if _, err := parents(index, testPreDump("checkpoint", "first")); err == nil {
+				t.Error("expected an error for parents that form a cycle")
+			}
+		})
+	}
+}
+
+type tarEntry struct {
+	typeflag byte
+	name     string
+	linkname string
+	data     string
+}
+
+func writeTar(t *testing.T, store *memoryStore, mediaType, ref string, entries ...tarEntry) is.Descriptor {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Typeflag: e.typeflag,
+			Name:     e.name,
+			Linkname: e.linkname,
+			Size:     int64(len(e.data)),
+			Mode:     0600,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	desc, err := writeContent(context.Background(), store, mediaType, ref, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return desc
+}
+
+func readTar(t *testing.T, r io.Reader) []tarEntry {
+	var entries []tarEntry
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, tarEntry{
+			typeflag: hdr.Typeflag,
+			name:     hdr.Name,
+			linkname: hdr.Linkname,
+			data:     string(data),
+		})
+	}
+}
+
+func TestMergeDumps(t *testing.T) {
+	store := newMemoryStore()
+	// criu links each dump to its parent's image directory and the tars hardlink identical files
+	first := writeTar(t, store, images.MediaTypeContainerd1CheckpointPreDump, "first",
+		tarEntry{typeflag: tar.TypeReg, name: "pages-1.img", data: "first pages"},
+		tarEntry{typeflag: tar.TypeLink, name: "pages-2.img", linkname: "pages-1.img"},
+	)
+	second := writeTar(t, store, images.MediaTypeContainerd1CheckpointPreDump, "second",
+		tarEntry{typeflag: tar.TypeSymlink, name: "parent", linkname: "/tmp/boss-precopy-1/first"},
+		tarEntry{typeflag: tar.TypeReg, name: "./pages-1.img", data: "second pages"},
+		tarEntry{typeflag: tar.TypeLink, name: "pages-2.img", linkname: "./pages-1.img"},
+	)
+	checkpoint := writeTar(t, store, images.MediaTypeContainerd1Checkpoint, "checkpoint",
+		tarEntry{typeflag: tar.TypeSymlink, name: "./parent", linkname: "/tmp/boss-precopy-1/second"},
+		tarEntry{typeflag: tar.TypeDir, name: "tmpfs/"},
+		tarEntry{typeflag: tar.TypeReg, name: "tmpfs/dev.img", data: "tmpfs"},
+		tarEntry{typeflag: tar.TypeLink, name: "core.img", linkname: "tmpfs/dev.img"},
+		// only the top level parent link is criu's, other symlinks are kept as they are
+		tarEntry{typeflag: tar.TypeSymlink, name: "tmpfs/parent", linkname: "dev.img"},
+	)
+	var buf bytes.Buffer
+	if err := mergeDumps(context.Background(), store, checkpoint, []is.Descriptor{first, second}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := []tarEntry{
+		{typeflag: tar.TypeSymlink, name: "parent", linkname: ".parents/1"},
+		{typeflag: tar.TypeDir, name: "tmpfs/"},
+		{typeflag: tar.TypeReg, name: "tmpfs/dev.img", data: "tmpfs"},
+		{typeflag: tar.TypeLink, name: "core.img", linkname: "tmpfs/dev.img"},
+		{typeflag: tar.TypeSymlink, name: "tmpfs/parent", linkname: "dev.img"},
+		{typeflag: tar.TypeDir, name: ".parents/"},
+		{typeflag: tar.TypeDir, name: ".parents/0/"},
+		{typeflag: tar.TypeReg, name: ".parents/0/pages-1.img", data: "first pages"},
+		{typeflag: tar.TypeLink, name: ".parents/0/pages-2.img", linkname: ".parents/0/pages-1.img"},
+		{typeflag: tar.TypeDir, name: ".parents/1/"},
+		{typeflag: tar.TypeSymlink, name: ".parents/1/parent", linkname: "../0"},
+		{typeflag: tar.TypeReg, name: ".parents/1/pages-1.img", data: "second pages"},
+		{typeflag: tar.TypeLink, name: ".parents/1/pages-2.img", linkname: ".parents/1/pages-1.img"},
+	}
+	if entries := readTar(t, &buf); !reflect.DeepEqual(entries, expected) {
+		t.Errorf("merged entries\n%v\n!= expected\n%v", entries, expected)
+	}
+}
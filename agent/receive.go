@@ -0,0 +1,271 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/crosbymichael/boss/api/v1"
+	digest "github.com/opencontainers/go-digest"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// sendChunkSize is the size of the blob data in each message sent to another agent
+	sendChunkSize = 1024 * 1024
+	// migrationLabel is set on received checkpoints to the migration that sent them
+	migrationLabel = "io/boss/migration"
+)
+
+var errNoIndex = errors.New("first message must have the ref and index")
+
+// Receive writes a checkpoint sent by another agent into the content store and creates its ref
+// so that it can be restored without a registry.
+// Existing refs are only replaced by the migration that received them, which sends its pre-dumps
+// and checkpoint under the same ref
+func (a *Agent) Receive(stream v1.Agent_ReceiveServer) error {
+	ctx := relayContext(stream.Context())
+	ctx, done, err := a.client.WithLease(ctx)
+	if err != nil {
+		return err
+	}
+	defer done(ctx)
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if req.Ref == "" || len(req.Index) == 0 {
+		return errNoIndex
+	}
+	var index is.Index
+	if err := json.Unmarshal(req.Index, &index); err != nil {
+		return errors.Wrap(err, "decode index")
+	}
+	if image, err := a.client.ImageService().Get(ctx, req.Ref); err == nil {
+		if req.Migration == "" || image.Labels[migrationLabel] != req.Migration {
+			return errors.Wrapf(errdefs.ErrAlreadyExists, "image %s", req.Ref)
+		}
+	} else if !errdefs.IsNotFound(err) {
+		return err
+	}
+	store := a.client.ContentStore()
+	labels := map[string]string{}
+	blobs := make(map[digest.Digest]is.Descriptor)
+	var missing []string
+	for i, m := range index.Manifests {
+		labels[fmt.Sprintf("containerd.io/gc.ref.content.%d", i)] = m.Digest.String()
+		if _, err := store.Info(ctx, m.Digest); err == nil {
+			continue
+		} else if !errdefs.IsNotFound(err) {
+			return err
+		}
+		if _, ok := blobs[m.Digest]; !ok {
+			blobs[m.Digest] = m
+			missing = append(missing, m.Digest.String())
+		}
+	}
+	if err := stream.Send(&v1.ReceiveResponse{
+		Missing: missing,
+	}); err != nil {
+		return err
+	}
+	var (
+		w       content.Writer
+		current is.Descriptor
+		skip    bool
+	)
+	defer func() {
+		if w != nil {
+			w.Close()
+		}
+	}()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if req.Digest != "" {
+			if err := commitBlob(ctx, w, current); err != nil {
+				return err
+			}
+			w, skip = nil, false
+			var ok bool
+			if current, ok = blobs[digest.Digest(req.Digest)]; !ok {
+				return errors.Errorf("blob %s was not requested", req.Digest)
+			}
+			delete(blobs, current.Digest)
+			if w, err = store.Writer(ctx, content.WithRef("receive-"+req.Digest), content.WithDescriptor(current)); err != nil {
+				if !errdefs.IsAlreadyExists(err) {
+					return err
+				}
+				// written by another ingest since the index was received
+				skip = true
+			} else if err := w.Truncate(0); err != nil {
+				// an ingest left behind by a failed transfer resumes at its offset
+				return err
+			}
+		}
+		if skip {
+			continue
+		}
+		if w == nil {
+			return errors.New("blob data sent before its digest")
+		}
+		if _, err := w.Write(req.Data); err != nil {
+			return err
+		}
+	}
+	if err := commitBlob(ctx, w, current); err != nil {
+		return err
+	}
+	w = nil
+	if len(blobs) > 0 {
+		return errors.Errorf("%d requested blobs were not sent", len(blobs))
+	}
+	desc, err := writeContent(ctx, store, is.MediaTypeImageIndex, req.Ref+"-received-index", bytes.NewReader(req.Index), content.WithLabels(labels))
+	if err != nil {
+		return err
+	}
+	i := images.Image{
+		Name:   req.Ref,
+		Target: desc,
+	}
+	if req.Migration != "" {
+		i.Labels = map[string]string{
+			migrationLabel: req.Migration,
+		}
+	}
+	if _, err := a.client.ImageService().Create(ctx, i); err != nil {
+		if !errdefs.IsAlreadyExists(err) || req.Migration == "" {
+			return err
+		}
+		// a ref created since the first message belongs to someone else
+		existing, err := a.client.ImageService().Get(ctx, req.Ref)
+		if err != nil {
+			return err
+		}
+		if existing.Labels[migrationLabel] != req.Migration {
+			return errors.Wrapf(errdefs.ErrAlreadyExists, "image %s", req.Ref)
+		}
+		if _, err := a.client.ImageService().Update(ctx, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitBlob commits the blob being written, verifying its size and digest against the index
+func commitBlob(ctx context.Context, w content.Writer, desc is.Descriptor) error {
+	if w == nil {
+		return nil
+	}
+	defer w.Close()
+	if err := w.Commit(ctx, desc.Size, desc.Digest); err != nil && !errdefs.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "commit %s", desc.Digest)
+	}
+	return nil
+}
+
+// send transfers the checkpoint at ref into the content store of the target agent,
+// only the blobs that the target does not have are sent
+func (a *Agent) send(ctx context.Context, to v1.AgentClient, ref, migration string) error {
+	image, err := a.client.GetImage(ctx, ref)
+	if err != nil {
+		return err
+	}
+	store := a.client.ContentStore()
+	data, err := content.ReadBlob(ctx, store, image.Target())
+	if err != nil {
+		return err
+	}
+	var index is.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return err
+	}
+	stream, err := to.Receive(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&v1.ReceiveRequest{
+		Ref:       ref,
+		Index:     data,
+		Migration: migration,
+	}); err != nil && err != io.EOF {
+		return err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	blobs := make(map[string]is.Descriptor)
+	for _, m := range index.Manifests {
+		blobs[m.Digest.String()] = m
+	}
+	for _, d := range resp.Missing {
+		desc, ok := blobs[d]
+		if !ok {
+			return errors.Errorf("target requested unknown blob %s", d)
+		}
+		if err := sendBlob(ctx, store, stream, desc); err != nil {
+			return errors.Wrapf(err, "send %s", d)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		if err == nil {
+			return errors.New("unexpected response from target")
+		}
+		return err
+	}
+	return nil
+}
+
+func sendBlob(ctx context.Context, store content.Provider, stream v1.Agent_ReceiveClient, desc is.Descriptor) error {
+	ra, err := store.ReaderAt(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+	r := content.NewReader(ra)
+	buf := make([]byte, sendChunkSize)
+	first := true
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 || first {
+			req := &v1.ReceiveRequest{
+				Data: buf[:n],
+			}
+			if first {
+				req.Digest = desc.Digest.String()
+				first = false
+			}
+			if err := stream.Send(req); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// isUnimplemented returns true if the target agent does not have the rpc
+func isUnimplemented(err error) bool {
+	return status.Code(errors.Cause(err)) == codes.Unimplemented
+}
@@ -0,0 +1,301 @@
+package agent
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	findingContainer = "container"
+	findingUnit      = "unit"
+	findingNetns     = "netns"
+	findingService   = "service"
+	findingConfig    = "config"
+)
+
+func (a *Agent) Doctor(ctx context.Context, req *v1.DoctorRequest) (*v1.DoctorResponse, error) {
+	ctx = relayContext(ctx)
+	findings, err := a.reconcile(ctx, req.Repair)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.DoctorResponse{
+		Findings: findings,
+	}, nil
+}
+
+// Reconcile repairs drift between containerd, systemd, the network, and the register.
+// It runs once and then on the interval until the context is done
+func (a *Agent) Reconcile(ctx context.Context, interval time.Duration) {
+	ctx = relayContext(ctx)
+	for {
+		findings, err := a.reconcile(ctx, true)
+		if err != nil {
+			logrus.WithError(err).Error("reconcile")
+		}
+		for _, f := range findings {
+			logger := logrus.WithFields(logrus.Fields{
+				"id":       f.ID,
+				"kind":     f.Kind,
+				"repaired": f.Repaired,
+			})
+			if f.Error != "" {
+				logger = logger.WithField("error", f.Error)
+			}
+			logger.Warn(f.Message)
+		}
+		if interval <= 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (a *Agent) reconcile(ctx context.Context, repair bool) ([]*v1.Finding, error) {
+	containers, err := a.containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	services, err := a.register.Services()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		findings []*v1.Finding
+		ids      = make(map[string]bool)
+		bossIDs  = make(map[string]bool)
+	)
+	for _, c := range containers {
+		ids[c.ID()] = true
+		info, err := c.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+		bossIDs[opts.ID(info)] = true
+		f, err := a.check(ctx, c, services, repair)
+		if err != nil {
+			findings = append(findings, &v1.Finding{
+				ID:      c.ID(),
+				Kind:    findingContainer,
+				Message: "unable to check container",
+				Error:   err.Error(),
+			})
+			continue
+		}
+		findings = append(findings, f...)
+	}
+	// services are registered under the boss id of the container
+	for id, names := range services {
+		if bossIDs[id] {
+			continue
+		}
+		for _, name := range names {
+			name := name
+			findings = append(findings, a.orphan(ctx, id, repair, &v1.Finding{
+				ID:      id,
+				Kind:    findingService,
+				Message: "service " + name + " is registered for a missing container",
+			}, func() error {
+				return a.register.Deregister(id, name)
+			}))
+		}
+	}
+	dirs, err := ioutil.ReadDir(v1.State)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, d := range dirs {
+		id := d.Name()
+		if !d.IsDir() || ids[id] {
+			continue
+		}
+		message := "state directory exists for a missing container"
+		if _, err := os.Lstat(v1.NetworkPath(id)); err == nil {
+			message = "network namespace exists for a missing container"
+		}
+		findings = append(findings, a.orphan(ctx, id, repair, &v1.Finding{
+			ID:      id,
+			Kind:    findingNetns,
+			Message: message,
+		}, func() error {
+			path := v1.NetworkPath(id)
+			if err := unix.Unmount(path, unix.MNT_DETACH); err != nil && err != unix.EINVAL && err != unix.ENOENT {
+				return err
+			}
+			return os.RemoveAll(filepath.Dir(path))
+		}))
+	}
+	return findings, nil
+}
+
+// check compares a container's config with its unit, network, services, and rendered configs
+func (a *Agent) check(ctx context.Context, container containerd.Container, services map[string][]string, repair bool) ([]*v1.Finding, error) {
+	id := container.ID()
+	labels, err := container.Labels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// operations lock the boss id which differs from the containerd id for siblings
+	lockID := labels[opts.IDLabel]
+	if lockID == "" {
+		lockID = id
+	}
+	unlock, err := a.locks.lock(ctx, lockID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	info, err := container.Info(ctx)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			// removed by an operation while waiting for the lock
+			return nil, nil
+		}
+		return nil, err
+	}
+	d := info.Extensions[opts.CurrentConfig]
+	config, err := opts.UnmarshalConfig(&d)
+	if err != nil {
+		return nil, err
+	}
+	var findings []*v1.Finding
+	enabled, err := unitEnabled(ctx, id, config)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		findings = append(findings, fix(repair, &v1.Finding{
+			ID:      id,
+			Kind:    findingUnit,
+			Message: "unit is not enabled",
+		}, func() error {
+			return enableUnit(ctx, id, config)
+		}))
+	}
+	running, err := isRunning(ctx, container)
+	if err != nil {
+		return nil, err
+	}
+	// services are registered under the boss id, by a blue/green sibling once it replaced the container
+	unregistered := opts.Unregistered(info.Labels)
+	isRegistered := make(map[string]bool)
+	for _, name := range services[config.ID] {
+		isRegistered[name] = true
+		if _, ok := config.Services[name]; ok || unregistered {
+			continue
+		}
+		name := name
+		findings = append(findings, fix(repair, &v1.Finding{
+			ID:      id,
+			Kind:    findingService,
+			Message: "service " + name + " is registered but not in the container config",
+		}, func() error {
+			return a.register.Deregister(config.ID, name)
+		}))
+	}
+	if !running {
+		return findings, nil
+	}
+	if v1.IsCNI(config.Network) {
+		if _, err := os.Lstat(v1.NetworkPath(id)); err != nil {
+			findings = append(findings, &v1.Finding{
+				ID:      id,
+				Kind:    findingNetns,
+				Message: "container is running without a network namespace",
+			})
+		}
+	}
+	for name, srv := range config.Services {
+		if isRegistered[name] || unregistered {
+			continue
+		}
+		ips, err := opts.ServiceIPs(info.Labels, srv)
+		if err != nil {
+			return nil, err
+		}
+		if len(ips) == 0 {
+			continue
+		}
+		name, srv := name, srv
+		findings = append(findings, fix(repair, &v1.Finding{
+			ID:      id,
+			Kind:    findingService,
+			Message: "service " + name + " is not registered",
+		}, func() error {
+			if err := a.register.Register(config.ID, name, ips, srv); err != nil {
+				return err
+			}
+			return a.register.DisableMaintainance(config.ID, name)
+		}))
+	}
+	for name := range config.Configs {
+		if _, err := os.Stat(v1.ConfigPath(id, name)); err != nil {
+			findings = append(findings, &v1.Finding{
+				ID:      id,
+				Kind:    findingConfig,
+				Message: "config " + name + " is not rendered",
+			})
+		}
+	}
+	return findings, nil
+}
+
+// orphan repairs state left behind by a container that no longer exists.
+// The container is checked again under its lock so an in-flight create is not undone
+func (a *Agent) orphan(ctx context.Context, id string, repair bool, f *v1.Finding, fn func() error) *v1.Finding {
+	return fix(repair, f, func() error {
+		unlock, err := a.locks.lock(ctx, id)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		if _, err := a.client.LoadContainer(ctx, id); err == nil {
+			return errContainerExists
+		} else if !errdefs.IsNotFound(err) {
+			return err
+		}
+		return fn()
+	})
+}
+
+// fix runs the repair for the finding when repairs are requested
+func fix(repair bool, f *v1.Finding, fn func() error) *v1.Finding {
+	if !repair {
+		return f
+	}
+	if err := fn(); err != nil {
+		f.Error = err.Error()
+		return f
+	}
+	f.Repaired = true
+	return f
+}
+
+func isRunning(ctx context.Context, container containerd.Container) (bool, error) {
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		return false, err
+	}
+	return status.Status == containerd.Running, nil
+}
@@ -0,0 +1,196 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/flux"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// Recover finishes or rolls back operations that were interrupted by an agent crash.
+// Creates and updates are rolled back unless the container was started, and deletes are finished
+func (a *Agent) Recover(ctx context.Context) error {
+	ctx = relayContext(ctx)
+	ops, err := a.journal.pending()
+	if err != nil {
+		return errors.Wrap(err, "read journal")
+	}
+	for _, op := range ops {
+		logger := logrus.WithFields(logrus.Fields{
+			"id":      op.ID,
+			"op":      op.Op,
+			"started": op.Started,
+		})
+		if err := a.replay(ctx, op); err != nil {
+			logger.WithError(err).Error("recover operation")
+			continue
+		}
+		logger.Info("recovered operation")
+	}
+	return nil
+}
+
+func (a *Agent) replay(ctx context.Context, op *operation) error {
+	switch op.Op {
+	case opCreate, opUpdate:
+		// the container was started before the agent died, only the journal entry is left
+		if op.started(stepStarted) {
+			return op.done()
+		}
+		return a.rollback(ctx, op)
+	case opDelete:
+		if err := a.remove(ctx, op.ID); err != nil && !errdefs.IsNotFound(errors.Cause(err)) {
+			return err
+		}
+		return op.done()
+	}
+	return errors.Errorf("unknown operation %q", op.Op)
+}
+
+// rollback runs the compensation for each started step in reverse order
+func (a *Agent) rollback(ctx context.Context, op *operation) error {
+	for i := len(op.Steps) - 1; i >= 0; i-- {
+		if err := a.compensate(ctx, op, op.Steps[i]); err != nil {
+			return errors.Wrapf(err, "compensate %s %s", op.Op, op.Steps[i])
+		}
+	}
+	return op.done()
+}
+
+// compensate undoes a step.
+// Steps are recorded before they run so compensations must handle steps that
+// never completed
+func (a *Agent) compensate(ctx context.Context, op *operation, step string) error {
+	switch step {
+	case stepStart:
+		config, err := a.unitConfig(ctx, op.ID)
+		if err != nil {
+			return err
+		}
+		return stopUnit(ctx, op.ID, config)
+	case stepEnable:
+		config, err := a.unitConfig(ctx, op.ID)
+		if err != nil {
+			return err
+		}
+		return disableUnit(ctx, op.ID, config)
+	case stepContainer:
+		container, err := a.client.LoadContainer(ctx, op.ID)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		info, err := container.Info(ctx)
+		if err != nil {
+			return err
+		}
+		// the create failed with AlreadyExists, or never ran, and the container belongs to someone else
+		if info.CreatedAt.Before(op.Started) {
+			return nil
+		}
+		return container.Delete(ctx, flux.WithRevisionCleanup)
+	case stepPause:
+		container, err := a.client.LoadContainer(ctx, op.ID)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		task, err := container.Task(ctx, nil)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		status, err := task.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if status.Status != containerd.Paused {
+			return nil
+		}
+		return task.Resume(ctx)
+	case stepApply:
+		return a.revert(ctx, op)
+	case stepVolumes:
+		return a.removeVolumes(ctx, op.Volumes)
+	}
+	// config files and task restarts do not need to be undone
+	return nil
+}
+
+// unitConfig returns the config used to pick the container's systemd units.
+// The container is created before its units so a missing container is treated as a service
+func (a *Agent) unitConfig(ctx context.Context, id string) (*v1.Container, error) {
+	container, err := a.client.LoadContainer(ctx, id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return &v1.Container{ID: id}, nil
+		}
+		return nil, err
+	}
+	return opts.GetConfig(ctx, container)
+}
+
+// revert puts back the container record from before an update and restarts
+// the task if it was already restarted with the new configuration
+func (a *Agent) revert(ctx context.Context, op *operation) error {
+	if op.Previous == nil {
+		return nil
+	}
+	container, err := a.client.LoadContainer(ctx, op.ID)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if err := container.Update(ctx, withPrevious(op.Previous)); err != nil {
+		return err
+	}
+	if !op.started(stepRestart) {
+		return nil
+	}
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return task.Kill(ctx, unix.SIGTERM)
+}
+
+func withPrevious(p *previous) containerd.UpdateContainerOpts {
+	return func(_ context.Context, _ *containerd.Client, c *containers.Container) error {
+		c.Image = p.Image
+		c.SnapshotKey = p.SnapshotKey
+		c.Spec = p.Spec
+		c.Extensions = p.Extensions
+		return nil
+	}
+}
+
+func getPrevious(ctx context.Context, container containerd.Container) (*previous, error) {
+	info, err := container.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &previous{
+		Image:       info.Image,
+		SnapshotKey: info.SnapshotKey,
+		Spec:        info.Spec,
+		Extensions:  info.Extensions,
+	}, nil
+}
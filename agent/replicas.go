@@ -0,0 +1,257 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/flux"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	errInvalidReplicas  = errors.New("replicas must be at least 1")
+	errNegativeReplicas = errors.New("replicas cannot be negative")
+)
+
+// validateReplicas checks the container's replica count, without replicas it is a standalone container
+func validateReplicas(c *v1.Container) error {
+	if c.Replicas < 0 {
+		return errNegativeReplicas
+	}
+	return nil
+}
+
+func (a *Agent) Scale(ctx context.Context, req *v1.ScaleRequest) (*types.Empty, error) {
+	ctx = relayContext(ctx)
+	if req.ID == "" {
+		return nil, ErrNoID
+	}
+	if req.Replicas < 1 {
+		return nil, errInvalidReplicas
+	}
+	unlock, err := a.locks.lock(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	replicas, err := a.replicas(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(replicas) == 0 {
+		return nil, errors.Wrapf(errdefs.ErrNotFound, "replicated container %s", req.ID)
+	}
+	current, err := opts.GetConfig(ctx, replicas[replicaIndexes(replicas)[0]])
+	if err != nil {
+		return nil, err
+	}
+	// replicas share a config apart from their id
+	config := *current
+	config.ID = req.ID
+	config.Replicas = req.Replicas
+	return empty, a.scale(ctx, &config, replicas)
+}
+
+// scale creates missing replicas and removes the ones past the config's replica count
+func (a *Agent) scale(ctx context.Context, c *v1.Container, replicas map[int]containerd.Container) error {
+	// the missing replicas' ids cannot be taken by standalone containers
+	for i := 0; i < int(c.Replicas); i++ {
+		if _, ok := replicas[i]; ok {
+			continue
+		}
+		if err := a.notExists(ctx, replicaID(c.ID, i)); err != nil {
+			return err
+		}
+	}
+	var image containerd.Image
+	for i := 0; i < int(c.Replicas); i++ {
+		if _, ok := replicas[i]; ok {
+			continue
+		}
+		if image == nil {
+			var err error
+			if image, err = a.client.Pull(ctx, c.Image, containerd.WithPullUnpack, withPlainRemote(c.Image)); err != nil {
+				return err
+			}
+		}
+		if err := a.createReplica(ctx, image, c, i); err != nil {
+			return err
+		}
+	}
+	indexes := replicaIndexes(replicas)
+	// remove the highest replicas first
+	for j := len(indexes) - 1; j >= 0; j-- {
+		i := indexes[j]
+		if i < int(c.Replicas) {
+			break
+		}
+		if err := a.withLock(ctx, replicaID(c.ID, i), func() error {
+			_, err := a.delete(ctx, replicas[i].ID())
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Agent) createReplica(ctx context.Context, image containerd.Image, c *v1.Container, i int) error {
+	config := replicaConfig(c, i)
+	return a.withLock(ctx, config.ID, func() error {
+		op, err := a.journal.begin(config.ID, opCreate, nil)
+		if err != nil {
+			return err
+		}
+		return a.create(ctx, op, config, func() error {
+			_, err := a.client.NewContainer(ctx,
+				config.ID,
+				flux.WithNewSnapshot(image),
+				opts.WithBossConfig(a.c.Agent.VolumeRoot, config, image),
+				opts.WithReplica(c.ID, i),
+			)
+			return err
+		})
+	})
+}
+
+// updateReplicas updates one replica at a time, waiting for each to be healthy
+// before moving on, and scales to the new replica count
+func (a *Agent) updateReplicas(ctx context.Context, req *v1.UpdateRequest) (*v1.UpdateResponse, error) {
+	c := req.Container
+	replicas, err := a.replicas(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(replicas) == 0 {
+		return nil, errors.Wrapf(errdefs.ErrNotFound, "replicated container %s", c.ID)
+	}
+	var resp *v1.UpdateResponse
+	for _, i := range replicaIndexes(replicas) {
+		if i >= int(c.Replicas) {
+			continue
+		}
+		config := replicaConfig(c, i)
+		if err := a.withLock(ctx, config.ID, func() error {
+			r, err := a.update(ctx, &v1.UpdateRequest{
+				Container: config,
+				DryRun:    req.DryRun,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "update %s", config.ID)
+			}
+			if resp == nil {
+				resp = r
+			}
+			if req.DryRun || !r.Diff.NewRevision {
+				return nil
+			}
+			container, err := a.load(ctx, config.ID)
+			if err != nil {
+				return err
+			}
+			_, err = waitHealthy(ctx, container, config, healthTimeout(c))
+			return errors.Wrapf(err, "%s did not become healthy", config.ID)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if req.DryRun {
+		return resp, nil
+	}
+	if err := a.scale(ctx, c, replicas); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// deleteReplicas deletes all replicas of the container
+func (a *Agent) deleteReplicas(ctx context.Context, id string, replicas map[int]containerd.Container) (*types.Empty, error) {
+	for _, i := range replicaIndexes(replicas) {
+		container := replicas[i]
+		if err := a.withLock(ctx, replicaID(id, i), func() error {
+			_, err := a.delete(ctx, container.ID())
+			return err
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return empty, nil
+}
+
+// replicas returns the containers of the replicated container by replica index
+func (a *Agent) replicas(ctx context.Context, id string) (map[int]containerd.Container, error) {
+	containers, err := a.client.Containers(ctx, fmt.Sprintf("labels.%q==%q", opts.GroupLabel, id))
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[int]containerd.Container)
+	for _, c := range containers {
+		labels, err := c.Labels(ctx)
+		if err != nil {
+			return nil, err
+		}
+		i, err := strconv.Atoi(labels[opts.ReplicaLabel])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid replica label on %s", c.ID())
+		}
+		out[i] = c
+	}
+	return out, nil
+}
+
+// notExists returns an error if a container with the id exists
+func (a *Agent) notExists(ctx context.Context, id string) error {
+	_, err := a.load(ctx, id)
+	if err == nil {
+		return errors.Wrapf(errdefs.ErrAlreadyExists, "container %s", id)
+	}
+	if errdefs.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (a *Agent) withLock(ctx context.Context, id string, fn func() error) error {
+	unlock, err := a.locks.lock(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return fn()
+}
+
+// replicaConfig returns the config of a single replica of the container
+func replicaConfig(c *v1.Container, i int) *v1.Container {
+	config := *c
+	config.ID = replicaID(c.ID, i)
+	config.Replicas = 0
+	return &config
+}
+
+func replicaID(id string, i int) string {
+	return fmt.Sprintf("%s-%d", id, i)
+}
+
+func replicaIndexes(replicas map[int]containerd.Container) []int {
+	var indexes []int
+	for i := range replicas {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+func healthTimeout(c *v1.Container) time.Duration {
+	if c.Update == nil {
+		return defaultHealthTimeout
+	}
+	return seconds(c.Update.HealthTimeout, defaultHealthTimeout)
+}
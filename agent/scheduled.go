@@ -0,0 +1,241 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ScheduledCheckpointLabel is set on the images of scheduled checkpoints to the id of their container
+	ScheduledCheckpointLabel = "io/boss/checkpoint.scheduled"
+
+	// defaultCheckpointRef names scheduled checkpoints that are not pushed
+	defaultCheckpointRef = "checkpoints/{{.ID}}:{{.Timestamp}}"
+	checkpointTick       = 30 * time.Second
+)
+
+// checkpointRef is the data of a checkpoint ref template
+type checkpointRef struct {
+	ID        string
+	Timestamp string
+}
+
+// scheduled holds the status of the last scheduled checkpoint of each container
+type scheduled struct {
+	mu     sync.Mutex
+	status map[string]*v1.CheckpointStatus
+}
+
+func (s *scheduled) get(id string) *v1.CheckpointStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status[id]
+}
+
+func (s *scheduled) set(id string, status *v1.CheckpointStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status == nil {
+		s.status = make(map[string]*v1.CheckpointStatus)
+	}
+	s.status[id] = status
+}
+
+func validateCheckpoint(c *v1.Container) error {
+	p := c.Checkpoint
+	if p == nil {
+		return nil
+	}
+	if p.Interval <= 0 {
+		return errors.New("checkpoint interval must be greater than zero")
+	}
+	if p.Retain < 0 {
+		return errors.New("checkpoint retain cannot be negative")
+	}
+	if _, err := renderCheckpointRef(p, c.ID, time.Now()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ScheduleCheckpoints checkpoints containers with a checkpoint policy on their interval until the context is done
+func (a *Agent) ScheduleCheckpoints(ctx context.Context) {
+	ctx = relayContext(ctx)
+	for {
+		if err := a.scheduleCheckpoints(ctx); err != nil {
+			logrus.WithError(err).Error("scheduled checkpoints")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(checkpointTick):
+		}
+	}
+}
+
+func (a *Agent) scheduleCheckpoints(ctx context.Context) error {
+	containers, err := a.containers(ctx)
+	if err != nil {
+		return err
+	}
+	// one container's error is logged so that it does not hold up the checkpoints of the others
+	for _, c := range containers {
+		info, err := c.Info(ctx)
+		if err != nil {
+			if !errdefs.IsNotFound(err) {
+				logrus.WithError(err).WithField("id", c.ID()).Error("scheduled checkpoint info")
+			}
+			continue
+		}
+		d, ok := info.Extensions[opts.CurrentConfig]
+		if !ok {
+			continue
+		}
+		config, err := opts.UnmarshalConfig(&d)
+		if err != nil {
+			logrus.WithError(err).WithField("id", c.ID()).Error("scheduled checkpoint config")
+			continue
+		}
+		p := config.Checkpoint
+		if p == nil || p.Interval <= 0 {
+			continue
+		}
+		id := opts.ID(info)
+		last, err := a.lastCheckpoint(ctx, id)
+		if err != nil {
+			logrus.WithError(err).WithField("id", id).Error("last scheduled checkpoint")
+			continue
+		}
+		if time.Since(last) < time.Duration(p.Interval)*time.Second {
+			continue
+		}
+		status := &v1.CheckpointStatus{
+			Last: time.Now(),
+		}
+		status.Ref, err = a.scheduledCheckpoint(ctx, id, p)
+		logger := logrus.WithFields(logrus.Fields{
+			"id":  id,
+			"ref": status.Ref,
+		})
+		if err != nil {
+			status.Error = err.Error()
+			logger.WithError(err).Error("scheduled checkpoint")
+		} else {
+			logger.Info("scheduled checkpoint")
+		}
+		a.scheduled.set(id, status)
+	}
+	return nil
+}
+
+// lastCheckpoint returns the time of the container's last scheduled checkpoint,
+// failed attempts count so that they are retried on the next interval
+func (a *Agent) lastCheckpoint(ctx context.Context, id string) (time.Time, error) {
+	var last time.Time
+	if status := a.scheduled.get(id); status != nil {
+		last = status.Last
+	}
+	list, err := a.scheduledImages(ctx, id)
+	if err != nil {
+		return last, err
+	}
+	if len(list) > 0 && list[0].CreatedAt.After(last) {
+		last = list[0].CreatedAt
+	}
+	return last, nil
+}
+
+func (a *Agent) scheduledCheckpoint(ctx context.Context, id string, p *v1.CheckpointPolicy) (string, error) {
+	ref, err := renderCheckpointRef(p, id, time.Now())
+	if err != nil {
+		return "", err
+	}
+	unlock, err := a.locks.lock(ctx, id)
+	if err != nil {
+		return ref, err
+	}
+	defer unlock()
+	if _, err := a.checkpoint(ctx, &v1.CheckpointRequest{
+		ID:   id,
+		Ref:  ref,
+		Live: p.Live,
+	}, nil); err != nil {
+		return ref, err
+	}
+	if _, err := a.client.ImageService().Update(ctx, images.Image{
+		Name: ref,
+		Labels: map[string]string{
+			ScheduledCheckpointLabel: id,
+		},
+	}, "labels."+ScheduledCheckpointLabel); err != nil {
+		return ref, err
+	}
+	if p.Push != "" {
+		if _, err := a.Push(ctx, &v1.PushRequest{
+			Ref: ref,
+		}); err != nil {
+			return ref, errors.Wrap(err, "push")
+		}
+	}
+	return ref, a.pruneCheckpoints(ctx, id, p.Retain)
+}
+
+// pruneCheckpoints removes the container's oldest scheduled checkpoints so that only retain are kept
+func (a *Agent) pruneCheckpoints(ctx context.Context, id string, retain int64) error {
+	if retain <= 0 {
+		return nil
+	}
+	list, err := a.scheduledImages(ctx, id)
+	if err != nil {
+		return err
+	}
+	for i := int(retain); i < len(list); i++ {
+		if err := a.client.ImageService().Delete(ctx, list[i].Name); err != nil {
+			return errors.Wrapf(err, "prune %s", list[i].Name)
+		}
+	}
+	return nil
+}
+
+// scheduledImages returns the images of the container's scheduled checkpoints, newest first
+func (a *Agent) scheduledImages(ctx context.Context, id string) ([]images.Image, error) {
+	list, err := a.client.ImageService().List(ctx, fmt.Sprintf("labels.%q==%q", ScheduledCheckpointLabel, id))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+	return list, nil
+}
+
+func renderCheckpointRef(p *v1.CheckpointPolicy, id string, now time.Time) (string, error) {
+	text := p.Push
+	if text == "" {
+		text = defaultCheckpointRef
+	}
+	t, err := template.New("checkpoint").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrap(err, "parse checkpoint ref")
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, checkpointRef{
+		ID:        id,
+		Timestamp: now.UTC().Format("20060102-150405"),
+	}); err != nil {
+		return "", errors.Wrap(err, "render checkpoint ref")
+	}
+	return buf.String(), nil
+}
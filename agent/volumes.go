@@ -0,0 +1,559 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/crosbymichael/boss/volumes"
+	"github.com/gogo/protobuf/types"
+	ver "github.com/opencontainers/image-spec/specs-go"
+	is "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	MediaTypeVolumeConfig = "application/vnd.boss.volume.config.v1+json"
+	// MediaTypeVolumeLayer is a gzipped tar of a volume's files in a checkpoint
+	MediaTypeVolumeLayer = "application/vnd.boss.volume.layer.v1.tar+gzip"
+
+	// annotations of volume layers so the volume can be recreated on restore
+	AnnotationVolumeID    = "io.boss.volume.id"
+	AnnotationVolumeQuota = "io.boss.volume.quota"
+	AnnotationVolumeUID   = "io.boss.volume.uid"
+	AnnotationVolumeGID   = "io.boss.volume.gid"
+
+	// volumeLock is the lock prefix for volume operations so they do not collide with container ids
+	volumeLock      = "volume/"
+	exportChunkSize = 32 * 1024
+)
+
+// volumeConfig is the config of a volume snapshot image
+type volumeConfig struct {
+	ID      string    `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+// volumeUser is a container that mounts a volume
+type volumeUser struct {
+	id        string
+	container containerd.Container
+}
+
+// MountVolumes mounts the filesystem images of volumes with loop quotas before containers use them
+func (a *Agent) MountVolumes(ctx context.Context) error {
+	if a.c.Agent.VolumeRoot == "" {
+		return nil
+	}
+	store, err := volumes.New(a.c.Agent.VolumeRoot)
+	if err != nil {
+		return err
+	}
+	return store.Mount(relayContext(ctx))
+}
+
+func (a *Agent) CreateVolume(ctx context.Context, req *v1.CreateVolumeRequest) (*types.Empty, error) {
+	ctx = relayContext(ctx)
+	if req.ID == "" {
+		return nil, ErrNoID
+	}
+	store, err := volumes.New(a.c.Agent.VolumeRoot)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := a.locks.lock(ctx, volumeLock+req.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	_, err = store.Create(ctx, req.ID, req.Quota, a.c.Agent.VolumeQuota, int(req.Uid), int(req.Gid))
+	return empty, err
+}
+
+func (a *Agent) ListVolumes(ctx context.Context, req *v1.ListVolumesRequest) (*v1.ListVolumesResponse, error) {
+	ctx = relayContext(ctx)
+	store, err := volumes.New(a.c.Agent.VolumeRoot)
+	if err != nil {
+		return nil, err
+	}
+	list, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	users, err := a.volumeUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resp v1.ListVolumesResponse
+	for _, v := range list {
+		size, err := store.Size(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "size of volume %s", v.ID)
+		}
+		info := &v1.VolumeInfo{
+			ID:        v.ID,
+			Size_:     size,
+			Quota:     v.Quota,
+			QuotaType: v.QuotaType,
+			Created:   v.Created,
+		}
+		for _, u := range users[v.ID] {
+			info.Containers = append(info.Containers, u.id)
+		}
+		resp.Volumes = append(resp.Volumes, info)
+	}
+	return &resp, nil
+}
+
+func (a *Agent) DeleteVolume(ctx context.Context, req *v1.DeleteVolumeRequest) (*types.Empty, error) {
+	ctx = relayContext(ctx)
+	if req.ID == "" {
+		return nil, ErrNoID
+	}
+	store, err := volumes.New(a.c.Agent.VolumeRoot)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := a.locks.lock(ctx, volumeLock+req.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	users, err := a.volumeUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if u := users[req.ID]; len(u) > 0 {
+		var ids []string
+		for _, user := range u {
+			ids = append(ids, user.id)
+		}
+		return nil, errors.Wrapf(errdefs.ErrFailedPrecondition, "volume %s is used by %s", req.ID, strings.Join(ids, ", "))
+	}
+	return empty, store.Delete(ctx, req.ID)
+}
+
+// SnapshotVolume stores the volume as an image with a single gzipped layer so it can be pushed.
+// Containers using the volume are paused while it is read
+func (a *Agent) SnapshotVolume(ctx context.Context, req *v1.SnapshotVolumeRequest) (*v1.SnapshotVolumeResponse, error) {
+	ctx = relayContext(ctx)
+	if req.ID == "" {
+		return nil, ErrNoID
+	}
+	if req.Ref == "" {
+		return nil, ErrNoRef
+	}
+	store, err := volumes.New(a.c.Agent.VolumeRoot)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := a.locks.lock(ctx, volumeLock+req.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	ctx, done, err := a.client.WithLease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done(ctx)
+	layer, err := a.writeVolume(ctx, store, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(volumeConfig{
+		ID:      req.ID,
+		Created: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	config, err := writeContent(ctx, a.client.ContentStore(), MediaTypeVolumeConfig, req.ID+"-volume-config", strings.NewReader(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	manifest := is.Manifest{
+		Versioned: ver.Versioned{
+			SchemaVersion: 2,
+		},
+		Config: config,
+		Layers: []is.Descriptor{layer},
+	}
+	if data, err = json.Marshal(manifest); err != nil {
+		return nil, err
+	}
+	desc, err := writeContent(ctx, a.client.ContentStore(), is.MediaTypeImageManifest, req.ID+"-volume-manifest", strings.NewReader(string(data)),
+		content.WithLabels(map[string]string{
+			"containerd.io/gc.ref.content.0": config.Digest.String(),
+			"containerd.io/gc.ref.content.1": layer.Digest.String(),
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.saveImage(ctx, req.Ref, desc); err != nil {
+		return nil, err
+	}
+	return &v1.SnapshotVolumeResponse{
+		Digest: layer.Digest.String(),
+		Size_:  layer.Size,
+	}, nil
+}
+
+// ExportVolume streams the volume as a gzipped tar.
+// Containers using the volume are paused while it is copied to a temporary file
+func (a *Agent) ExportVolume(req *v1.ExportVolumeRequest, stream v1.Agent_ExportVolumeServer) error {
+	ctx := relayContext(stream.Context())
+	if req.ID == "" {
+		return ErrNoID
+	}
+	store, err := volumes.New(a.c.Agent.VolumeRoot)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile("", "boss-export-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := a.exportVolume(ctx, store, req.ID, f); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	buf := make([]byte, exportChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&v1.ExportVolumeResponse{
+				Data: buf[:n],
+			}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// writeVolume writes the volume into the content store as a gzipped tar layer
+func (a *Agent) writeVolume(ctx context.Context, store *volumes.Store, id string) (layer is.Descriptor, err error) {
+	err = a.withUsers(ctx, id, func(users []volumeUser) error {
+		return a.pauseUsers(ctx, users, func() error {
+			layer, err = a.volumeLayer(ctx, store, id, is.MediaTypeImageLayerGzip, id+"-volume")
+			return err
+		})
+	})
+	return layer, err
+}
+
+func (a *Agent) volumeLayer(ctx context.Context, store *volumes.Store, id, mediaType, ref string) (is.Descriptor, error) {
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(store.Export(ctx, w, id))
+	}()
+	layer, err := writeContent(ctx, a.client.ContentStore(), mediaType, ref, r)
+	r.CloseWithError(err)
+	return layer, err
+}
+
+// checkpointVolumes returns the ids of the container's volumes to include in a checkpoint
+func checkpointVolumes(config *v1.Container, include, exclude []string) ([]string, error) {
+	mounted := make(map[string]bool)
+	for _, v := range config.Volumes {
+		mounted[v.ID] = true
+	}
+	for _, id := range append(include, exclude...) {
+		if !mounted[id] {
+			return nil, errors.Errorf("volume %s is not mounted by %s", id, config.ID)
+		}
+	}
+	if len(include) == 0 {
+		for _, v := range config.Volumes {
+			include = append(include, v.ID)
+		}
+	}
+	skip := make(map[string]bool)
+	for _, id := range exclude {
+		skip[id] = true
+	}
+	var ids []string
+	for _, id := range include {
+		if !skip[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// writeVolumeLayers writes a layer for each volume into the content store for a checkpoint.
+// The container must be paused so that its volumes do not change while they are read
+func (a *Agent) writeVolumeLayers(ctx context.Context, ids []string) ([]is.Descriptor, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	store, err := volumes.New(a.c.Agent.VolumeRoot)
+	if err != nil {
+		return nil, err
+	}
+	var layers []is.Descriptor
+	for _, id := range ids {
+		layer, err := a.writeVolumeLayer(ctx, store, id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "checkpoint volume %s", id)
+		}
+		layers = append(layers, layer)
+	}
+	return layers, nil
+}
+
+func (a *Agent) writeVolumeLayer(ctx context.Context, store *volumes.Store, id string) (is.Descriptor, error) {
+	unlock, err := a.locks.lock(ctx, volumeLock+id)
+	if err != nil {
+		return is.Descriptor{}, err
+	}
+	defer unlock()
+	v, err := store.Get(id)
+	if err != nil {
+		return is.Descriptor{}, err
+	}
+	var st syscall.Stat_t
+	if err := syscall.Stat(store.Path(id), &st); err != nil {
+		return is.Descriptor{}, err
+	}
+	layer, err := a.volumeLayer(ctx, store, id, MediaTypeVolumeLayer, "checkpoint-volume-"+id)
+	if err != nil {
+		return is.Descriptor{}, err
+	}
+	layer.Annotations = map[string]string{
+		AnnotationVolumeID:  id,
+		AnnotationVolumeUID: strconv.FormatUint(uint64(st.Uid), 10),
+		AnnotationVolumeGID: strconv.FormatUint(uint64(st.Gid), 10),
+	}
+	if v.Quota > 0 {
+		layer.Annotations[AnnotationVolumeQuota] = strconv.FormatInt(v.Quota, 10)
+	}
+	return layer, nil
+}
+
+// restoreVolumes unpacks the volume layers of a checkpoint into the volumes that ids maps them to,
+// volumes that do not exist on this agent are created with the quota and owner they were checkpointed with
+// and recorded in the operation, and volumes used by other containers are not overwritten
+func (a *Agent) restoreVolumes(ctx context.Context, op *operation, index *is.Index, ids map[string]string) error {
+	var layers []is.Descriptor
+	for _, d := range index.Manifests {
+		if d.MediaType != MediaTypeVolumeLayer {
+			continue
+		}
+		if _, ok := ids[d.Annotations[AnnotationVolumeID]]; ok {
+			layers = append(layers, d)
+		}
+	}
+	if len(layers) == 0 {
+		return nil
+	}
+	store, err := volumes.New(a.c.Agent.VolumeRoot)
+	if err != nil {
+		return err
+	}
+	users, err := a.volumeUsers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, layer := range layers {
+		id := ids[layer.Annotations[AnnotationVolumeID]]
+		if u := users[id]; len(u) > 0 {
+			var used []string
+			for _, user := range u {
+				used = append(used, user.id)
+			}
+			return errors.Wrapf(errdefs.ErrFailedPrecondition, "volume %s is used by %s", id, strings.Join(used, ", "))
+		}
+		if err := a.restoreVolume(ctx, op, store, id, layer); err != nil {
+			return errors.Wrapf(err, "restore volume %s", id)
+		}
+	}
+	return nil
+}
+
+func (a *Agent) restoreVolume(ctx context.Context, op *operation, store *volumes.Store, id string, layer is.Descriptor) error {
+	unlock, err := a.locks.lock(ctx, volumeLock+id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if _, err := store.Get(id); err != nil {
+		if !errdefs.IsNotFound(err) {
+			return err
+		}
+		var quota, uid, gid int64
+		for key, v := range map[string]*int64{
+			AnnotationVolumeQuota: &quota,
+			AnnotationVolumeUID:   &uid,
+			AnnotationVolumeGID:   &gid,
+		} {
+			if s, ok := layer.Annotations[key]; ok {
+				if *v, err = strconv.ParseInt(s, 10, 64); err != nil {
+					return errors.Wrapf(err, "parse %s", key)
+				}
+			}
+		}
+		if err := op.createVolume(id); err != nil {
+			return err
+		}
+		if _, err := store.Create(ctx, id, quota, a.c.Agent.VolumeQuota, int(uid), int(gid)); err != nil {
+			return err
+		}
+	}
+	ra, err := a.client.ContentStore().ReaderAt(ctx, layer)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+	return store.Import(ctx, content.NewReader(ra), id)
+}
+
+// removeVolumes deletes the volumes created by a rolled back restore
+func (a *Agent) removeVolumes(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	store, err := volumes.New(a.c.Agent.VolumeRoot)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := store.Delete(ctx, id); err != nil && !errdefs.IsNotFound(errors.Cause(err)) {
+			return errors.Wrapf(err, "delete volume %s", id)
+		}
+	}
+	return nil
+}
+
+func (a *Agent) exportVolume(ctx context.Context, store *volumes.Store, id string, w io.Writer) error {
+	unlock, err := a.locks.lock(ctx, volumeLock+id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return a.withUsers(ctx, id, func(users []volumeUser) error {
+		return a.pauseUsers(ctx, users, func() error {
+			return store.Export(ctx, w, id)
+		})
+	})
+}
+
+func (a *Agent) withUsers(ctx context.Context, id string, fn func([]volumeUser) error) error {
+	users, err := a.volumeUsers(ctx)
+	if err != nil {
+		return err
+	}
+	return fn(users[id])
+}
+
+// saveImage points the image ref at the descriptor, creating it if it does not exist
+func (a *Agent) saveImage(ctx context.Context, ref string, desc is.Descriptor) error {
+	i := images.Image{
+		Name:   ref,
+		Target: desc,
+	}
+	if _, err := a.client.ImageService().Create(ctx, i); err != nil {
+		if !errdefs.IsAlreadyExists(err) {
+			return err
+		}
+		if _, err := a.client.ImageService().Update(ctx, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// volumeUsers returns the containers that mount each volume, by volume id
+func (a *Agent) volumeUsers(ctx context.Context) (map[string][]volumeUser, error) {
+	containers, err := a.containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[string][]volumeUser)
+	for _, c := range containers {
+		info, err := c.Info(ctx)
+		if err != nil {
+			return nil, err
+		}
+		d, ok := info.Extensions[opts.CurrentConfig]
+		if !ok {
+			continue
+		}
+		config, err := opts.UnmarshalConfig(&d)
+		if err != nil {
+			return nil, err
+		}
+		ids := make(map[string]bool)
+		for _, v := range config.Volumes {
+			ids[v.ID] = true
+		}
+		for _, helpers := range [][]*v1.Helper{config.Init, config.Sidecars} {
+			for _, h := range helpers {
+				for _, v := range h.Volumes {
+					ids[v.ID] = true
+				}
+			}
+		}
+		for id := range ids {
+			users[id] = append(users[id], volumeUser{
+				id:        opts.ID(info),
+				container: c,
+			})
+		}
+	}
+	for _, u := range users {
+		sort.Slice(u, func(i, j int) bool {
+			return u[i].id < u[j].id
+		})
+	}
+	return users, nil
+}
+
+// pauseUsers pauses the containers using a volume, and their sidecars, while fn runs
+func (a *Agent) pauseUsers(ctx context.Context, users []volumeUser, fn func() error) error {
+	if len(users) == 0 {
+		return fn()
+	}
+	sidecars, err := a.client.Containers(ctx, fmt.Sprintf("labels.%q==%q", opts.HelperLabel, users[0].container.ID()))
+	if err != nil {
+		return err
+	}
+	containers := append([]containerd.Container{users[0].container}, sidecars...)
+	return pauseAll(ctx, containers, func() error {
+		return a.pauseUsers(ctx, users[1:], fn)
+	})
+}
+
+func pauseAll(ctx context.Context, containers []containerd.Container, fn func() error) error {
+	if len(containers) == 0 {
+		return fn()
+	}
+	return pauseAndRun(ctx, containers[0], func() error {
+		return pauseAll(ctx, containers[1:], fn)
+	})
+}
@@ -1,10 +1,18 @@
 package api
 
 import (
+	"crypto/tls"
+	"net"
+	"strings"
+	"time"
+
 	"github.com/crosbymichael/boss/api/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
+const unixPrefix = "unix://"
+
 type LocalAgent struct {
 	v1.AgentClient
 	conn *grpc.ClientConn
@@ -14,8 +22,20 @@ func (a *LocalAgent) Close() error {
 	return a.conn.Close()
 }
 
-func Agent(address string) (*LocalAgent, error) {
-	conn, err := grpc.Dial(address, grpc.WithInsecure())
+// Agent dials the agent at the address, using mutual tls when a config is provided.
+// unix:// addresses connect to the agent's local socket and do not use tls
+func Agent(address string, c *tls.Config) (*LocalAgent, error) {
+	var opts []grpc.DialOption
+	switch {
+	case strings.HasPrefix(address, unixPrefix):
+		address = strings.TrimPrefix(address, unixPrefix)
+		opts = append(opts, grpc.WithInsecure(), grpc.WithDialer(unixDialer))
+	case c != nil:
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(c)))
+	default:
+		opts = append(opts, grpc.WithInsecure())
+	}
+	conn, err := grpc.Dial(strings.TrimPrefix(address, "tcp://"), opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -24,3 +44,7 @@ func Agent(address string) (*LocalAgent, error) {
 		conn:        conn,
 	}, nil
 }
+
+func unixDialer(path string, timeout time.Duration) (net.Conn, error) {
+	return net.DialTimeout("unix", path, timeout)
+}
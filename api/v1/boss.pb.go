@@ -41,7 +41,7 @@ func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
 func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRequest) ProtoMessage()    {}
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{0}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{0}
 }
 func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
@@ -86,7 +86,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{1}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{1}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
@@ -124,7 +124,7 @@ func (m *GetRequest) Reset()         { *m = GetRequest{} }
 func (m *GetRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRequest) ProtoMessage()    {}
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{2}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{2}
 }
 func (m *GetRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
@@ -162,7 +162,7 @@ func (m *GetResponse) Reset()         { *m = GetResponse{} }
 func (m *GetResponse) String() string { return proto.CompactTextString(m) }
 func (*GetResponse) ProtoMessage()    {}
 func (*GetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{3}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{3}
 }
 func (m *GetResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
@@ -201,7 +201,7 @@ func (m *KillRequest) Reset()         { *m = KillRequest{} }
 func (m *KillRequest) String() string { return proto.CompactTextString(m) }
 func (*KillRequest) ProtoMessage()    {}
 func (*KillRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{4}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{4}
 }
 func (m *KillRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KillRequest.Unmarshal(m, b)
@@ -245,7 +245,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{5}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{5}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
@@ -276,7 +276,7 @@ func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{6}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{6}
 }
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
@@ -304,28 +304,36 @@ func (m *ListResponse) GetContainers() []*ContainerInfo {
 }
 
 type ContainerInfo struct {
-	ID                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Image                string      `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Status               string      `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	IP                   string      `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
-	Cpu                  uint64      `protobuf:"varint,5,opt,name=cpu,proto3" json:"cpu,omitempty"`
-	MemoryUsage          float64     `protobuf:"fixed64,6,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
-	MemoryLimit          float64     `protobuf:"fixed64,7,opt,name=memory_limit,json=memoryLimit,proto3" json:"memory_limit,omitempty"`
-	PidUsage             uint64      `protobuf:"varint,8,opt,name=pid_usage,json=pidUsage,proto3" json:"pid_usage,omitempty"`
-	PidLimit             uint64      `protobuf:"varint,9,opt,name=pid_limit,json=pidLimit,proto3" json:"pid_limit,omitempty"`
-	FsSize               int64       `protobuf:"varint,10,opt,name=fs_size,json=fsSize,proto3" json:"fs_size,omitempty"`
-	Config               *Container  `protobuf:"bytes,11,opt,name=config" json:"config,omitempty"`
-	Snapshots            []*Snapshot `protobuf:"bytes,12,rep,name=snapshots" json:"snapshots,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	ID          string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Image       string      `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Status      string      `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	IP          string      `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	Cpu         uint64      `protobuf:"varint,5,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	MemoryUsage float64     `protobuf:"fixed64,6,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
+	MemoryLimit float64     `protobuf:"fixed64,7,opt,name=memory_limit,json=memoryLimit,proto3" json:"memory_limit,omitempty"`
+	PidUsage    uint64      `protobuf:"varint,8,opt,name=pid_usage,json=pidUsage,proto3" json:"pid_usage,omitempty"`
+	PidLimit    uint64      `protobuf:"varint,9,opt,name=pid_limit,json=pidLimit,proto3" json:"pid_limit,omitempty"`
+	FsSize      int64       `protobuf:"varint,10,opt,name=fs_size,json=fsSize,proto3" json:"fs_size,omitempty"`
+	Config      *Container  `protobuf:"bytes,11,opt,name=config" json:"config,omitempty"`
+	Snapshots   []*Snapshot `protobuf:"bytes,12,rep,name=snapshots" json:"snapshots,omitempty"`
+	// group is the id of the replicated container this is a replica of
+	Group string `protobuf:"bytes,13,opt,name=group,proto3" json:"group,omitempty"`
+	// checkpoint is the last scheduled checkpoint of the container
+	Checkpoint *CheckpointStatus `protobuf:"bytes,14,opt,name=checkpoint" json:"checkpoint,omitempty"`
+	// ip6 is the ipv6 address of a dual-stack container
+	IP6 string `protobuf:"bytes,15,opt,name=ip6,proto3" json:"ip6,omitempty"`
+	// interfaces of the container on each of its networks
+	Interfaces           []*NetworkInterface `protobuf:"bytes,16,rep,name=interfaces" json:"interfaces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *ContainerInfo) Reset()         { *m = ContainerInfo{} }
 func (m *ContainerInfo) String() string { return proto.CompactTextString(m) }
 func (*ContainerInfo) ProtoMessage()    {}
 func (*ContainerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{7}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{7}
 }
 func (m *ContainerInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ContainerInfo.Unmarshal(m, b)
@@ -429,6 +437,144 @@ func (m *ContainerInfo) GetSnapshots() []*Snapshot {
 	return nil
 }
 
+func (m *ContainerInfo) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetCheckpoint() *CheckpointStatus {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+func (m *ContainerInfo) GetIP6() string {
+	if m != nil {
+		return m.IP6
+	}
+	return ""
+}
+
+func (m *ContainerInfo) GetInterfaces() []*NetworkInterface {
+	if m != nil {
+		return m.Interfaces
+	}
+	return nil
+}
+
+type NetworkInterface struct {
+	// name of the interface in the container
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// network the interface is attached to
+	Network              string   `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	IPs                  []string `protobuf:"bytes,3,rep,name=ips" json:"ips,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NetworkInterface) Reset()         { *m = NetworkInterface{} }
+func (m *NetworkInterface) String() string { return proto.CompactTextString(m) }
+func (*NetworkInterface) ProtoMessage()    {}
+func (*NetworkInterface) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{8}
+}
+func (m *NetworkInterface) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkInterface.Unmarshal(m, b)
+}
+func (m *NetworkInterface) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkInterface.Marshal(b, m, deterministic)
+}
+func (dst *NetworkInterface) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkInterface.Merge(dst, src)
+}
+func (m *NetworkInterface) XXX_Size() int {
+	return xxx_messageInfo_NetworkInterface.Size(m)
+}
+func (m *NetworkInterface) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkInterface.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkInterface proto.InternalMessageInfo
+
+func (m *NetworkInterface) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NetworkInterface) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *NetworkInterface) GetIPs() []string {
+	if m != nil {
+		return m.IPs
+	}
+	return nil
+}
+
+type CheckpointStatus struct {
+	Ref                  string    `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	Last                 time.Time `protobuf:"bytes,2,opt,name=last,stdtime" json:"last"`
+	Error                string    `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CheckpointStatus) Reset()         { *m = CheckpointStatus{} }
+func (m *CheckpointStatus) String() string { return proto.CompactTextString(m) }
+func (*CheckpointStatus) ProtoMessage()    {}
+func (*CheckpointStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{9}
+}
+func (m *CheckpointStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointStatus.Unmarshal(m, b)
+}
+func (m *CheckpointStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointStatus.Marshal(b, m, deterministic)
+}
+func (dst *CheckpointStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointStatus.Merge(dst, src)
+}
+func (m *CheckpointStatus) XXX_Size() int {
+	return xxx_messageInfo_CheckpointStatus.Size(m)
+}
+func (m *CheckpointStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointStatus proto.InternalMessageInfo
+
+func (m *CheckpointStatus) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *CheckpointStatus) GetLast() time.Time {
+	if m != nil {
+		return m.Last
+	}
+	return time.Time{}
+}
+
+func (m *CheckpointStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type Snapshot struct {
 	ID                   string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Created              time.Time `protobuf:"bytes,2,opt,name=created,stdtime" json:"created"`
@@ -443,7 +589,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{8}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{10}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Snapshot.Unmarshal(m, b)
@@ -502,7 +648,7 @@ func (m *RollbackRequest) Reset()         { *m = RollbackRequest{} }
 func (m *RollbackRequest) String() string { return proto.CompactTextString(m) }
 func (*RollbackRequest) ProtoMessage()    {}
 func (*RollbackRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{9}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{11}
 }
 func (m *RollbackRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackRequest.Unmarshal(m, b)
@@ -540,7 +686,7 @@ func (m *RollbackResponse) Reset()         { *m = RollbackResponse{} }
 func (m *RollbackResponse) String() string { return proto.CompactTextString(m) }
 func (*RollbackResponse) ProtoMessage()    {}
 func (*RollbackResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{10}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{12}
 }
 func (m *RollbackResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackResponse.Unmarshal(m, b)
@@ -578,7 +724,7 @@ func (m *StartRequest) Reset()         { *m = StartRequest{} }
 func (m *StartRequest) String() string { return proto.CompactTextString(m) }
 func (*StartRequest) ProtoMessage()    {}
 func (*StartRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{11}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{13}
 }
 func (m *StartRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StartRequest.Unmarshal(m, b)
@@ -616,7 +762,7 @@ func (m *StopRequest) Reset()         { *m = StopRequest{} }
 func (m *StopRequest) String() string { return proto.CompactTextString(m) }
 func (*StopRequest) ProtoMessage()    {}
 func (*StopRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{12}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{14}
 }
 func (m *StopRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StopRequest.Unmarshal(m, b)
@@ -644,17 +790,19 @@ func (m *StopRequest) GetID() string {
 }
 
 type UpdateRequest struct {
-	Container            *Container `protobuf:"bytes,1,opt,name=container" json:"container,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Container *Container `protobuf:"bytes,1,opt,name=container" json:"container,omitempty"`
+	// dry_run returns the diff without applying the update
+	DryRun               bool     `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
 func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRequest) ProtoMessage()    {}
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{13}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{15}
 }
 func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
@@ -681,18 +829,26 @@ func (m *UpdateRequest) GetContainer() *Container {
 	return nil
 }
 
+func (m *UpdateRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 type UpdateResponse struct {
-	Container            *Container `protobuf:"bytes,1,opt,name=container" json:"container,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Container            *Container  `protobuf:"bytes,1,opt,name=container" json:"container,omitempty"`
+	Diff                 *UpdateDiff `protobuf:"bytes,2,opt,name=diff" json:"diff,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
 func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateResponse) ProtoMessage()    {}
 func (*UpdateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{14}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{16}
 }
 func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
@@ -719,6 +875,145 @@ func (m *UpdateResponse) GetContainer() *Container {
 	return nil
 }
 
+func (m *UpdateResponse) GetDiff() *UpdateDiff {
+	if m != nil {
+		return m.Diff
+	}
+	return nil
+}
+
+type UpdateDiff struct {
+	Changes              []*FieldChange `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
+	ImageDigest          string         `protobuf:"bytes,2,opt,name=image_digest,json=imageDigest,proto3" json:"image_digest,omitempty"`
+	NewImageDigest       string         `protobuf:"bytes,3,opt,name=new_image_digest,json=newImageDigest,proto3" json:"new_image_digest,omitempty"`
+	Restart              bool           `protobuf:"varint,4,opt,name=restart,proto3" json:"restart,omitempty"`
+	NewRevision          bool           `protobuf:"varint,5,opt,name=new_revision,json=newRevision,proto3" json:"new_revision,omitempty"`
+	Deregister           []string       `protobuf:"bytes,6,rep,name=deregister" json:"deregister,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *UpdateDiff) Reset()         { *m = UpdateDiff{} }
+func (m *UpdateDiff) String() string { return proto.CompactTextString(m) }
+func (*UpdateDiff) ProtoMessage()    {}
+func (*UpdateDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{17}
+}
+func (m *UpdateDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateDiff.Unmarshal(m, b)
+}
+func (m *UpdateDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateDiff.Marshal(b, m, deterministic)
+}
+func (dst *UpdateDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDiff.Merge(dst, src)
+}
+func (m *UpdateDiff) XXX_Size() int {
+	return xxx_messageInfo_UpdateDiff.Size(m)
+}
+func (m *UpdateDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateDiff proto.InternalMessageInfo
+
+func (m *UpdateDiff) GetChanges() []*FieldChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *UpdateDiff) GetImageDigest() string {
+	if m != nil {
+		return m.ImageDigest
+	}
+	return ""
+}
+
+func (m *UpdateDiff) GetNewImageDigest() string {
+	if m != nil {
+		return m.NewImageDigest
+	}
+	return ""
+}
+
+func (m *UpdateDiff) GetRestart() bool {
+	if m != nil {
+		return m.Restart
+	}
+	return false
+}
+
+func (m *UpdateDiff) GetNewRevision() bool {
+	if m != nil {
+		return m.NewRevision
+	}
+	return false
+}
+
+func (m *UpdateDiff) GetDeregister() []string {
+	if m != nil {
+		return m.Deregister
+	}
+	return nil
+}
+
+type FieldChange struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Old                  string   `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	New                  string   `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FieldChange) Reset()         { *m = FieldChange{} }
+func (m *FieldChange) String() string { return proto.CompactTextString(m) }
+func (*FieldChange) ProtoMessage()    {}
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{18}
+}
+func (m *FieldChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldChange.Unmarshal(m, b)
+}
+func (m *FieldChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldChange.Marshal(b, m, deterministic)
+}
+func (dst *FieldChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldChange.Merge(dst, src)
+}
+func (m *FieldChange) XXX_Size() int {
+	return xxx_messageInfo_FieldChange.Size(m)
+}
+func (m *FieldChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldChange proto.InternalMessageInfo
+
+func (m *FieldChange) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FieldChange) GetOld() string {
+	if m != nil {
+		return m.Old
+	}
+	return ""
+}
+
+func (m *FieldChange) GetNew() string {
+	if m != nil {
+		return m.New
+	}
+	return ""
+}
+
 type PushBuildRequest struct {
 	Ref                  string   `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -730,7 +1025,7 @@ func (m *PushBuildRequest) Reset()         { *m = PushBuildRequest{} }
 func (m *PushBuildRequest) String() string { return proto.CompactTextString(m) }
 func (*PushBuildRequest) ProtoMessage()    {}
 func (*PushBuildRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{15}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{19}
 }
 func (m *PushBuildRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PushBuildRequest.Unmarshal(m, b)
@@ -769,7 +1064,7 @@ func (m *PushRequest) Reset()         { *m = PushRequest{} }
 func (m *PushRequest) String() string { return proto.CompactTextString(m) }
 func (*PushRequest) ProtoMessage()    {}
 func (*PushRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{16}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{20}
 }
 func (m *PushRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PushRequest.Unmarshal(m, b)
@@ -804,10 +1099,14 @@ func (m *PushRequest) GetBuild() bool {
 }
 
 type CheckpointRequest struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Ref                  string   `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
-	Live                 bool     `protobuf:"varint,3,opt,name=live,proto3" json:"live,omitempty"`
-	Exit                 bool     `protobuf:"varint,4,opt,name=exit,proto3" json:"exit,omitempty"`
+	ID   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ref  string `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Live bool   `protobuf:"varint,3,opt,name=live,proto3" json:"live,omitempty"`
+	Exit bool   `protobuf:"varint,4,opt,name=exit,proto3" json:"exit,omitempty"`
+	// volumes to include in the checkpoint, all of the container's volumes when empty
+	Volumes []string `protobuf:"bytes,5,rep,name=volumes" json:"volumes,omitempty"`
+	// exclude_volumes are left out of the checkpoint
+	ExcludeVolumes       []string `protobuf:"bytes,6,rep,name=exclude_volumes,json=excludeVolumes" json:"exclude_volumes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -817,7 +1116,7 @@ func (m *CheckpointRequest) Reset()         { *m = CheckpointRequest{} }
 func (m *CheckpointRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckpointRequest) ProtoMessage()    {}
 func (*CheckpointRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{17}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{21}
 }
 func (m *CheckpointRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckpointRequest.Unmarshal(m, b)
@@ -865,6 +1164,20 @@ func (m *CheckpointRequest) GetExit() bool {
 	return false
 }
 
+func (m *CheckpointRequest) GetVolumes() []string {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+func (m *CheckpointRequest) GetExcludeVolumes() []string {
+	if m != nil {
+		return m.ExcludeVolumes
+	}
+	return nil
+}
+
 type CheckpointResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -875,7 +1188,7 @@ func (m *CheckpointResponse) Reset()         { *m = CheckpointResponse{} }
 func (m *CheckpointResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckpointResponse) ProtoMessage()    {}
 func (*CheckpointResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{18}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{22}
 }
 func (m *CheckpointResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckpointResponse.Unmarshal(m, b)
@@ -896,8 +1209,15 @@ func (m *CheckpointResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_CheckpointResponse proto.InternalMessageInfo
 
 type RestoreRequest struct {
-	Ref                  string   `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
-	Live                 bool     `protobuf:"varint,2,opt,name=live,proto3" json:"live,omitempty"`
+	Ref  string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	Live bool   `protobuf:"varint,2,opt,name=live,proto3" json:"live,omitempty"`
+	// id restores the checkpoint as a new container instead of its source
+	ID      string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Network string `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
+	// overrides are merged into the checkpointed config, lists replace the checkpointed ones
+	Overrides *Container `protobuf:"bytes,5,opt,name=overrides" json:"overrides,omitempty"`
+	// healthy waits for the restored container to pass its service checks
+	Healthy              bool     `protobuf:"varint,6,opt,name=healthy,proto3" json:"healthy,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -907,7 +1227,7 @@ func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
 func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
 func (*RestoreRequest) ProtoMessage()    {}
 func (*RestoreRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{19}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{23}
 }
 func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
@@ -941,7 +1261,36 @@ func (m *RestoreRequest) GetLive() bool {
 	return false
 }
 
+func (m *RestoreRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *RestoreRequest) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *RestoreRequest) GetOverrides() *Container {
+	if m != nil {
+		return m.Overrides
+	}
+	return nil
+}
+
+func (m *RestoreRequest) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
 type RestoreResponse struct {
+	IP                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -951,7 +1300,7 @@ func (m *RestoreResponse) Reset()         { *m = RestoreResponse{} }
 func (m *RestoreResponse) String() string { return proto.CompactTextString(m) }
 func (*RestoreResponse) ProtoMessage()    {}
 func (*RestoreResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{20}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{24}
 }
 func (m *RestoreResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RestoreResponse.Unmarshal(m, b)
@@ -971,13 +1320,28 @@ func (m *RestoreResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_RestoreResponse proto.InternalMessageInfo
 
+func (m *RestoreResponse) GetIP() string {
+	if m != nil {
+		return m.IP
+	}
+	return ""
+}
+
 type MigrateRequest struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Ref                  string   `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
-	Live                 bool     `protobuf:"varint,3,opt,name=live,proto3" json:"live,omitempty"`
-	Stop                 bool     `protobuf:"varint,4,opt,name=stop,proto3" json:"stop,omitempty"`
-	To                   string   `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
-	Delete               bool     `protobuf:"varint,6,opt,name=delete,proto3" json:"delete,omitempty"`
+	ID     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ref    string `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Live   bool   `protobuf:"varint,3,opt,name=live,proto3" json:"live,omitempty"`
+	Stop   bool   `protobuf:"varint,4,opt,name=stop,proto3" json:"stop,omitempty"`
+	To     string `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Delete bool   `protobuf:"varint,6,opt,name=delete,proto3" json:"delete,omitempty"`
+	// volumes to migrate, all of the container's volumes when empty
+	Volumes []string `protobuf:"bytes,7,rep,name=volumes" json:"volumes,omitempty"`
+	// exclude_volumes are not migrated
+	ExcludeVolumes []string `protobuf:"bytes,8,rep,name=exclude_volumes,json=excludeVolumes" json:"exclude_volumes,omitempty"`
+	// pre_dumps of the container's memory to push while it keeps running before the final live checkpoint
+	PreDumps int64 `protobuf:"varint,9,opt,name=pre_dumps,json=preDumps,proto3" json:"pre_dumps,omitempty"`
+	// registry pushes the checkpoint to the ref's registry for the target to pull instead of sending it to the target directly
+	Registry             bool     `protobuf:"varint,10,opt,name=registry,proto3" json:"registry,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -987,7 +1351,7 @@ func (m *MigrateRequest) Reset()         { *m = MigrateRequest{} }
 func (m *MigrateRequest) String() string { return proto.CompactTextString(m) }
 func (*MigrateRequest) ProtoMessage()    {}
 func (*MigrateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{21}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{25}
 }
 func (m *MigrateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MigrateRequest.Unmarshal(m, b)
@@ -1049,17 +1413,47 @@ func (m *MigrateRequest) GetDelete() bool {
 	return false
 }
 
+func (m *MigrateRequest) GetVolumes() []string {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+func (m *MigrateRequest) GetExcludeVolumes() []string {
+	if m != nil {
+		return m.ExcludeVolumes
+	}
+	return nil
+}
+
+func (m *MigrateRequest) GetPreDumps() int64 {
+	if m != nil {
+		return m.PreDumps
+	}
+	return 0
+}
+
+func (m *MigrateRequest) GetRegistry() bool {
+	if m != nil {
+		return m.Registry
+	}
+	return false
+}
+
+// MigrateResponse is sent as each phase of the migration completes
 type MigrateResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Phase                *MigratePhase `protobuf:"bytes,1,opt,name=phase" json:"phase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *MigrateResponse) Reset()         { *m = MigrateResponse{} }
 func (m *MigrateResponse) String() string { return proto.CompactTextString(m) }
 func (*MigrateResponse) ProtoMessage()    {}
 func (*MigrateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{22}
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{26}
 }
 func (m *MigrateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MigrateResponse.Unmarshal(m, b)
@@ -1079,651 +1473,3067 @@ func (m *MigrateResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_MigrateResponse proto.InternalMessageInfo
 
-type Container struct {
-	ID                   string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Image                string              `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Network              string              `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
-	Process              *Process            `protobuf:"bytes,4,opt,name=process" json:"process,omitempty"`
-	Mounts               []*Mount            `protobuf:"bytes,5,rep,name=mounts" json:"mounts,omitempty"`
-	Resources            *Resources          `protobuf:"bytes,6,opt,name=resources" json:"resources,omitempty"`
-	Gpus                 *GPUs               `protobuf:"bytes,7,opt,name=gpus" json:"gpus,omitempty"`
-	Services             map[string]*Service `protobuf:"bytes,8,rep,name=services" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
-	Configs              map[string]*Config  `protobuf:"bytes,9,rep,name=configs" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
-	Readonly             bool                `protobuf:"varint,10,opt,name=readonly,proto3" json:"readonly,omitempty"`
-	Volumes              []*Volume           `protobuf:"bytes,11,rep,name=volumes" json:"volumes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+func (m *MigrateResponse) GetPhase() *MigratePhase {
+	if m != nil {
+		return m.Phase
+	}
+	return nil
 }
 
-func (m *Container) Reset()         { *m = Container{} }
-func (m *Container) String() string { return proto.CompactTextString(m) }
-func (*Container) ProtoMessage()    {}
-func (*Container) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{23}
+type MigratePhase struct {
+	Name                 string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Duration             time.Duration `protobuf:"bytes,2,opt,name=duration,stdduration" json:"duration"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
-func (m *Container) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Container.Unmarshal(m, b)
+
+func (m *MigratePhase) Reset()         { *m = MigratePhase{} }
+func (m *MigratePhase) String() string { return proto.CompactTextString(m) }
+func (*MigratePhase) ProtoMessage()    {}
+func (*MigratePhase) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{27}
 }
-func (m *Container) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Container.Marshal(b, m, deterministic)
+func (m *MigratePhase) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigratePhase.Unmarshal(m, b)
 }
-func (dst *Container) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Container.Merge(dst, src)
+func (m *MigratePhase) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigratePhase.Marshal(b, m, deterministic)
 }
-func (m *Container) XXX_Size() int {
-	return xxx_messageInfo_Container.Size(m)
+func (dst *MigratePhase) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigratePhase.Merge(dst, src)
 }
-func (m *Container) XXX_DiscardUnknown() {
-	xxx_messageInfo_Container.DiscardUnknown(m)
+func (m *MigratePhase) XXX_Size() int {
+	return xxx_messageInfo_MigratePhase.Size(m)
+}
+func (m *MigratePhase) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigratePhase.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Container proto.InternalMessageInfo
+var xxx_messageInfo_MigratePhase proto.InternalMessageInfo
 
-func (m *Container) GetID() string {
+func (m *MigratePhase) GetName() string {
 	if m != nil {
-		return m.ID
+		return m.Name
 	}
 	return ""
 }
 
-func (m *Container) GetImage() string {
+func (m *MigratePhase) GetDuration() time.Duration {
 	if m != nil {
-		return m.Image
+		return m.Duration
 	}
-	return ""
+	return 0
 }
 
-func (m *Container) GetNetwork() string {
+type AuditRequest struct {
+	Since                time.Time `protobuf:"bytes,1,opt,name=since,stdtime" json:"since"`
+	ID                   string    `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *AuditRequest) Reset()         { *m = AuditRequest{} }
+func (m *AuditRequest) String() string { return proto.CompactTextString(m) }
+func (*AuditRequest) ProtoMessage()    {}
+func (*AuditRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{28}
+}
+func (m *AuditRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditRequest.Unmarshal(m, b)
+}
+func (m *AuditRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditRequest.Marshal(b, m, deterministic)
+}
+func (dst *AuditRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditRequest.Merge(dst, src)
+}
+func (m *AuditRequest) XXX_Size() int {
+	return xxx_messageInfo_AuditRequest.Size(m)
+}
+func (m *AuditRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditRequest proto.InternalMessageInfo
+
+func (m *AuditRequest) GetSince() time.Time {
 	if m != nil {
-		return m.Network
+		return m.Since
+	}
+	return time.Time{}
+}
+
+func (m *AuditRequest) GetID() string {
+	if m != nil {
+		return m.ID
 	}
 	return ""
 }
 
-func (m *Container) GetProcess() *Process {
+type AuditResponse struct {
+	Entries              []*AuditEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *AuditResponse) Reset()         { *m = AuditResponse{} }
+func (m *AuditResponse) String() string { return proto.CompactTextString(m) }
+func (*AuditResponse) ProtoMessage()    {}
+func (*AuditResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{29}
+}
+func (m *AuditResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditResponse.Unmarshal(m, b)
+}
+func (m *AuditResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditResponse.Marshal(b, m, deterministic)
+}
+func (dst *AuditResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditResponse.Merge(dst, src)
+}
+func (m *AuditResponse) XXX_Size() int {
+	return xxx_messageInfo_AuditResponse.Size(m)
+}
+func (m *AuditResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditResponse proto.InternalMessageInfo
+
+func (m *AuditResponse) GetEntries() []*AuditEntry {
 	if m != nil {
-		return m.Process
+		return m.Entries
 	}
 	return nil
 }
 
-func (m *Container) GetMounts() []*Mount {
+type AuditEntry struct {
+	Timestamp            time.Time     `protobuf:"bytes,1,opt,name=timestamp,stdtime" json:"timestamp"`
+	Identity             string        `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	Rpc                  string        `protobuf:"bytes,3,opt,name=rpc,proto3" json:"rpc,omitempty"`
+	ID                   string        `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	Request              string        `protobuf:"bytes,5,opt,name=request,proto3" json:"request,omitempty"`
+	Error                string        `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Duration             time.Duration `protobuf:"bytes,7,opt,name=duration,stdduration" json:"duration"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *AuditEntry) Reset()         { *m = AuditEntry{} }
+func (m *AuditEntry) String() string { return proto.CompactTextString(m) }
+func (*AuditEntry) ProtoMessage()    {}
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{30}
+}
+func (m *AuditEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEntry.Unmarshal(m, b)
+}
+func (m *AuditEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEntry.Marshal(b, m, deterministic)
+}
+func (dst *AuditEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEntry.Merge(dst, src)
+}
+func (m *AuditEntry) XXX_Size() int {
+	return xxx_messageInfo_AuditEntry.Size(m)
+}
+func (m *AuditEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEntry proto.InternalMessageInfo
+
+func (m *AuditEntry) GetTimestamp() time.Time {
 	if m != nil {
-		return m.Mounts
+		return m.Timestamp
 	}
-	return nil
+	return time.Time{}
 }
 
-func (m *Container) GetResources() *Resources {
+func (m *AuditEntry) GetIdentity() string {
 	if m != nil {
-		return m.Resources
+		return m.Identity
 	}
-	return nil
+	return ""
 }
 
-func (m *Container) GetGpus() *GPUs {
+func (m *AuditEntry) GetRpc() string {
 	if m != nil {
-		return m.Gpus
+		return m.Rpc
 	}
-	return nil
+	return ""
 }
 
-func (m *Container) GetServices() map[string]*Service {
+func (m *AuditEntry) GetID() string {
 	if m != nil {
-		return m.Services
+		return m.ID
 	}
-	return nil
+	return ""
 }
 
-func (m *Container) GetConfigs() map[string]*Config {
+func (m *AuditEntry) GetRequest() string {
 	if m != nil {
-		return m.Configs
+		return m.Request
 	}
-	return nil
+	return ""
 }
 
-func (m *Container) GetReadonly() bool {
+func (m *AuditEntry) GetError() string {
 	if m != nil {
-		return m.Readonly
+		return m.Error
 	}
-	return false
+	return ""
 }
 
-func (m *Container) GetVolumes() []*Volume {
+func (m *AuditEntry) GetDuration() time.Duration {
 	if m != nil {
-		return m.Volumes
+		return m.Duration
 	}
-	return nil
+	return 0
 }
 
-type Volume struct {
+type ScaleRequest struct {
 	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Destination          string   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
-	Rw                   bool     `protobuf:"varint,3,opt,name=rw,proto3" json:"rw,omitempty"`
+	Replicas             int64    `protobuf:"varint,2,opt,name=replicas,proto3" json:"replicas,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Volume) Reset()         { *m = Volume{} }
-func (m *Volume) String() string { return proto.CompactTextString(m) }
-func (*Volume) ProtoMessage()    {}
-func (*Volume) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{24}
+func (m *ScaleRequest) Reset()         { *m = ScaleRequest{} }
+func (m *ScaleRequest) String() string { return proto.CompactTextString(m) }
+func (*ScaleRequest) ProtoMessage()    {}
+func (*ScaleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{31}
 }
-func (m *Volume) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Volume.Unmarshal(m, b)
+func (m *ScaleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScaleRequest.Unmarshal(m, b)
 }
-func (m *Volume) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Volume.Marshal(b, m, deterministic)
+func (m *ScaleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScaleRequest.Marshal(b, m, deterministic)
 }
-func (dst *Volume) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Volume.Merge(dst, src)
+func (dst *ScaleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScaleRequest.Merge(dst, src)
 }
-func (m *Volume) XXX_Size() int {
-	return xxx_messageInfo_Volume.Size(m)
+func (m *ScaleRequest) XXX_Size() int {
+	return xxx_messageInfo_ScaleRequest.Size(m)
 }
-func (m *Volume) XXX_DiscardUnknown() {
-	xxx_messageInfo_Volume.DiscardUnknown(m)
+func (m *ScaleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScaleRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Volume proto.InternalMessageInfo
+var xxx_messageInfo_ScaleRequest proto.InternalMessageInfo
 
-func (m *Volume) GetID() string {
+func (m *ScaleRequest) GetID() string {
 	if m != nil {
 		return m.ID
 	}
 	return ""
 }
 
-func (m *Volume) GetDestination() string {
-	if m != nil {
-		return m.Destination
-	}
-	return ""
-}
-
-func (m *Volume) GetRw() bool {
+func (m *ScaleRequest) GetReplicas() int64 {
 	if m != nil {
-		return m.Rw
+		return m.Replicas
 	}
-	return false
+	return 0
 }
 
-type Config struct {
-	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
-	Signal               string   `protobuf:"bytes,3,opt,name=signal,proto3" json:"signal,omitempty"`
-	Content              string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+type JobsRequest struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// logs includes the last lines of each run's output
+	Logs                 bool     `protobuf:"varint,2,opt,name=logs,proto3" json:"logs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Config) Reset()         { *m = Config{} }
-func (m *Config) String() string { return proto.CompactTextString(m) }
-func (*Config) ProtoMessage()    {}
-func (*Config) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{25}
+func (m *JobsRequest) Reset()         { *m = JobsRequest{} }
+func (m *JobsRequest) String() string { return proto.CompactTextString(m) }
+func (*JobsRequest) ProtoMessage()    {}
+func (*JobsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{32}
 }
-func (m *Config) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Config.Unmarshal(m, b)
+func (m *JobsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobsRequest.Unmarshal(m, b)
 }
-func (m *Config) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Config.Marshal(b, m, deterministic)
+func (m *JobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobsRequest.Marshal(b, m, deterministic)
 }
-func (dst *Config) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Config.Merge(dst, src)
+func (dst *JobsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobsRequest.Merge(dst, src)
 }
-func (m *Config) XXX_Size() int {
-	return xxx_messageInfo_Config.Size(m)
+func (m *JobsRequest) XXX_Size() int {
+	return xxx_messageInfo_JobsRequest.Size(m)
 }
-func (m *Config) XXX_DiscardUnknown() {
-	xxx_messageInfo_Config.DiscardUnknown(m)
+func (m *JobsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Config proto.InternalMessageInfo
+var xxx_messageInfo_JobsRequest proto.InternalMessageInfo
 
-func (m *Config) GetPath() string {
+func (m *JobsRequest) GetID() string {
 	if m != nil {
-		return m.Path
+		return m.ID
 	}
 	return ""
 }
 
-func (m *Config) GetSource() string {
+func (m *JobsRequest) GetLogs() bool {
 	if m != nil {
-		return m.Source
+		return m.Logs
 	}
-	return ""
+	return false
 }
 
-func (m *Config) GetSignal() string {
-	if m != nil {
-		return m.Signal
-	}
-	return ""
+type JobsResponse struct {
+	Jobs                 []*Job   `protobuf:"bytes,1,rep,name=jobs" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Config) GetContent() string {
+func (m *JobsResponse) Reset()         { *m = JobsResponse{} }
+func (m *JobsResponse) String() string { return proto.CompactTextString(m) }
+func (*JobsResponse) ProtoMessage()    {}
+func (*JobsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{33}
+}
+func (m *JobsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobsResponse.Unmarshal(m, b)
+}
+func (m *JobsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobsResponse.Marshal(b, m, deterministic)
+}
+func (dst *JobsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobsResponse.Merge(dst, src)
+}
+func (m *JobsResponse) XXX_Size() int {
+	return xxx_messageInfo_JobsResponse.Size(m)
+}
+func (m *JobsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobsResponse proto.InternalMessageInfo
+
+func (m *JobsResponse) GetJobs() []*Job {
 	if m != nil {
-		return m.Content
+		return m.Jobs
 	}
-	return ""
+	return nil
 }
 
-type Service struct {
-	Port                 int64        `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
-	Labels               []string     `protobuf:"bytes,2,rep,name=labels" json:"labels,omitempty"`
-	Url                  string       `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
-	Check                *HealthCheck `protobuf:"bytes,4,opt,name=check" json:"check,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+type Job struct {
+	ID                   string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Schedule             string    `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	NextRun              string    `protobuf:"bytes,3,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	Runs                 []*JobRun `protobuf:"bytes,4,rep,name=runs" json:"runs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *Service) Reset()         { *m = Service{} }
-func (m *Service) String() string { return proto.CompactTextString(m) }
-func (*Service) ProtoMessage()    {}
-func (*Service) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{26}
+func (m *Job) Reset()         { *m = Job{} }
+func (m *Job) String() string { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()    {}
+func (*Job) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{34}
 }
-func (m *Service) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Service.Unmarshal(m, b)
+func (m *Job) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Job.Unmarshal(m, b)
 }
-func (m *Service) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Service.Marshal(b, m, deterministic)
+func (m *Job) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Job.Marshal(b, m, deterministic)
 }
-func (dst *Service) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Service.Merge(dst, src)
+func (dst *Job) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Job.Merge(dst, src)
 }
-func (m *Service) XXX_Size() int {
-	return xxx_messageInfo_Service.Size(m)
+func (m *Job) XXX_Size() int {
+	return xxx_messageInfo_Job.Size(m)
 }
-func (m *Service) XXX_DiscardUnknown() {
-	xxx_messageInfo_Service.DiscardUnknown(m)
+func (m *Job) XXX_DiscardUnknown() {
+	xxx_messageInfo_Job.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Service proto.InternalMessageInfo
+var xxx_messageInfo_Job proto.InternalMessageInfo
 
-func (m *Service) GetPort() int64 {
+func (m *Job) GetID() string {
 	if m != nil {
-		return m.Port
+		return m.ID
 	}
-	return 0
+	return ""
 }
 
-func (m *Service) GetLabels() []string {
+func (m *Job) GetSchedule() string {
 	if m != nil {
-		return m.Labels
+		return m.Schedule
 	}
-	return nil
+	return ""
 }
 
-func (m *Service) GetUrl() string {
+func (m *Job) GetNextRun() string {
 	if m != nil {
-		return m.Url
+		return m.NextRun
 	}
 	return ""
 }
 
-func (m *Service) GetCheck() *HealthCheck {
+func (m *Job) GetRuns() []*JobRun {
 	if m != nil {
-		return m.Check
+		return m.Runs
 	}
 	return nil
 }
 
-type HealthCheck struct {
-	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Interval             int64    `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
-	Timeout              int64    `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	Method               string   `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type JobRun struct {
+	Started              time.Time `protobuf:"bytes,1,opt,name=started,stdtime" json:"started"`
+	Finished             time.Time `protobuf:"bytes,2,opt,name=finished,stdtime" json:"finished"`
+	ExitCode             int64     `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Error                string    `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Logs                 string    `protobuf:"bytes,5,opt,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *HealthCheck) Reset()         { *m = HealthCheck{} }
-func (m *HealthCheck) String() string { return proto.CompactTextString(m) }
-func (*HealthCheck) ProtoMessage()    {}
-func (*HealthCheck) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{27}
+func (m *JobRun) Reset()         { *m = JobRun{} }
+func (m *JobRun) String() string { return proto.CompactTextString(m) }
+func (*JobRun) ProtoMessage()    {}
+func (*JobRun) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{35}
 }
-func (m *HealthCheck) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_HealthCheck.Unmarshal(m, b)
+func (m *JobRun) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobRun.Unmarshal(m, b)
 }
-func (m *HealthCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_HealthCheck.Marshal(b, m, deterministic)
+func (m *JobRun) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobRun.Marshal(b, m, deterministic)
 }
-func (dst *HealthCheck) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_HealthCheck.Merge(dst, src)
+func (dst *JobRun) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobRun.Merge(dst, src)
 }
-func (m *HealthCheck) XXX_Size() int {
-	return xxx_messageInfo_HealthCheck.Size(m)
+func (m *JobRun) XXX_Size() int {
+	return xxx_messageInfo_JobRun.Size(m)
 }
-func (m *HealthCheck) XXX_DiscardUnknown() {
-	xxx_messageInfo_HealthCheck.DiscardUnknown(m)
+func (m *JobRun) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobRun.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_HealthCheck proto.InternalMessageInfo
+var xxx_messageInfo_JobRun proto.InternalMessageInfo
 
-func (m *HealthCheck) GetType() string {
+func (m *JobRun) GetStarted() time.Time {
 	if m != nil {
-		return m.Type
+		return m.Started
 	}
-	return ""
+	return time.Time{}
 }
 
-func (m *HealthCheck) GetInterval() int64 {
+func (m *JobRun) GetFinished() time.Time {
 	if m != nil {
-		return m.Interval
+		return m.Finished
 	}
-	return 0
+	return time.Time{}
 }
 
-func (m *HealthCheck) GetTimeout() int64 {
+func (m *JobRun) GetExitCode() int64 {
 	if m != nil {
-		return m.Timeout
+		return m.ExitCode
 	}
 	return 0
 }
 
-func (m *HealthCheck) GetMethod() string {
+func (m *JobRun) GetError() string {
 	if m != nil {
-		return m.Method
+		return m.Error
 	}
 	return ""
 }
 
-type GPUs struct {
-	Devices              []int64  `protobuf:"varint,1,rep,packed,name=devices" json:"devices,omitempty"`
-	Capabilities         []string `protobuf:"bytes,2,rep,name=capabilities" json:"capabilities,omitempty"`
+func (m *JobRun) GetLogs() string {
+	if m != nil {
+		return m.Logs
+	}
+	return ""
+}
+
+type RunRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GPUs) Reset()         { *m = GPUs{} }
-func (m *GPUs) String() string { return proto.CompactTextString(m) }
-func (*GPUs) ProtoMessage()    {}
-func (*GPUs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{28}
+func (m *RunRequest) Reset()         { *m = RunRequest{} }
+func (m *RunRequest) String() string { return proto.CompactTextString(m) }
+func (*RunRequest) ProtoMessage()    {}
+func (*RunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{36}
 }
-func (m *GPUs) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GPUs.Unmarshal(m, b)
+func (m *RunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunRequest.Unmarshal(m, b)
 }
-func (m *GPUs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GPUs.Marshal(b, m, deterministic)
+func (m *RunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunRequest.Marshal(b, m, deterministic)
 }
-func (dst *GPUs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GPUs.Merge(dst, src)
+func (dst *RunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunRequest.Merge(dst, src)
 }
-func (m *GPUs) XXX_Size() int {
-	return xxx_messageInfo_GPUs.Size(m)
+func (m *RunRequest) XXX_Size() int {
+	return xxx_messageInfo_RunRequest.Size(m)
 }
-func (m *GPUs) XXX_DiscardUnknown() {
-	xxx_messageInfo_GPUs.DiscardUnknown(m)
+func (m *RunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GPUs proto.InternalMessageInfo
-
-func (m *GPUs) GetDevices() []int64 {
-	if m != nil {
-		return m.Devices
-	}
-	return nil
-}
+var xxx_messageInfo_RunRequest proto.InternalMessageInfo
 
-func (m *GPUs) GetCapabilities() []string {
+func (m *RunRequest) GetID() string {
 	if m != nil {
-		return m.Capabilities
+		return m.ID
 	}
-	return nil
+	return ""
 }
 
-type Resources struct {
-	Cpus                 float64  `protobuf:"fixed64,1,opt,name=cpus,proto3" json:"cpus,omitempty"`
-	Memory               int64    `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
-	Score                int64    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
-	NoFile               uint64   `protobuf:"varint,4,opt,name=no_file,json=noFile,proto3" json:"no_file,omitempty"`
+type DoctorRequest struct {
+	Repair               bool     `protobuf:"varint,1,opt,name=repair,proto3" json:"repair,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Resources) Reset()         { *m = Resources{} }
-func (m *Resources) String() string { return proto.CompactTextString(m) }
-func (*Resources) ProtoMessage()    {}
-func (*Resources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{29}
+func (m *DoctorRequest) Reset()         { *m = DoctorRequest{} }
+func (m *DoctorRequest) String() string { return proto.CompactTextString(m) }
+func (*DoctorRequest) ProtoMessage()    {}
+func (*DoctorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{37}
 }
-func (m *Resources) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Resources.Unmarshal(m, b)
+func (m *DoctorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DoctorRequest.Unmarshal(m, b)
 }
-func (m *Resources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Resources.Marshal(b, m, deterministic)
+func (m *DoctorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DoctorRequest.Marshal(b, m, deterministic)
 }
-func (dst *Resources) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Resources.Merge(dst, src)
+func (dst *DoctorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DoctorRequest.Merge(dst, src)
 }
-func (m *Resources) XXX_Size() int {
-	return xxx_messageInfo_Resources.Size(m)
+func (m *DoctorRequest) XXX_Size() int {
+	return xxx_messageInfo_DoctorRequest.Size(m)
 }
-func (m *Resources) XXX_DiscardUnknown() {
-	xxx_messageInfo_Resources.DiscardUnknown(m)
+func (m *DoctorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DoctorRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Resources proto.InternalMessageInfo
+var xxx_messageInfo_DoctorRequest proto.InternalMessageInfo
 
-func (m *Resources) GetCpus() float64 {
+func (m *DoctorRequest) GetRepair() bool {
 	if m != nil {
-		return m.Cpus
+		return m.Repair
 	}
-	return 0
+	return false
 }
 
-func (m *Resources) GetMemory() int64 {
-	if m != nil {
-		return m.Memory
-	}
-	return 0
+type DoctorResponse struct {
+	Findings             []*Finding `protobuf:"bytes,1,rep,name=findings" json:"findings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *Resources) GetScore() int64 {
-	if m != nil {
-		return m.Score
-	}
-	return 0
+func (m *DoctorResponse) Reset()         { *m = DoctorResponse{} }
+func (m *DoctorResponse) String() string { return proto.CompactTextString(m) }
+func (*DoctorResponse) ProtoMessage()    {}
+func (*DoctorResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{38}
+}
+func (m *DoctorResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DoctorResponse.Unmarshal(m, b)
+}
+func (m *DoctorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DoctorResponse.Marshal(b, m, deterministic)
+}
+func (dst *DoctorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DoctorResponse.Merge(dst, src)
+}
+func (m *DoctorResponse) XXX_Size() int {
+	return xxx_messageInfo_DoctorResponse.Size(m)
+}
+func (m *DoctorResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DoctorResponse.DiscardUnknown(m)
 }
 
-func (m *Resources) GetNoFile() uint64 {
+var xxx_messageInfo_DoctorResponse proto.InternalMessageInfo
+
+func (m *DoctorResponse) GetFindings() []*Finding {
 	if m != nil {
-		return m.NoFile
+		return m.Findings
 	}
-	return 0
+	return nil
 }
 
-type Mount struct {
-	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
-	Destination          string   `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
-	Options              []string `protobuf:"bytes,4,rep,name=options" json:"options,omitempty"`
+type Finding struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind                 string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Repaired             bool     `protobuf:"varint,4,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Mount) Reset()         { *m = Mount{} }
-func (m *Mount) String() string { return proto.CompactTextString(m) }
-func (*Mount) ProtoMessage()    {}
-func (*Mount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{30}
+func (m *Finding) Reset()         { *m = Finding{} }
+func (m *Finding) String() string { return proto.CompactTextString(m) }
+func (*Finding) ProtoMessage()    {}
+func (*Finding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{39}
 }
-func (m *Mount) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Mount.Unmarshal(m, b)
+func (m *Finding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Finding.Unmarshal(m, b)
 }
-func (m *Mount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Mount.Marshal(b, m, deterministic)
+func (m *Finding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Finding.Marshal(b, m, deterministic)
 }
-func (dst *Mount) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Mount.Merge(dst, src)
+func (dst *Finding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Finding.Merge(dst, src)
 }
-func (m *Mount) XXX_Size() int {
-	return xxx_messageInfo_Mount.Size(m)
+func (m *Finding) XXX_Size() int {
+	return xxx_messageInfo_Finding.Size(m)
 }
-func (m *Mount) XXX_DiscardUnknown() {
-	xxx_messageInfo_Mount.DiscardUnknown(m)
+func (m *Finding) XXX_DiscardUnknown() {
+	xxx_messageInfo_Finding.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Mount proto.InternalMessageInfo
+var xxx_messageInfo_Finding proto.InternalMessageInfo
 
-func (m *Mount) GetType() string {
+func (m *Finding) GetID() string {
 	if m != nil {
-		return m.Type
+		return m.ID
 	}
 	return ""
 }
 
-func (m *Mount) GetSource() string {
+func (m *Finding) GetKind() string {
 	if m != nil {
-		return m.Source
+		return m.Kind
 	}
 	return ""
 }
 
-func (m *Mount) GetDestination() string {
+func (m *Finding) GetMessage() string {
 	if m != nil {
-		return m.Destination
+		return m.Message
 	}
 	return ""
 }
 
-func (m *Mount) GetOptions() []string {
+func (m *Finding) GetRepaired() bool {
 	if m != nil {
-		return m.Options
+		return m.Repaired
 	}
-	return nil
+	return false
 }
 
-type Process struct {
-	User                 *User    `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
-	Args                 []string `protobuf:"bytes,2,rep,name=args" json:"args,omitempty"`
-	Env                  []string `protobuf:"bytes,3,rep,name=env" json:"env,omitempty"`
-	Capabilities         []string `protobuf:"bytes,4,rep,name=capabilities" json:"capabilities,omitempty"`
+func (m *Finding) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type Container struct {
+	ID        string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Image     string              `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Network   string              `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	Process   *Process            `protobuf:"bytes,4,opt,name=process" json:"process,omitempty"`
+	Mounts    []*Mount            `protobuf:"bytes,5,rep,name=mounts" json:"mounts,omitempty"`
+	Resources *Resources          `protobuf:"bytes,6,opt,name=resources" json:"resources,omitempty"`
+	Gpus      *GPUs               `protobuf:"bytes,7,opt,name=gpus" json:"gpus,omitempty"`
+	Services  map[string]*Service `protobuf:"bytes,8,rep,name=services" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Configs   map[string]*Config  `protobuf:"bytes,9,rep,name=configs" json:"configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Readonly  bool                `protobuf:"varint,10,opt,name=readonly,proto3" json:"readonly,omitempty"`
+	Volumes   []*Volume           `protobuf:"bytes,11,rep,name=volumes" json:"volumes,omitempty"`
+	Update    *UpdatePolicy       `protobuf:"bytes,12,opt,name=update" json:"update,omitempty"`
+	// replicas of the container to run as <id>-0 to <id>-N
+	Replicas int64 `protobuf:"varint,13,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	// kind is "service", the default, or "job" for containers that run to completion
+	Kind string `protobuf:"bytes,14,opt,name=kind,proto3" json:"kind,omitempty"`
+	// schedule of a job as a cron expression or systemd calendar event
+	Schedule string `protobuf:"bytes,15,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// init containers run to completion, in order, before the container starts
+	Init []*Helper `protobuf:"bytes,16,rep,name=init" json:"init,omitempty"`
+	// sidecars run alongside the container for as long as it runs
+	Sidecars []*Helper `protobuf:"bytes,17,rep,name=sidecars" json:"sidecars,omitempty"`
+	// checkpoint schedules checkpoints of the container as backups
+	Checkpoint *CheckpointPolicy `protobuf:"bytes,18,opt,name=checkpoint" json:"checkpoint,omitempty"`
+	// networks are attached to the container after its network, as eth1 and on
+	Networks []string `protobuf:"bytes,19,rep,name=networks" json:"networks,omitempty"`
+	// ports published on the host to the container on its cni network
+	Ports                []*Port  `protobuf:"bytes,20,rep,name=ports" json:"ports,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Process) Reset()         { *m = Process{} }
-func (m *Process) String() string { return proto.CompactTextString(m) }
-func (*Process) ProtoMessage()    {}
-func (*Process) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{31}
+func (m *Container) Reset()         { *m = Container{} }
+func (m *Container) String() string { return proto.CompactTextString(m) }
+func (*Container) ProtoMessage()    {}
+func (*Container) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{40}
 }
-func (m *Process) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Process.Unmarshal(m, b)
+func (m *Container) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Container.Unmarshal(m, b)
 }
-func (m *Process) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Process.Marshal(b, m, deterministic)
+func (m *Container) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Container.Marshal(b, m, deterministic)
 }
-func (dst *Process) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Process.Merge(dst, src)
+func (dst *Container) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Container.Merge(dst, src)
 }
-func (m *Process) XXX_Size() int {
-	return xxx_messageInfo_Process.Size(m)
+func (m *Container) XXX_Size() int {
+	return xxx_messageInfo_Container.Size(m)
 }
-func (m *Process) XXX_DiscardUnknown() {
-	xxx_messageInfo_Process.DiscardUnknown(m)
+func (m *Container) XXX_DiscardUnknown() {
+	xxx_messageInfo_Container.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Process proto.InternalMessageInfo
+var xxx_messageInfo_Container proto.InternalMessageInfo
 
-func (m *Process) GetUser() *User {
+func (m *Container) GetID() string {
 	if m != nil {
-		return m.User
+		return m.ID
+	}
+	return ""
+}
+
+func (m *Container) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *Container) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *Container) GetProcess() *Process {
+	if m != nil {
+		return m.Process
 	}
 	return nil
 }
 
-func (m *Process) GetArgs() []string {
+func (m *Container) GetMounts() []*Mount {
 	if m != nil {
-		return m.Args
+		return m.Mounts
 	}
 	return nil
 }
 
-func (m *Process) GetEnv() []string {
+func (m *Container) GetResources() *Resources {
 	if m != nil {
-		return m.Env
+		return m.Resources
 	}
 	return nil
 }
 
-func (m *Process) GetCapabilities() []string {
+func (m *Container) GetGpus() *GPUs {
 	if m != nil {
-		return m.Capabilities
+		return m.Gpus
 	}
 	return nil
 }
 
-type User struct {
-	Uid                  uint32   `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Gid                  uint32   `protobuf:"varint,2,opt,name=gid,proto3" json:"gid,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Container) GetServices() map[string]*Service {
+	if m != nil {
+		return m.Services
+	}
+	return nil
 }
 
-func (m *User) Reset()         { *m = User{} }
-func (m *User) String() string { return proto.CompactTextString(m) }
-func (*User) ProtoMessage()    {}
-func (*User) Descriptor() ([]byte, []int) {
-	return fileDescriptor_boss_c670a4faf431fe91, []int{32}
+func (m *Container) GetConfigs() map[string]*Config {
+	if m != nil {
+		return m.Configs
+	}
+	return nil
 }
-func (m *User) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_User.Unmarshal(m, b)
+
+func (m *Container) GetReadonly() bool {
+	if m != nil {
+		return m.Readonly
+	}
+	return false
 }
-func (m *User) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_User.Marshal(b, m, deterministic)
+
+func (m *Container) GetVolumes() []*Volume {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
 }
-func (dst *User) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_User.Merge(dst, src)
+
+func (m *Container) GetUpdate() *UpdatePolicy {
+	if m != nil {
+		return m.Update
+	}
+	return nil
 }
-func (m *User) XXX_Size() int {
-	return xxx_messageInfo_User.Size(m)
+
+func (m *Container) GetReplicas() int64 {
+	if m != nil {
+		return m.Replicas
+	}
+	return 0
 }
-func (m *User) XXX_DiscardUnknown() {
-	xxx_messageInfo_User.DiscardUnknown(m)
+
+func (m *Container) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
 }
 
-var xxx_messageInfo_User proto.InternalMessageInfo
+func (m *Container) GetSchedule() string {
+	if m != nil {
+		return m.Schedule
+	}
+	return ""
+}
 
-func (m *User) GetUid() uint32 {
+func (m *Container) GetInit() []*Helper {
 	if m != nil {
-		return m.Uid
+		return m.Init
 	}
-	return 0
+	return nil
 }
 
-func (m *User) GetGid() uint32 {
+func (m *Container) GetSidecars() []*Helper {
 	if m != nil {
-		return m.Gid
+		return m.Sidecars
 	}
-	return 0
+	return nil
 }
 
-func init() {
-	proto.RegisterType((*CreateRequest)(nil), "io.boss.v1.CreateRequest")
-	proto.RegisterType((*DeleteRequest)(nil), "io.boss.v1.DeleteRequest")
-	proto.RegisterType((*GetRequest)(nil), "io.boss.v1.GetRequest")
-	proto.RegisterType((*GetResponse)(nil), "io.boss.v1.GetResponse")
-	proto.RegisterType((*KillRequest)(nil), "io.boss.v1.KillRequest")
-	proto.RegisterType((*ListRequest)(nil), "io.boss.v1.ListRequest")
-	proto.RegisterType((*ListResponse)(nil), "io.boss.v1.ListResponse")
+func (m *Container) GetCheckpoint() *CheckpointPolicy {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+func (m *Container) GetNetworks() []string {
+	if m != nil {
+		return m.Networks
+	}
+	return nil
+}
+
+func (m *Container) GetPorts() []*Port {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+type Port struct {
+	Host      int64 `protobuf:"varint,1,opt,name=host,proto3" json:"host,omitempty"`
+	Container int64 `protobuf:"varint,2,opt,name=container,proto3" json:"container,omitempty"`
+	// protocol is tcp or udp, tcp by default
+	Protocol             string   `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Port) Reset()         { *m = Port{} }
+func (m *Port) String() string { return proto.CompactTextString(m) }
+func (*Port) ProtoMessage()    {}
+func (*Port) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{41}
+}
+func (m *Port) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Port.Unmarshal(m, b)
+}
+func (m *Port) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Port.Marshal(b, m, deterministic)
+}
+func (dst *Port) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Port.Merge(dst, src)
+}
+func (m *Port) XXX_Size() int {
+	return xxx_messageInfo_Port.Size(m)
+}
+func (m *Port) XXX_DiscardUnknown() {
+	xxx_messageInfo_Port.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Port proto.InternalMessageInfo
+
+func (m *Port) GetHost() int64 {
+	if m != nil {
+		return m.Host
+	}
+	return 0
+}
+
+func (m *Port) GetContainer() int64 {
+	if m != nil {
+		return m.Container
+	}
+	return 0
+}
+
+func (m *Port) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+type CheckpointPolicy struct {
+	// interval in seconds between checkpoints
+	Interval int64 `protobuf:"varint,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	// live checkpoints include the memory of the container's processes, otherwise only its filesystem and volumes
+	Live bool `protobuf:"varint,2,opt,name=live,proto3" json:"live,omitempty"`
+	// retain is the number of checkpoints to keep on the agent, all of them when zero
+	Retain int64 `protobuf:"varint,3,opt,name=retain,proto3" json:"retain,omitempty"`
+	// push is a ref template the checkpoints are named by and pushed to, i.e. registry.example.com/backups/{{.ID}}:{{.Timestamp}}
+	Push                 string   `protobuf:"bytes,4,opt,name=push,proto3" json:"push,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointPolicy) Reset()         { *m = CheckpointPolicy{} }
+func (m *CheckpointPolicy) String() string { return proto.CompactTextString(m) }
+func (*CheckpointPolicy) ProtoMessage()    {}
+func (*CheckpointPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{42}
+}
+func (m *CheckpointPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointPolicy.Unmarshal(m, b)
+}
+func (m *CheckpointPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointPolicy.Marshal(b, m, deterministic)
+}
+func (dst *CheckpointPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointPolicy.Merge(dst, src)
+}
+func (m *CheckpointPolicy) XXX_Size() int {
+	return xxx_messageInfo_CheckpointPolicy.Size(m)
+}
+func (m *CheckpointPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointPolicy proto.InternalMessageInfo
+
+func (m *CheckpointPolicy) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *CheckpointPolicy) GetLive() bool {
+	if m != nil {
+		return m.Live
+	}
+	return false
+}
+
+func (m *CheckpointPolicy) GetRetain() int64 {
+	if m != nil {
+		return m.Retain
+	}
+	return 0
+}
+
+func (m *CheckpointPolicy) GetPush() string {
+	if m != nil {
+		return m.Push
+	}
+	return ""
+}
+
+// Helper is an init or sidecar container sharing the network namespace of its container
+type Helper struct {
+	Name    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Image   string   `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Process *Process `protobuf:"bytes,3,opt,name=process" json:"process,omitempty"`
+	// volumes of the container to mount into the helper
+	Volumes              []*Volume `protobuf:"bytes,4,rep,name=volumes" json:"volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Helper) Reset()         { *m = Helper{} }
+func (m *Helper) String() string { return proto.CompactTextString(m) }
+func (*Helper) ProtoMessage()    {}
+func (*Helper) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{43}
+}
+func (m *Helper) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Helper.Unmarshal(m, b)
+}
+func (m *Helper) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Helper.Marshal(b, m, deterministic)
+}
+func (dst *Helper) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Helper.Merge(dst, src)
+}
+func (m *Helper) XXX_Size() int {
+	return xxx_messageInfo_Helper.Size(m)
+}
+func (m *Helper) XXX_DiscardUnknown() {
+	xxx_messageInfo_Helper.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Helper proto.InternalMessageInfo
+
+func (m *Helper) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Helper) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *Helper) GetProcess() *Process {
+	if m != nil {
+		return m.Process
+	}
+	return nil
+}
+
+func (m *Helper) GetVolumes() []*Volume {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+type UpdatePolicy struct {
+	// strategy is "in-place", the default, or "blue-green"
+	Strategy string `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	// health_timeout in seconds to wait for a blue/green sibling to pass its health checks
+	HealthTimeout int64 `protobuf:"varint,2,opt,name=health_timeout,json=healthTimeout,proto3" json:"health_timeout,omitempty"`
+	// drain in seconds between taking the old container out of service and removing it
+	Drain                int64    `protobuf:"varint,3,opt,name=drain,proto3" json:"drain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdatePolicy) Reset()         { *m = UpdatePolicy{} }
+func (m *UpdatePolicy) String() string { return proto.CompactTextString(m) }
+func (*UpdatePolicy) ProtoMessage()    {}
+func (*UpdatePolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{44}
+}
+func (m *UpdatePolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdatePolicy.Unmarshal(m, b)
+}
+func (m *UpdatePolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdatePolicy.Marshal(b, m, deterministic)
+}
+func (dst *UpdatePolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePolicy.Merge(dst, src)
+}
+func (m *UpdatePolicy) XXX_Size() int {
+	return xxx_messageInfo_UpdatePolicy.Size(m)
+}
+func (m *UpdatePolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePolicy proto.InternalMessageInfo
+
+func (m *UpdatePolicy) GetStrategy() string {
+	if m != nil {
+		return m.Strategy
+	}
+	return ""
+}
+
+func (m *UpdatePolicy) GetHealthTimeout() int64 {
+	if m != nil {
+		return m.HealthTimeout
+	}
+	return 0
+}
+
+func (m *UpdatePolicy) GetDrain() int64 {
+	if m != nil {
+		return m.Drain
+	}
+	return 0
+}
+
+type Volume struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Destination          string   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Rw                   bool     `protobuf:"varint,3,opt,name=rw,proto3" json:"rw,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Volume) Reset()         { *m = Volume{} }
+func (m *Volume) String() string { return proto.CompactTextString(m) }
+func (*Volume) ProtoMessage()    {}
+func (*Volume) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{45}
+}
+func (m *Volume) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Volume.Unmarshal(m, b)
+}
+func (m *Volume) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Volume.Marshal(b, m, deterministic)
+}
+func (dst *Volume) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Volume.Merge(dst, src)
+}
+func (m *Volume) XXX_Size() int {
+	return xxx_messageInfo_Volume.Size(m)
+}
+func (m *Volume) XXX_DiscardUnknown() {
+	xxx_messageInfo_Volume.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Volume proto.InternalMessageInfo
+
+func (m *Volume) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *Volume) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *Volume) GetRw() bool {
+	if m != nil {
+		return m.Rw
+	}
+	return false
+}
+
+type Config struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Signal               string   `protobuf:"bytes,3,opt,name=signal,proto3" json:"signal,omitempty"`
+	Content              string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Config) Reset()         { *m = Config{} }
+func (m *Config) String() string { return proto.CompactTextString(m) }
+func (*Config) ProtoMessage()    {}
+func (*Config) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{46}
+}
+func (m *Config) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Config.Unmarshal(m, b)
+}
+func (m *Config) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Config.Marshal(b, m, deterministic)
+}
+func (dst *Config) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Config.Merge(dst, src)
+}
+func (m *Config) XXX_Size() int {
+	return xxx_messageInfo_Config.Size(m)
+}
+func (m *Config) XXX_DiscardUnknown() {
+	xxx_messageInfo_Config.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Config proto.InternalMessageInfo
+
+func (m *Config) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Config) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Config) GetSignal() string {
+	if m != nil {
+		return m.Signal
+	}
+	return ""
+}
+
+func (m *Config) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+type Service struct {
+	Port   int64        `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	Labels []string     `protobuf:"bytes,2,rep,name=labels" json:"labels,omitempty"`
+	Url    string       `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Check  *HealthCheck `protobuf:"bytes,4,opt,name=check" json:"check,omitempty"`
+	// network the service is registered on, the container's network by default
+	Network              string   `protobuf:"bytes,5,opt,name=network,proto3" json:"network,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Service) Reset()         { *m = Service{} }
+func (m *Service) String() string { return proto.CompactTextString(m) }
+func (*Service) ProtoMessage()    {}
+func (*Service) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{47}
+}
+func (m *Service) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Service.Unmarshal(m, b)
+}
+func (m *Service) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Service.Marshal(b, m, deterministic)
+}
+func (dst *Service) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Service.Merge(dst, src)
+}
+func (m *Service) XXX_Size() int {
+	return xxx_messageInfo_Service.Size(m)
+}
+func (m *Service) XXX_DiscardUnknown() {
+	xxx_messageInfo_Service.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Service proto.InternalMessageInfo
+
+func (m *Service) GetPort() int64 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Service) GetLabels() []string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Service) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Service) GetCheck() *HealthCheck {
+	if m != nil {
+		return m.Check
+	}
+	return nil
+}
+
+func (m *Service) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+type HealthCheck struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Interval             int64    `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	Timeout              int64    `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Method               string   `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthCheck) Reset()         { *m = HealthCheck{} }
+func (m *HealthCheck) String() string { return proto.CompactTextString(m) }
+func (*HealthCheck) ProtoMessage()    {}
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{48}
+}
+func (m *HealthCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthCheck.Unmarshal(m, b)
+}
+func (m *HealthCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthCheck.Marshal(b, m, deterministic)
+}
+func (dst *HealthCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthCheck.Merge(dst, src)
+}
+func (m *HealthCheck) XXX_Size() int {
+	return xxx_messageInfo_HealthCheck.Size(m)
+}
+func (m *HealthCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthCheck proto.InternalMessageInfo
+
+func (m *HealthCheck) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *HealthCheck) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *HealthCheck) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+type GPUs struct {
+	Devices              []int64  `protobuf:"varint,1,rep,packed,name=devices" json:"devices,omitempty"`
+	Capabilities         []string `protobuf:"bytes,2,rep,name=capabilities" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GPUs) Reset()         { *m = GPUs{} }
+func (m *GPUs) String() string { return proto.CompactTextString(m) }
+func (*GPUs) ProtoMessage()    {}
+func (*GPUs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{49}
+}
+func (m *GPUs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GPUs.Unmarshal(m, b)
+}
+func (m *GPUs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GPUs.Marshal(b, m, deterministic)
+}
+func (dst *GPUs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GPUs.Merge(dst, src)
+}
+func (m *GPUs) XXX_Size() int {
+	return xxx_messageInfo_GPUs.Size(m)
+}
+func (m *GPUs) XXX_DiscardUnknown() {
+	xxx_messageInfo_GPUs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GPUs proto.InternalMessageInfo
+
+func (m *GPUs) GetDevices() []int64 {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+func (m *GPUs) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type Resources struct {
+	Cpus                 float64  `protobuf:"fixed64,1,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	Memory               int64    `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	Score                int64    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	NoFile               uint64   `protobuf:"varint,4,opt,name=no_file,json=noFile,proto3" json:"no_file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Resources) Reset()         { *m = Resources{} }
+func (m *Resources) String() string { return proto.CompactTextString(m) }
+func (*Resources) ProtoMessage()    {}
+func (*Resources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{50}
+}
+func (m *Resources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Resources.Unmarshal(m, b)
+}
+func (m *Resources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Resources.Marshal(b, m, deterministic)
+}
+func (dst *Resources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Resources.Merge(dst, src)
+}
+func (m *Resources) XXX_Size() int {
+	return xxx_messageInfo_Resources.Size(m)
+}
+func (m *Resources) XXX_DiscardUnknown() {
+	xxx_messageInfo_Resources.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Resources proto.InternalMessageInfo
+
+func (m *Resources) GetCpus() float64 {
+	if m != nil {
+		return m.Cpus
+	}
+	return 0
+}
+
+func (m *Resources) GetMemory() int64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
+}
+
+func (m *Resources) GetScore() int64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *Resources) GetNoFile() uint64 {
+	if m != nil {
+		return m.NoFile
+	}
+	return 0
+}
+
+type Mount struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Destination          string   `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	Options              []string `protobuf:"bytes,4,rep,name=options" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Mount) Reset()         { *m = Mount{} }
+func (m *Mount) String() string { return proto.CompactTextString(m) }
+func (*Mount) ProtoMessage()    {}
+func (*Mount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{51}
+}
+func (m *Mount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Mount.Unmarshal(m, b)
+}
+func (m *Mount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Mount.Marshal(b, m, deterministic)
+}
+func (dst *Mount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Mount.Merge(dst, src)
+}
+func (m *Mount) XXX_Size() int {
+	return xxx_messageInfo_Mount.Size(m)
+}
+func (m *Mount) XXX_DiscardUnknown() {
+	xxx_messageInfo_Mount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Mount proto.InternalMessageInfo
+
+func (m *Mount) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Mount) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Mount) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *Mount) GetOptions() []string {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type Process struct {
+	User                 *User    `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
+	Args                 []string `protobuf:"bytes,2,rep,name=args" json:"args,omitempty"`
+	Env                  []string `protobuf:"bytes,3,rep,name=env" json:"env,omitempty"`
+	Capabilities         []string `protobuf:"bytes,4,rep,name=capabilities" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Process) Reset()         { *m = Process{} }
+func (m *Process) String() string { return proto.CompactTextString(m) }
+func (*Process) ProtoMessage()    {}
+func (*Process) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{52}
+}
+func (m *Process) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Process.Unmarshal(m, b)
+}
+func (m *Process) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Process.Marshal(b, m, deterministic)
+}
+func (dst *Process) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Process.Merge(dst, src)
+}
+func (m *Process) XXX_Size() int {
+	return xxx_messageInfo_Process.Size(m)
+}
+func (m *Process) XXX_DiscardUnknown() {
+	xxx_messageInfo_Process.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Process proto.InternalMessageInfo
+
+func (m *Process) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *Process) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *Process) GetEnv() []string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *Process) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type User struct {
+	Uid                  uint32   `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid                  uint32   `protobuf:"varint,2,opt,name=gid,proto3" json:"gid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *User) Reset()         { *m = User{} }
+func (m *User) String() string { return proto.CompactTextString(m) }
+func (*User) ProtoMessage()    {}
+func (*User) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{53}
+}
+func (m *User) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_User.Unmarshal(m, b)
+}
+func (m *User) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_User.Marshal(b, m, deterministic)
+}
+func (dst *User) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_User.Merge(dst, src)
+}
+func (m *User) XXX_Size() int {
+	return xxx_messageInfo_User.Size(m)
+}
+func (m *User) XXX_DiscardUnknown() {
+	xxx_messageInfo_User.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_User proto.InternalMessageInfo
+
+func (m *User) GetUid() uint32 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *User) GetGid() uint32 {
+	if m != nil {
+		return m.Gid
+	}
+	return 0
+}
+
+// Group is a set of containers deployed together
+type Group struct {
+	ID                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Members              []*GroupMember `protobuf:"bytes,2,rep,name=members" json:"members,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Group) Reset()         { *m = Group{} }
+func (m *Group) String() string { return proto.CompactTextString(m) }
+func (*Group) ProtoMessage()    {}
+func (*Group) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{54}
+}
+func (m *Group) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Group.Unmarshal(m, b)
+}
+func (m *Group) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Group.Marshal(b, m, deterministic)
+}
+func (dst *Group) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Group.Merge(dst, src)
+}
+func (m *Group) XXX_Size() int {
+	return xxx_messageInfo_Group.Size(m)
+}
+func (m *Group) XXX_DiscardUnknown() {
+	xxx_messageInfo_Group.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Group proto.InternalMessageInfo
+
+func (m *Group) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *Group) GetMembers() []*GroupMember {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+type GroupMember struct {
+	Container *Container `protobuf:"bytes,1,opt,name=container" json:"container,omitempty"`
+	// ids of the members that must be ready before this member is started
+	After                []string `protobuf:"bytes,2,rep,name=after" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupMember) Reset()         { *m = GroupMember{} }
+func (m *GroupMember) String() string { return proto.CompactTextString(m) }
+func (*GroupMember) ProtoMessage()    {}
+func (*GroupMember) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{55}
+}
+func (m *GroupMember) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupMember.Unmarshal(m, b)
+}
+func (m *GroupMember) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupMember.Marshal(b, m, deterministic)
+}
+func (dst *GroupMember) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupMember.Merge(dst, src)
+}
+func (m *GroupMember) XXX_Size() int {
+	return xxx_messageInfo_GroupMember.Size(m)
+}
+func (m *GroupMember) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupMember.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupMember proto.InternalMessageInfo
+
+func (m *GroupMember) GetContainer() *Container {
+	if m != nil {
+		return m.Container
+	}
+	return nil
+}
+
+func (m *GroupMember) GetAfter() []string {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+type CreateGroupRequest struct {
+	Group                *Group   `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateGroupRequest) Reset()         { *m = CreateGroupRequest{} }
+func (m *CreateGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateGroupRequest) ProtoMessage()    {}
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{56}
+}
+func (m *CreateGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateGroupRequest.Unmarshal(m, b)
+}
+func (m *CreateGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateGroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateGroupRequest.Merge(dst, src)
+}
+func (m *CreateGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateGroupRequest.Size(m)
+}
+func (m *CreateGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateGroupRequest proto.InternalMessageInfo
+
+func (m *CreateGroupRequest) GetGroup() *Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+type UpdateGroupRequest struct {
+	Group                *Group   `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
+	DryRun               bool     `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateGroupRequest) Reset()         { *m = UpdateGroupRequest{} }
+func (m *UpdateGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateGroupRequest) ProtoMessage()    {}
+func (*UpdateGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{57}
+}
+func (m *UpdateGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateGroupRequest.Unmarshal(m, b)
+}
+func (m *UpdateGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateGroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *UpdateGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateGroupRequest.Merge(dst, src)
+}
+func (m *UpdateGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateGroupRequest.Size(m)
+}
+func (m *UpdateGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateGroupRequest proto.InternalMessageInfo
+
+func (m *UpdateGroupRequest) GetGroup() *Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+func (m *UpdateGroupRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type UpdateGroupResponse struct {
+	// diffs of the updated members by id
+	Diffs                map[string]*UpdateDiff `protobuf:"bytes,1,rep,name=diffs" json:"diffs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
+	Created              []string               `protobuf:"bytes,2,rep,name=created" json:"created,omitempty"`
+	Deleted              []string               `protobuf:"bytes,3,rep,name=deleted" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *UpdateGroupResponse) Reset()         { *m = UpdateGroupResponse{} }
+func (m *UpdateGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateGroupResponse) ProtoMessage()    {}
+func (*UpdateGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{58}
+}
+func (m *UpdateGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateGroupResponse.Unmarshal(m, b)
+}
+func (m *UpdateGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateGroupResponse.Marshal(b, m, deterministic)
+}
+func (dst *UpdateGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateGroupResponse.Merge(dst, src)
+}
+func (m *UpdateGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateGroupResponse.Size(m)
+}
+func (m *UpdateGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateGroupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateGroupResponse proto.InternalMessageInfo
+
+func (m *UpdateGroupResponse) GetDiffs() map[string]*UpdateDiff {
+	if m != nil {
+		return m.Diffs
+	}
+	return nil
+}
+
+func (m *UpdateGroupResponse) GetCreated() []string {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *UpdateGroupResponse) GetDeleted() []string {
+	if m != nil {
+		return m.Deleted
+	}
+	return nil
+}
+
+type DeleteGroupRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteGroupRequest) Reset()         { *m = DeleteGroupRequest{} }
+func (m *DeleteGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteGroupRequest) ProtoMessage()    {}
+func (*DeleteGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{59}
+}
+func (m *DeleteGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteGroupRequest.Unmarshal(m, b)
+}
+func (m *DeleteGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteGroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteGroupRequest.Merge(dst, src)
+}
+func (m *DeleteGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteGroupRequest.Size(m)
+}
+func (m *DeleteGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteGroupRequest proto.InternalMessageInfo
+
+func (m *DeleteGroupRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type GroupStatusRequest struct {
+	// id of the group, all groups when empty
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupStatusRequest) Reset()         { *m = GroupStatusRequest{} }
+func (m *GroupStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GroupStatusRequest) ProtoMessage()    {}
+func (*GroupStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{60}
+}
+func (m *GroupStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupStatusRequest.Unmarshal(m, b)
+}
+func (m *GroupStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *GroupStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupStatusRequest.Merge(dst, src)
+}
+func (m *GroupStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GroupStatusRequest.Size(m)
+}
+func (m *GroupStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupStatusRequest proto.InternalMessageInfo
+
+func (m *GroupStatusRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type GroupStatusResponse struct {
+	Groups               []*GroupStatus `protobuf:"bytes,1,rep,name=groups" json:"groups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GroupStatusResponse) Reset()         { *m = GroupStatusResponse{} }
+func (m *GroupStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GroupStatusResponse) ProtoMessage()    {}
+func (*GroupStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{61}
+}
+func (m *GroupStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupStatusResponse.Unmarshal(m, b)
+}
+func (m *GroupStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *GroupStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupStatusResponse.Merge(dst, src)
+}
+func (m *GroupStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GroupStatusResponse.Size(m)
+}
+func (m *GroupStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupStatusResponse proto.InternalMessageInfo
+
+func (m *GroupStatusResponse) GetGroups() []*GroupStatus {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+type GroupStatus struct {
+	ID         string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Containers []*ContainerInfo `protobuf:"bytes,2,rep,name=containers" json:"containers,omitempty"`
+	// members without any containers
+	Missing              []string `protobuf:"bytes,3,rep,name=missing" json:"missing,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupStatus) Reset()         { *m = GroupStatus{} }
+func (m *GroupStatus) String() string { return proto.CompactTextString(m) }
+func (*GroupStatus) ProtoMessage()    {}
+func (*GroupStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{62}
+}
+func (m *GroupStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupStatus.Unmarshal(m, b)
+}
+func (m *GroupStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupStatus.Marshal(b, m, deterministic)
+}
+func (dst *GroupStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupStatus.Merge(dst, src)
+}
+func (m *GroupStatus) XXX_Size() int {
+	return xxx_messageInfo_GroupStatus.Size(m)
+}
+func (m *GroupStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupStatus proto.InternalMessageInfo
+
+func (m *GroupStatus) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *GroupStatus) GetContainers() []*ContainerInfo {
+	if m != nil {
+		return m.Containers
+	}
+	return nil
+}
+
+func (m *GroupStatus) GetMissing() []string {
+	if m != nil {
+		return m.Missing
+	}
+	return nil
+}
+
+type CreateVolumeRequest struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// quota is the size limit of the volume in bytes, zero for no limit
+	Quota                int64    `protobuf:"varint,2,opt,name=quota,proto3" json:"quota,omitempty"`
+	Uid                  uint32   `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Gid                  uint32   `protobuf:"varint,4,opt,name=gid,proto3" json:"gid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateVolumeRequest) Reset()         { *m = CreateVolumeRequest{} }
+func (m *CreateVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateVolumeRequest) ProtoMessage()    {}
+func (*CreateVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{63}
+}
+func (m *CreateVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateVolumeRequest.Unmarshal(m, b)
+}
+func (m *CreateVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateVolumeRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateVolumeRequest.Merge(dst, src)
+}
+func (m *CreateVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateVolumeRequest.Size(m)
+}
+func (m *CreateVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateVolumeRequest proto.InternalMessageInfo
+
+func (m *CreateVolumeRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *CreateVolumeRequest) GetQuota() int64 {
+	if m != nil {
+		return m.Quota
+	}
+	return 0
+}
+
+func (m *CreateVolumeRequest) GetUid() uint32 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *CreateVolumeRequest) GetGid() uint32 {
+	if m != nil {
+		return m.Gid
+	}
+	return 0
+}
+
+type ListVolumesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListVolumesRequest) Reset()         { *m = ListVolumesRequest{} }
+func (m *ListVolumesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListVolumesRequest) ProtoMessage()    {}
+func (*ListVolumesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{64}
+}
+func (m *ListVolumesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumesRequest.Unmarshal(m, b)
+}
+func (m *ListVolumesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListVolumesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumesRequest.Merge(dst, src)
+}
+func (m *ListVolumesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListVolumesRequest.Size(m)
+}
+func (m *ListVolumesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumesRequest proto.InternalMessageInfo
+
+type ListVolumesResponse struct {
+	Volumes              []*VolumeInfo `protobuf:"bytes,1,rep,name=volumes" json:"volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListVolumesResponse) Reset()         { *m = ListVolumesResponse{} }
+func (m *ListVolumesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListVolumesResponse) ProtoMessage()    {}
+func (*ListVolumesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{65}
+}
+func (m *ListVolumesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumesResponse.Unmarshal(m, b)
+}
+func (m *ListVolumesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListVolumesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumesResponse.Merge(dst, src)
+}
+func (m *ListVolumesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListVolumesResponse.Size(m)
+}
+func (m *ListVolumesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumesResponse proto.InternalMessageInfo
+
+func (m *ListVolumesResponse) GetVolumes() []*VolumeInfo {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+type VolumeInfo struct {
+	ID        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Size_     int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Quota     int64  `protobuf:"varint,3,opt,name=quota,proto3" json:"quota,omitempty"`
+	QuotaType string `protobuf:"bytes,4,opt,name=quota_type,json=quotaType,proto3" json:"quota_type,omitempty"`
+	// containers that mount the volume
+	Containers           []string  `protobuf:"bytes,5,rep,name=containers" json:"containers,omitempty"`
+	Created              time.Time `protobuf:"bytes,6,opt,name=created,stdtime" json:"created"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *VolumeInfo) Reset()         { *m = VolumeInfo{} }
+func (m *VolumeInfo) String() string { return proto.CompactTextString(m) }
+func (*VolumeInfo) ProtoMessage()    {}
+func (*VolumeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{66}
+}
+func (m *VolumeInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeInfo.Unmarshal(m, b)
+}
+func (m *VolumeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeInfo.Marshal(b, m, deterministic)
+}
+func (dst *VolumeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeInfo.Merge(dst, src)
+}
+func (m *VolumeInfo) XXX_Size() int {
+	return xxx_messageInfo_VolumeInfo.Size(m)
+}
+func (m *VolumeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeInfo proto.InternalMessageInfo
+
+func (m *VolumeInfo) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *VolumeInfo) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *VolumeInfo) GetQuota() int64 {
+	if m != nil {
+		return m.Quota
+	}
+	return 0
+}
+
+func (m *VolumeInfo) GetQuotaType() string {
+	if m != nil {
+		return m.QuotaType
+	}
+	return ""
+}
+
+func (m *VolumeInfo) GetContainers() []string {
+	if m != nil {
+		return m.Containers
+	}
+	return nil
+}
+
+func (m *VolumeInfo) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
+	}
+	return time.Time{}
+}
+
+type DeleteVolumeRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteVolumeRequest) Reset()         { *m = DeleteVolumeRequest{} }
+func (m *DeleteVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteVolumeRequest) ProtoMessage()    {}
+func (*DeleteVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{67}
+}
+func (m *DeleteVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteVolumeRequest.Unmarshal(m, b)
+}
+func (m *DeleteVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteVolumeRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteVolumeRequest.Merge(dst, src)
+}
+func (m *DeleteVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteVolumeRequest.Size(m)
+}
+func (m *DeleteVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteVolumeRequest proto.InternalMessageInfo
+
+func (m *DeleteVolumeRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type SnapshotVolumeRequest struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ref of the image the snapshot is stored as so it can be pushed
+	Ref                  string   `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotVolumeRequest) Reset()         { *m = SnapshotVolumeRequest{} }
+func (m *SnapshotVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotVolumeRequest) ProtoMessage()    {}
+func (*SnapshotVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{68}
+}
+func (m *SnapshotVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotVolumeRequest.Unmarshal(m, b)
+}
+func (m *SnapshotVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotVolumeRequest.Marshal(b, m, deterministic)
+}
+func (dst *SnapshotVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotVolumeRequest.Merge(dst, src)
+}
+func (m *SnapshotVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotVolumeRequest.Size(m)
+}
+func (m *SnapshotVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotVolumeRequest proto.InternalMessageInfo
+
+func (m *SnapshotVolumeRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *SnapshotVolumeRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type SnapshotVolumeResponse struct {
+	Digest               string   `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	Size_                int64    `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotVolumeResponse) Reset()         { *m = SnapshotVolumeResponse{} }
+func (m *SnapshotVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*SnapshotVolumeResponse) ProtoMessage()    {}
+func (*SnapshotVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{69}
+}
+func (m *SnapshotVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotVolumeResponse.Unmarshal(m, b)
+}
+func (m *SnapshotVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotVolumeResponse.Marshal(b, m, deterministic)
+}
+func (dst *SnapshotVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotVolumeResponse.Merge(dst, src)
+}
+func (m *SnapshotVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_SnapshotVolumeResponse.Size(m)
+}
+func (m *SnapshotVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotVolumeResponse proto.InternalMessageInfo
+
+func (m *SnapshotVolumeResponse) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *SnapshotVolumeResponse) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+type ExportVolumeRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportVolumeRequest) Reset()         { *m = ExportVolumeRequest{} }
+func (m *ExportVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportVolumeRequest) ProtoMessage()    {}
+func (*ExportVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{70}
+}
+func (m *ExportVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportVolumeRequest.Unmarshal(m, b)
+}
+func (m *ExportVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportVolumeRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExportVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportVolumeRequest.Merge(dst, src)
+}
+func (m *ExportVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportVolumeRequest.Size(m)
+}
+func (m *ExportVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportVolumeRequest proto.InternalMessageInfo
+
+func (m *ExportVolumeRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type ExportVolumeResponse struct {
+	// data is the next chunk of the gzipped tar of the volume
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportVolumeResponse) Reset()         { *m = ExportVolumeResponse{} }
+func (m *ExportVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportVolumeResponse) ProtoMessage()    {}
+func (*ExportVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{71}
+}
+func (m *ExportVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportVolumeResponse.Unmarshal(m, b)
+}
+func (m *ExportVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportVolumeResponse.Marshal(b, m, deterministic)
+}
+func (dst *ExportVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportVolumeResponse.Merge(dst, src)
+}
+func (m *ExportVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportVolumeResponse.Size(m)
+}
+func (m *ExportVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportVolumeResponse proto.InternalMessageInfo
+
+func (m *ExportVolumeResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// ReceiveRequest sends a checkpoint to an agent, the first message has the ref and index
+// and is answered with the blobs the agent is missing, which are sent in the following messages
+type ReceiveRequest struct {
+	Ref   string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	Index []byte `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	// digest of the blob that data belongs to, set on the first message of each blob
+	Digest string `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	Data   []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// migration identifies the transfers of a migration, they replace the checkpoint
+	// that the migration sent before under the same ref
+	Migration            string   `protobuf:"bytes,5,opt,name=migration,proto3" json:"migration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiveRequest) Reset()         { *m = ReceiveRequest{} }
+func (m *ReceiveRequest) String() string { return proto.CompactTextString(m) }
+func (*ReceiveRequest) ProtoMessage()    {}
+func (*ReceiveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{72}
+}
+func (m *ReceiveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiveRequest.Unmarshal(m, b)
+}
+func (m *ReceiveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiveRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReceiveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiveRequest.Merge(dst, src)
+}
+func (m *ReceiveRequest) XXX_Size() int {
+	return xxx_messageInfo_ReceiveRequest.Size(m)
+}
+func (m *ReceiveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiveRequest proto.InternalMessageInfo
+
+func (m *ReceiveRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *ReceiveRequest) GetIndex() []byte {
+	if m != nil {
+		return m.Index
+	}
+	return nil
+}
+
+func (m *ReceiveRequest) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *ReceiveRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ReceiveRequest) GetMigration() string {
+	if m != nil {
+		return m.Migration
+	}
+	return ""
+}
+
+type ReceiveResponse struct {
+	// missing are the digests of the blobs in the index that the agent does not have
+	Missing              []string `protobuf:"bytes,1,rep,name=missing" json:"missing,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiveResponse) Reset()         { *m = ReceiveResponse{} }
+func (m *ReceiveResponse) String() string { return proto.CompactTextString(m) }
+func (*ReceiveResponse) ProtoMessage()    {}
+func (*ReceiveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{73}
+}
+func (m *ReceiveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiveResponse.Unmarshal(m, b)
+}
+func (m *ReceiveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiveResponse.Marshal(b, m, deterministic)
+}
+func (dst *ReceiveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiveResponse.Merge(dst, src)
+}
+func (m *ReceiveResponse) XXX_Size() int {
+	return xxx_messageInfo_ReceiveResponse.Size(m)
+}
+func (m *ReceiveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiveResponse proto.InternalMessageInfo
+
+func (m *ReceiveResponse) GetMissing() []string {
+	if m != nil {
+		return m.Missing
+	}
+	return nil
+}
+
+type ListCheckpointsRequest struct {
+	// id of the container to list the checkpoints of, all checkpoints when empty
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCheckpointsRequest) Reset()         { *m = ListCheckpointsRequest{} }
+func (m *ListCheckpointsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCheckpointsRequest) ProtoMessage()    {}
+func (*ListCheckpointsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{74}
+}
+func (m *ListCheckpointsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCheckpointsRequest.Unmarshal(m, b)
+}
+func (m *ListCheckpointsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCheckpointsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListCheckpointsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCheckpointsRequest.Merge(dst, src)
+}
+func (m *ListCheckpointsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCheckpointsRequest.Size(m)
+}
+func (m *ListCheckpointsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCheckpointsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCheckpointsRequest proto.InternalMessageInfo
+
+func (m *ListCheckpointsRequest) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type ListCheckpointsResponse struct {
+	Checkpoints          []*CheckpointInfo `protobuf:"bytes,1,rep,name=checkpoints" json:"checkpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ListCheckpointsResponse) Reset()         { *m = ListCheckpointsResponse{} }
+func (m *ListCheckpointsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCheckpointsResponse) ProtoMessage()    {}
+func (*ListCheckpointsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{75}
+}
+func (m *ListCheckpointsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCheckpointsResponse.Unmarshal(m, b)
+}
+func (m *ListCheckpointsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCheckpointsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListCheckpointsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCheckpointsResponse.Merge(dst, src)
+}
+func (m *ListCheckpointsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCheckpointsResponse.Size(m)
+}
+func (m *ListCheckpointsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCheckpointsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCheckpointsResponse proto.InternalMessageInfo
+
+func (m *ListCheckpointsResponse) GetCheckpoints() []*CheckpointInfo {
+	if m != nil {
+		return m.Checkpoints
+	}
+	return nil
+}
+
+type CheckpointInfo struct {
+	Ref string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// id of the checkpointed container
+	ID string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// node the checkpoint was taken on
+	Node    string    `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	Created time.Time `protobuf:"bytes,4,opt,name=created,stdtime" json:"created"`
+	// size of all the checkpoint's blobs
+	Size_  int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	RwSize int64 `protobuf:"varint,6,opt,name=rw_size,json=rwSize,proto3" json:"rw_size,omitempty"`
+	// live checkpoints have the criu state of the container's processes
+	Live     bool     `protobuf:"varint,7,opt,name=live,proto3" json:"live,omitempty"`
+	PreDumps int64    `protobuf:"varint,8,opt,name=pre_dumps,json=preDumps,proto3" json:"pre_dumps,omitempty"`
+	Volumes  []string `protobuf:"bytes,9,rep,name=volumes" json:"volumes,omitempty"`
+	// config of the container, only set when inspecting a checkpoint
+	Config *Container `protobuf:"bytes,10,opt,name=config" json:"config,omitempty"`
+	// version of the checkpoint format
+	Version              int64    `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointInfo) Reset()         { *m = CheckpointInfo{} }
+func (m *CheckpointInfo) String() string { return proto.CompactTextString(m) }
+func (*CheckpointInfo) ProtoMessage()    {}
+func (*CheckpointInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{76}
+}
+func (m *CheckpointInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointInfo.Unmarshal(m, b)
+}
+func (m *CheckpointInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointInfo.Marshal(b, m, deterministic)
+}
+func (dst *CheckpointInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointInfo.Merge(dst, src)
+}
+func (m *CheckpointInfo) XXX_Size() int {
+	return xxx_messageInfo_CheckpointInfo.Size(m)
+}
+func (m *CheckpointInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointInfo proto.InternalMessageInfo
+
+func (m *CheckpointInfo) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *CheckpointInfo) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *CheckpointInfo) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *CheckpointInfo) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
+	}
+	return time.Time{}
+}
+
+func (m *CheckpointInfo) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *CheckpointInfo) GetRwSize() int64 {
+	if m != nil {
+		return m.RwSize
+	}
+	return 0
+}
+
+func (m *CheckpointInfo) GetLive() bool {
+	if m != nil {
+		return m.Live
+	}
+	return false
+}
+
+func (m *CheckpointInfo) GetPreDumps() int64 {
+	if m != nil {
+		return m.PreDumps
+	}
+	return 0
+}
+
+func (m *CheckpointInfo) GetVolumes() []string {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+func (m *CheckpointInfo) GetConfig() *Container {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *CheckpointInfo) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type InspectCheckpointRequest struct {
+	Ref                  string   `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InspectCheckpointRequest) Reset()         { *m = InspectCheckpointRequest{} }
+func (m *InspectCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectCheckpointRequest) ProtoMessage()    {}
+func (*InspectCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{77}
+}
+func (m *InspectCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectCheckpointRequest.Unmarshal(m, b)
+}
+func (m *InspectCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (dst *InspectCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCheckpointRequest.Merge(dst, src)
+}
+func (m *InspectCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_InspectCheckpointRequest.Size(m)
+}
+func (m *InspectCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectCheckpointRequest proto.InternalMessageInfo
+
+func (m *InspectCheckpointRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type InspectCheckpointResponse struct {
+	Checkpoint           *CheckpointInfo `protobuf:"bytes,1,opt,name=checkpoint" json:"checkpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *InspectCheckpointResponse) Reset()         { *m = InspectCheckpointResponse{} }
+func (m *InspectCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*InspectCheckpointResponse) ProtoMessage()    {}
+func (*InspectCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{78}
+}
+func (m *InspectCheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectCheckpointResponse.Unmarshal(m, b)
+}
+func (m *InspectCheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectCheckpointResponse.Marshal(b, m, deterministic)
+}
+func (dst *InspectCheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectCheckpointResponse.Merge(dst, src)
+}
+func (m *InspectCheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_InspectCheckpointResponse.Size(m)
+}
+func (m *InspectCheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectCheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectCheckpointResponse proto.InternalMessageInfo
+
+func (m *InspectCheckpointResponse) GetCheckpoint() *CheckpointInfo {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+type DeleteCheckpointRequest struct {
+	Ref                  string   `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCheckpointRequest) Reset()         { *m = DeleteCheckpointRequest{} }
+func (m *DeleteCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCheckpointRequest) ProtoMessage()    {}
+func (*DeleteCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{79}
+}
+func (m *DeleteCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCheckpointRequest.Unmarshal(m, b)
+}
+func (m *DeleteCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCheckpointRequest.Merge(dst, src)
+}
+func (m *DeleteCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteCheckpointRequest.Size(m)
+}
+func (m *DeleteCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCheckpointRequest proto.InternalMessageInfo
+
+func (m *DeleteCheckpointRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type ExportCheckpointRequest struct {
+	Ref                  string   `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportCheckpointRequest) Reset()         { *m = ExportCheckpointRequest{} }
+func (m *ExportCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportCheckpointRequest) ProtoMessage()    {}
+func (*ExportCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{80}
+}
+func (m *ExportCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportCheckpointRequest.Unmarshal(m, b)
+}
+func (m *ExportCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExportCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportCheckpointRequest.Merge(dst, src)
+}
+func (m *ExportCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportCheckpointRequest.Size(m)
+}
+func (m *ExportCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportCheckpointRequest proto.InternalMessageInfo
+
+func (m *ExportCheckpointRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+type ExportCheckpointResponse struct {
+	// data is the next chunk of the checkpoint as an oci image layout tar
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportCheckpointResponse) Reset()         { *m = ExportCheckpointResponse{} }
+func (m *ExportCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportCheckpointResponse) ProtoMessage()    {}
+func (*ExportCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{81}
+}
+func (m *ExportCheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportCheckpointResponse.Unmarshal(m, b)
+}
+func (m *ExportCheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportCheckpointResponse.Marshal(b, m, deterministic)
+}
+func (dst *ExportCheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportCheckpointResponse.Merge(dst, src)
+}
+func (m *ExportCheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportCheckpointResponse.Size(m)
+}
+func (m *ExportCheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportCheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportCheckpointResponse proto.InternalMessageInfo
+
+func (m *ExportCheckpointResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ImportCheckpointRequest struct {
+	// ref to import the checkpoint as, set on the first message, the ref in the layout is used when empty
+	Ref string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// data is the next chunk of an oci image layout tar
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportCheckpointRequest) Reset()         { *m = ImportCheckpointRequest{} }
+func (m *ImportCheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportCheckpointRequest) ProtoMessage()    {}
+func (*ImportCheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{82}
+}
+func (m *ImportCheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportCheckpointRequest.Unmarshal(m, b)
+}
+func (m *ImportCheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportCheckpointRequest.Marshal(b, m, deterministic)
+}
+func (dst *ImportCheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportCheckpointRequest.Merge(dst, src)
+}
+func (m *ImportCheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportCheckpointRequest.Size(m)
+}
+func (m *ImportCheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportCheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportCheckpointRequest proto.InternalMessageInfo
+
+func (m *ImportCheckpointRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
+	}
+	return ""
+}
+
+func (m *ImportCheckpointRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ImportCheckpointResponse struct {
+	Refs                 []string `protobuf:"bytes,1,rep,name=refs" json:"refs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportCheckpointResponse) Reset()         { *m = ImportCheckpointResponse{} }
+func (m *ImportCheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportCheckpointResponse) ProtoMessage()    {}
+func (*ImportCheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_boss_acbcc5ac7f08a73a, []int{83}
+}
+func (m *ImportCheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportCheckpointResponse.Unmarshal(m, b)
+}
+func (m *ImportCheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportCheckpointResponse.Marshal(b, m, deterministic)
+}
+func (dst *ImportCheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportCheckpointResponse.Merge(dst, src)
+}
+func (m *ImportCheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportCheckpointResponse.Size(m)
+}
+func (m *ImportCheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportCheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportCheckpointResponse proto.InternalMessageInfo
+
+func (m *ImportCheckpointResponse) GetRefs() []string {
+	if m != nil {
+		return m.Refs
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*CreateRequest)(nil), "io.boss.v1.CreateRequest")
+	proto.RegisterType((*DeleteRequest)(nil), "io.boss.v1.DeleteRequest")
+	proto.RegisterType((*GetRequest)(nil), "io.boss.v1.GetRequest")
+	proto.RegisterType((*GetResponse)(nil), "io.boss.v1.GetResponse")
+	proto.RegisterType((*KillRequest)(nil), "io.boss.v1.KillRequest")
+	proto.RegisterType((*ListRequest)(nil), "io.boss.v1.ListRequest")
+	proto.RegisterType((*ListResponse)(nil), "io.boss.v1.ListResponse")
 	proto.RegisterType((*ContainerInfo)(nil), "io.boss.v1.ContainerInfo")
+	proto.RegisterType((*NetworkInterface)(nil), "io.boss.v1.NetworkInterface")
+	proto.RegisterType((*CheckpointStatus)(nil), "io.boss.v1.CheckpointStatus")
 	proto.RegisterType((*Snapshot)(nil), "io.boss.v1.Snapshot")
 	proto.RegisterType((*RollbackRequest)(nil), "io.boss.v1.RollbackRequest")
 	proto.RegisterType((*RollbackResponse)(nil), "io.boss.v1.RollbackResponse")
@@ -1731,6 +4541,8 @@ func init() {
 	proto.RegisterType((*StopRequest)(nil), "io.boss.v1.StopRequest")
 	proto.RegisterType((*UpdateRequest)(nil), "io.boss.v1.UpdateRequest")
 	proto.RegisterType((*UpdateResponse)(nil), "io.boss.v1.UpdateResponse")
+	proto.RegisterType((*UpdateDiff)(nil), "io.boss.v1.UpdateDiff")
+	proto.RegisterType((*FieldChange)(nil), "io.boss.v1.FieldChange")
 	proto.RegisterType((*PushBuildRequest)(nil), "io.boss.v1.PushBuildRequest")
 	proto.RegisterType((*PushRequest)(nil), "io.boss.v1.PushRequest")
 	proto.RegisterType((*CheckpointRequest)(nil), "io.boss.v1.CheckpointRequest")
@@ -1739,9 +4551,26 @@ func init() {
 	proto.RegisterType((*RestoreResponse)(nil), "io.boss.v1.RestoreResponse")
 	proto.RegisterType((*MigrateRequest)(nil), "io.boss.v1.MigrateRequest")
 	proto.RegisterType((*MigrateResponse)(nil), "io.boss.v1.MigrateResponse")
+	proto.RegisterType((*MigratePhase)(nil), "io.boss.v1.MigratePhase")
+	proto.RegisterType((*AuditRequest)(nil), "io.boss.v1.AuditRequest")
+	proto.RegisterType((*AuditResponse)(nil), "io.boss.v1.AuditResponse")
+	proto.RegisterType((*AuditEntry)(nil), "io.boss.v1.AuditEntry")
+	proto.RegisterType((*ScaleRequest)(nil), "io.boss.v1.ScaleRequest")
+	proto.RegisterType((*JobsRequest)(nil), "io.boss.v1.JobsRequest")
+	proto.RegisterType((*JobsResponse)(nil), "io.boss.v1.JobsResponse")
+	proto.RegisterType((*Job)(nil), "io.boss.v1.Job")
+	proto.RegisterType((*JobRun)(nil), "io.boss.v1.JobRun")
+	proto.RegisterType((*RunRequest)(nil), "io.boss.v1.RunRequest")
+	proto.RegisterType((*DoctorRequest)(nil), "io.boss.v1.DoctorRequest")
+	proto.RegisterType((*DoctorResponse)(nil), "io.boss.v1.DoctorResponse")
+	proto.RegisterType((*Finding)(nil), "io.boss.v1.Finding")
 	proto.RegisterType((*Container)(nil), "io.boss.v1.Container")
 	proto.RegisterMapType((map[string]*Config)(nil), "io.boss.v1.Container.ConfigsEntry")
 	proto.RegisterMapType((map[string]*Service)(nil), "io.boss.v1.Container.ServicesEntry")
+	proto.RegisterType((*Port)(nil), "io.boss.v1.Port")
+	proto.RegisterType((*CheckpointPolicy)(nil), "io.boss.v1.CheckpointPolicy")
+	proto.RegisterType((*Helper)(nil), "io.boss.v1.Helper")
+	proto.RegisterType((*UpdatePolicy)(nil), "io.boss.v1.UpdatePolicy")
 	proto.RegisterType((*Volume)(nil), "io.boss.v1.Volume")
 	proto.RegisterType((*Config)(nil), "io.boss.v1.Config")
 	proto.RegisterType((*Service)(nil), "io.boss.v1.Service")
@@ -1751,444 +4580,1196 @@ func init() {
 	proto.RegisterType((*Mount)(nil), "io.boss.v1.Mount")
 	proto.RegisterType((*Process)(nil), "io.boss.v1.Process")
 	proto.RegisterType((*User)(nil), "io.boss.v1.User")
+	proto.RegisterType((*Group)(nil), "io.boss.v1.Group")
+	proto.RegisterType((*GroupMember)(nil), "io.boss.v1.GroupMember")
+	proto.RegisterType((*CreateGroupRequest)(nil), "io.boss.v1.CreateGroupRequest")
+	proto.RegisterType((*UpdateGroupRequest)(nil), "io.boss.v1.UpdateGroupRequest")
+	proto.RegisterType((*UpdateGroupResponse)(nil), "io.boss.v1.UpdateGroupResponse")
+	proto.RegisterMapType((map[string]*UpdateDiff)(nil), "io.boss.v1.UpdateGroupResponse.DiffsEntry")
+	proto.RegisterType((*DeleteGroupRequest)(nil), "io.boss.v1.DeleteGroupRequest")
+	proto.RegisterType((*GroupStatusRequest)(nil), "io.boss.v1.GroupStatusRequest")
+	proto.RegisterType((*GroupStatusResponse)(nil), "io.boss.v1.GroupStatusResponse")
+	proto.RegisterType((*GroupStatus)(nil), "io.boss.v1.GroupStatus")
+	proto.RegisterType((*CreateVolumeRequest)(nil), "io.boss.v1.CreateVolumeRequest")
+	proto.RegisterType((*ListVolumesRequest)(nil), "io.boss.v1.ListVolumesRequest")
+	proto.RegisterType((*ListVolumesResponse)(nil), "io.boss.v1.ListVolumesResponse")
+	proto.RegisterType((*VolumeInfo)(nil), "io.boss.v1.VolumeInfo")
+	proto.RegisterType((*DeleteVolumeRequest)(nil), "io.boss.v1.DeleteVolumeRequest")
+	proto.RegisterType((*SnapshotVolumeRequest)(nil), "io.boss.v1.SnapshotVolumeRequest")
+	proto.RegisterType((*SnapshotVolumeResponse)(nil), "io.boss.v1.SnapshotVolumeResponse")
+	proto.RegisterType((*ExportVolumeRequest)(nil), "io.boss.v1.ExportVolumeRequest")
+	proto.RegisterType((*ExportVolumeResponse)(nil), "io.boss.v1.ExportVolumeResponse")
+	proto.RegisterType((*ReceiveRequest)(nil), "io.boss.v1.ReceiveRequest")
+	proto.RegisterType((*ReceiveResponse)(nil), "io.boss.v1.ReceiveResponse")
+	proto.RegisterType((*ListCheckpointsRequest)(nil), "io.boss.v1.ListCheckpointsRequest")
+	proto.RegisterType((*ListCheckpointsResponse)(nil), "io.boss.v1.ListCheckpointsResponse")
+	proto.RegisterType((*CheckpointInfo)(nil), "io.boss.v1.CheckpointInfo")
+	proto.RegisterType((*InspectCheckpointRequest)(nil), "io.boss.v1.InspectCheckpointRequest")
+	proto.RegisterType((*InspectCheckpointResponse)(nil), "io.boss.v1.InspectCheckpointResponse")
+	proto.RegisterType((*DeleteCheckpointRequest)(nil), "io.boss.v1.DeleteCheckpointRequest")
+	proto.RegisterType((*ExportCheckpointRequest)(nil), "io.boss.v1.ExportCheckpointRequest")
+	proto.RegisterType((*ExportCheckpointResponse)(nil), "io.boss.v1.ExportCheckpointResponse")
+	proto.RegisterType((*ImportCheckpointRequest)(nil), "io.boss.v1.ImportCheckpointRequest")
+	proto.RegisterType((*ImportCheckpointResponse)(nil), "io.boss.v1.ImportCheckpointResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AgentClient is the client API for Agent service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AgentClient interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	Kill(ctx context.Context, in *KillRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error)
+	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	PushBuild(ctx context.Context, in *PushBuildRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Push(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	Migrate(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (Agent_MigrateClient, error)
+	Audit(ctx context.Context, in *AuditRequest, opts ...grpc.CallOption) (*AuditResponse, error)
+	Doctor(ctx context.Context, in *DoctorRequest, opts ...grpc.CallOption) (*DoctorResponse, error)
+	Scale(ctx context.Context, in *ScaleRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Jobs(ctx context.Context, in *JobsRequest, opts ...grpc.CallOption) (*JobsResponse, error)
+	Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*UpdateGroupResponse, error)
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	GroupStatus(ctx context.Context, in *GroupStatusRequest, opts ...grpc.CallOption) (*GroupStatusResponse, error)
+	CreateVolume(ctx context.Context, in *CreateVolumeRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error)
+	DeleteVolume(ctx context.Context, in *DeleteVolumeRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	SnapshotVolume(ctx context.Context, in *SnapshotVolumeRequest, opts ...grpc.CallOption) (*SnapshotVolumeResponse, error)
+	ExportVolume(ctx context.Context, in *ExportVolumeRequest, opts ...grpc.CallOption) (Agent_ExportVolumeClient, error)
+	Receive(ctx context.Context, opts ...grpc.CallOption) (Agent_ReceiveClient, error)
+	ListCheckpoints(ctx context.Context, in *ListCheckpointsRequest, opts ...grpc.CallOption) (*ListCheckpointsResponse, error)
+	InspectCheckpoint(ctx context.Context, in *InspectCheckpointRequest, opts ...grpc.CallOption) (*InspectCheckpointResponse, error)
+	DeleteCheckpoint(ctx context.Context, in *DeleteCheckpointRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	ExportCheckpoint(ctx context.Context, in *ExportCheckpointRequest, opts ...grpc.CallOption) (Agent_ExportCheckpointClient, error)
+	ImportCheckpoint(ctx context.Context, opts ...grpc.CallOption) (Agent_ImportCheckpointClient, error)
+}
+
+type agentClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAgentClient(cc *grpc.ClientConn) AgentClient {
+	return &agentClient{cc}
+}
+
+func (c *agentClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Kill(ctx context.Context, in *KillRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Kill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error) {
+	out := new(RollbackResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Rollback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Start", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Stop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) PushBuild(ctx context.Context, in *PushBuildRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/PushBuild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Push(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Push", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error) {
+	out := new(CheckpointResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Checkpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Migrate(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (Agent_MigrateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[0], "/io.boss.v1.Agent/Migrate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentMigrateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_MigrateClient interface {
+	Recv() (*MigrateResponse, error)
+	grpc.ClientStream
+}
+
+type agentMigrateClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentMigrateClient) Recv() (*MigrateResponse, error) {
+	m := new(MigrateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) Audit(ctx context.Context, in *AuditRequest, opts ...grpc.CallOption) (*AuditResponse, error) {
+	out := new(AuditResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Audit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Doctor(ctx context.Context, in *DoctorRequest, opts ...grpc.CallOption) (*DoctorResponse, error) {
+	out := new(DoctorResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Doctor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Scale(ctx context.Context, in *ScaleRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Scale", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Jobs(ctx context.Context, in *JobsRequest, opts ...grpc.CallOption) (*JobsResponse, error) {
+	out := new(JobsResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Jobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Run", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/CreateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*UpdateGroupResponse, error) {
+	out := new(UpdateGroupResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/UpdateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/DeleteGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GroupStatus(ctx context.Context, in *GroupStatusRequest, opts ...grpc.CallOption) (*GroupStatusResponse, error) {
+	out := new(GroupStatusResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/GroupStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) CreateVolume(ctx context.Context, in *CreateVolumeRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/CreateVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error) {
+	out := new(ListVolumesResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/ListVolumes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteVolume(ctx context.Context, in *DeleteVolumeRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/DeleteVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) SnapshotVolume(ctx context.Context, in *SnapshotVolumeRequest, opts ...grpc.CallOption) (*SnapshotVolumeResponse, error) {
+	out := new(SnapshotVolumeResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/SnapshotVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ExportVolume(ctx context.Context, in *ExportVolumeRequest, opts ...grpc.CallOption) (Agent_ExportVolumeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[1], "/io.boss.v1.Agent/ExportVolume", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentExportVolumeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_ExportVolumeClient interface {
+	Recv() (*ExportVolumeResponse, error)
+	grpc.ClientStream
+}
+
+type agentExportVolumeClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentExportVolumeClient) Recv() (*ExportVolumeResponse, error) {
+	m := new(ExportVolumeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) Receive(ctx context.Context, opts ...grpc.CallOption) (Agent_ReceiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[2], "/io.boss.v1.Agent/Receive", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentReceiveClient{stream}
+	return x, nil
+}
+
+type Agent_ReceiveClient interface {
+	Send(*ReceiveRequest) error
+	Recv() (*ReceiveResponse, error)
+	grpc.ClientStream
+}
+
+type agentReceiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentReceiveClient) Send(m *ReceiveRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentReceiveClient) Recv() (*ReceiveResponse, error) {
+	m := new(ReceiveResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) ListCheckpoints(ctx context.Context, in *ListCheckpointsRequest, opts ...grpc.CallOption) (*ListCheckpointsResponse, error) {
+	out := new(ListCheckpointsResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/ListCheckpoints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) InspectCheckpoint(ctx context.Context, in *InspectCheckpointRequest, opts ...grpc.CallOption) (*InspectCheckpointResponse, error) {
+	out := new(InspectCheckpointResponse)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/InspectCheckpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteCheckpoint(ctx context.Context, in *DeleteCheckpointRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/DeleteCheckpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ExportCheckpoint(ctx context.Context, in *ExportCheckpointRequest, opts ...grpc.CallOption) (Agent_ExportCheckpointClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[3], "/io.boss.v1.Agent/ExportCheckpoint", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentExportCheckpointClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_ExportCheckpointClient interface {
+	Recv() (*ExportCheckpointResponse, error)
+	grpc.ClientStream
+}
+
+type agentExportCheckpointClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentExportCheckpointClient) Recv() (*ExportCheckpointResponse, error) {
+	m := new(ExportCheckpointResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) ImportCheckpoint(ctx context.Context, opts ...grpc.CallOption) (Agent_ImportCheckpointClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[4], "/io.boss.v1.Agent/ImportCheckpoint", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentImportCheckpointClient{stream}
+	return x, nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+type Agent_ImportCheckpointClient interface {
+	Send(*ImportCheckpointRequest) error
+	CloseAndRecv() (*ImportCheckpointResponse, error)
+	grpc.ClientStream
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type agentImportCheckpointClient struct {
+	grpc.ClientStream
+}
 
-// AgentClient is the client API for Agent service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type AgentClient interface {
-	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	Kill(ctx context.Context, in *KillRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
-	Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error)
-	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
-	PushBuild(ctx context.Context, in *PushBuildRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	Push(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
-	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
-	Migrate(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (*MigrateResponse, error)
+func (x *agentImportCheckpointClient) Send(m *ImportCheckpointRequest) error {
+	return x.ClientStream.SendMsg(m)
 }
 
-type agentClient struct {
-	cc *grpc.ClientConn
+func (x *agentImportCheckpointClient) CloseAndRecv() (*ImportCheckpointResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportCheckpointResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func NewAgentClient(cc *grpc.ClientConn) AgentClient {
-	return &agentClient{cc}
+// AgentServer is the server API for Agent service.
+type AgentServer interface {
+	Create(context.Context, *CreateRequest) (*types.Empty, error)
+	Delete(context.Context, *DeleteRequest) (*types.Empty, error)
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	Kill(context.Context, *KillRequest) (*types.Empty, error)
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	Rollback(context.Context, *RollbackRequest) (*RollbackResponse, error)
+	Start(context.Context, *StartRequest) (*types.Empty, error)
+	Stop(context.Context, *StopRequest) (*types.Empty, error)
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	PushBuild(context.Context, *PushBuildRequest) (*types.Empty, error)
+	Push(context.Context, *PushRequest) (*types.Empty, error)
+	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	Migrate(*MigrateRequest, Agent_MigrateServer) error
+	Audit(context.Context, *AuditRequest) (*AuditResponse, error)
+	Doctor(context.Context, *DoctorRequest) (*DoctorResponse, error)
+	Scale(context.Context, *ScaleRequest) (*types.Empty, error)
+	Jobs(context.Context, *JobsRequest) (*JobsResponse, error)
+	Run(context.Context, *RunRequest) (*types.Empty, error)
+	CreateGroup(context.Context, *CreateGroupRequest) (*types.Empty, error)
+	UpdateGroup(context.Context, *UpdateGroupRequest) (*UpdateGroupResponse, error)
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*types.Empty, error)
+	GroupStatus(context.Context, *GroupStatusRequest) (*GroupStatusResponse, error)
+	CreateVolume(context.Context, *CreateVolumeRequest) (*types.Empty, error)
+	ListVolumes(context.Context, *ListVolumesRequest) (*ListVolumesResponse, error)
+	DeleteVolume(context.Context, *DeleteVolumeRequest) (*types.Empty, error)
+	SnapshotVolume(context.Context, *SnapshotVolumeRequest) (*SnapshotVolumeResponse, error)
+	ExportVolume(*ExportVolumeRequest, Agent_ExportVolumeServer) error
+	Receive(Agent_ReceiveServer) error
+	ListCheckpoints(context.Context, *ListCheckpointsRequest) (*ListCheckpointsResponse, error)
+	InspectCheckpoint(context.Context, *InspectCheckpointRequest) (*InspectCheckpointResponse, error)
+	DeleteCheckpoint(context.Context, *DeleteCheckpointRequest) (*types.Empty, error)
+	ExportCheckpoint(*ExportCheckpointRequest, Agent_ExportCheckpointServer) error
+	ImportCheckpoint(Agent_ImportCheckpointServer) error
 }
 
-func (c *agentClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Create", in, out, opts...)
-	if err != nil {
+func RegisterAgentServer(s *grpc.Server, srv AgentServer) {
+	s.RegisterService(&_Agent_serviceDesc, srv)
+}
+
+func _Agent_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AgentServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *agentClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Delete", in, out, opts...)
-	if err != nil {
+func _Agent_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AgentServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *agentClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
-	out := new(GetResponse)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Get", in, out, opts...)
-	if err != nil {
+func _Agent_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AgentServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *agentClient) Kill(ctx context.Context, in *KillRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Kill", in, out, opts...)
-	if err != nil {
+func _Agent_Kill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KillRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AgentServer).Kill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Kill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Kill(ctx, req.(*KillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *agentClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
-	out := new(ListResponse)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/List", in, out, opts...)
-	if err != nil {
+func _Agent_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AgentServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *agentClient) Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error) {
-	out := new(RollbackResponse)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Rollback", in, out, opts...)
-	if err != nil {
+func _Agent_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AgentServer).Rollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Rollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Rollback(ctx, req.(*RollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *agentClient) Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Start", in, out, opts...)
-	if err != nil {
+func _Agent_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).Start(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Start",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Start(ctx, req.(*StartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AgentServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Stop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Stop(ctx, req.(*StopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_PushBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushBuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).PushBuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/PushBuild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).PushBuild(ctx, req.(*PushBuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).Push(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Push",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Push(ctx, req.(*PushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *agentClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Stop", in, out, opts...)
-	if err != nil {
+func _Agent_Checkpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AgentServer).Checkpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Checkpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Checkpoint(ctx, req.(*CheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *agentClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
-	out := new(UpdateResponse)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Update", in, out, opts...)
-	if err != nil {
+func _Agent_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AgentServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *agentClient) PushBuild(ctx context.Context, in *PushBuildRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/PushBuild", in, out, opts...)
-	if err != nil {
-		return nil, err
+func _Agent_Migrate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MigrateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(AgentServer).Migrate(m, &agentMigrateServer{stream})
 }
 
-func (c *agentClient) Push(ctx context.Context, in *PushRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Push", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type Agent_MigrateServer interface {
+	Send(*MigrateResponse) error
+	grpc.ServerStream
 }
 
-func (c *agentClient) Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error) {
-	out := new(CheckpointResponse)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Checkpoint", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type agentMigrateServer struct {
+	grpc.ServerStream
 }
 
-func (c *agentClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
-	out := new(RestoreResponse)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Restore", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (x *agentMigrateServer) Send(m *MigrateResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (c *agentClient) Migrate(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (*MigrateResponse, error) {
-	out := new(MigrateResponse)
-	err := c.cc.Invoke(ctx, "/io.boss.v1.Agent/Migrate", in, out, opts...)
-	if err != nil {
+func _Agent_Audit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// AgentServer is the server API for Agent service.
-type AgentServer interface {
-	Create(context.Context, *CreateRequest) (*types.Empty, error)
-	Delete(context.Context, *DeleteRequest) (*types.Empty, error)
-	Get(context.Context, *GetRequest) (*GetResponse, error)
-	Kill(context.Context, *KillRequest) (*types.Empty, error)
-	List(context.Context, *ListRequest) (*ListResponse, error)
-	Rollback(context.Context, *RollbackRequest) (*RollbackResponse, error)
-	Start(context.Context, *StartRequest) (*types.Empty, error)
-	Stop(context.Context, *StopRequest) (*types.Empty, error)
-	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
-	PushBuild(context.Context, *PushBuildRequest) (*types.Empty, error)
-	Push(context.Context, *PushRequest) (*types.Empty, error)
-	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
-	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
-	Migrate(context.Context, *MigrateRequest) (*MigrateResponse, error)
+	if interceptor == nil {
+		return srv.(AgentServer).Audit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Audit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Audit(ctx, req.(*AuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterAgentServer(s *grpc.Server, srv AgentServer) {
-	s.RegisterService(&_Agent_serviceDesc, srv)
+func _Agent_Doctor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DoctorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).Doctor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/io.boss.v1.Agent/Doctor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).Doctor(ctx, req.(*DoctorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRequest)
+func _Agent_Scale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Create(ctx, in)
+		return srv.(AgentServer).Scale(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Create",
+		FullMethod: "/io.boss.v1.Agent/Scale",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Create(ctx, req.(*CreateRequest))
+		return srv.(AgentServer).Scale(ctx, req.(*ScaleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRequest)
+func _Agent_Jobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Delete(ctx, in)
+		return srv.(AgentServer).Jobs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Delete",
+		FullMethod: "/io.boss.v1.Agent/Jobs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Delete(ctx, req.(*DeleteRequest))
+		return srv.(AgentServer).Jobs(ctx, req.(*JobsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRequest)
+func _Agent_Run_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Get(ctx, in)
+		return srv.(AgentServer).Run(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Get",
+		FullMethod: "/io.boss.v1.Agent/Run",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Get(ctx, req.(*GetRequest))
+		return srv.(AgentServer).Run(ctx, req.(*RunRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Kill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(KillRequest)
+func _Agent_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Kill(ctx, in)
+		return srv.(AgentServer).CreateGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Kill",
+		FullMethod: "/io.boss.v1.Agent/CreateGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Kill(ctx, req.(*KillRequest))
+		return srv.(AgentServer).CreateGroup(ctx, req.(*CreateGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRequest)
+func _Agent_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).List(ctx, in)
+		return srv.(AgentServer).UpdateGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/List",
+		FullMethod: "/io.boss.v1.Agent/UpdateGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).List(ctx, req.(*ListRequest))
+		return srv.(AgentServer).UpdateGroup(ctx, req.(*UpdateGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RollbackRequest)
+func _Agent_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Rollback(ctx, in)
+		return srv.(AgentServer).DeleteGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Rollback",
+		FullMethod: "/io.boss.v1.Agent/DeleteGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Rollback(ctx, req.(*RollbackRequest))
+		return srv.(AgentServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StartRequest)
+func _Agent_GroupStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Start(ctx, in)
+		return srv.(AgentServer).GroupStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Start",
+		FullMethod: "/io.boss.v1.Agent/GroupStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Start(ctx, req.(*StartRequest))
+		return srv.(AgentServer).GroupStatus(ctx, req.(*GroupStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StopRequest)
+func _Agent_CreateVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVolumeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Stop(ctx, in)
+		return srv.(AgentServer).CreateVolume(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Stop",
+		FullMethod: "/io.boss.v1.Agent/CreateVolume",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Stop(ctx, req.(*StopRequest))
+		return srv.(AgentServer).CreateVolume(ctx, req.(*CreateVolumeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRequest)
+func _Agent_ListVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVolumesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Update(ctx, in)
+		return srv.(AgentServer).ListVolumes(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Update",
+		FullMethod: "/io.boss.v1.Agent/ListVolumes",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Update(ctx, req.(*UpdateRequest))
+		return srv.(AgentServer).ListVolumes(ctx, req.(*ListVolumesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_PushBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PushBuildRequest)
+func _Agent_DeleteVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVolumeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).PushBuild(ctx, in)
+		return srv.(AgentServer).DeleteVolume(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/PushBuild",
+		FullMethod: "/io.boss.v1.Agent/DeleteVolume",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).PushBuild(ctx, req.(*PushBuildRequest))
+		return srv.(AgentServer).DeleteVolume(ctx, req.(*DeleteVolumeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PushRequest)
+func _Agent_SnapshotVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotVolumeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Push(ctx, in)
+		return srv.(AgentServer).SnapshotVolume(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Push",
+		FullMethod: "/io.boss.v1.Agent/SnapshotVolume",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Push(ctx, req.(*PushRequest))
+		return srv.(AgentServer).SnapshotVolume(ctx, req.(*SnapshotVolumeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Checkpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CheckpointRequest)
+func _Agent_ExportVolume_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportVolumeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).ExportVolume(m, &agentExportVolumeServer{stream})
+}
+
+type Agent_ExportVolumeServer interface {
+	Send(*ExportVolumeResponse) error
+	grpc.ServerStream
+}
+
+type agentExportVolumeServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentExportVolumeServer) Send(m *ExportVolumeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Agent_Receive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).Receive(&agentReceiveServer{stream})
+}
+
+type Agent_ReceiveServer interface {
+	Send(*ReceiveResponse) error
+	Recv() (*ReceiveRequest, error)
+	grpc.ServerStream
+}
+
+type agentReceiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentReceiveServer) Send(m *ReceiveResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentReceiveServer) Recv() (*ReceiveRequest, error) {
+	m := new(ReceiveRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Agent_ListCheckpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCheckpointsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Checkpoint(ctx, in)
+		return srv.(AgentServer).ListCheckpoints(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Checkpoint",
+		FullMethod: "/io.boss.v1.Agent/ListCheckpoints",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Checkpoint(ctx, req.(*CheckpointRequest))
+		return srv.(AgentServer).ListCheckpoints(ctx, req.(*ListCheckpointsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RestoreRequest)
+func _Agent_InspectCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectCheckpointRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Restore(ctx, in)
+		return srv.(AgentServer).InspectCheckpoint(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Restore",
+		FullMethod: "/io.boss.v1.Agent/InspectCheckpoint",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Restore(ctx, req.(*RestoreRequest))
+		return srv.(AgentServer).InspectCheckpoint(ctx, req.(*InspectCheckpointRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Agent_Migrate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MigrateRequest)
+func _Agent_DeleteCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCheckpointRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AgentServer).Migrate(ctx, in)
+		return srv.(AgentServer).DeleteCheckpoint(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/io.boss.v1.Agent/Migrate",
+		FullMethod: "/io.boss.v1.Agent/DeleteCheckpoint",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AgentServer).Migrate(ctx, req.(*MigrateRequest))
+		return srv.(AgentServer).DeleteCheckpoint(ctx, req.(*DeleteCheckpointRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_ExportCheckpoint_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCheckpointRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).ExportCheckpoint(m, &agentExportCheckpointServer{stream})
+}
+
+type Agent_ExportCheckpointServer interface {
+	Send(*ExportCheckpointResponse) error
+	grpc.ServerStream
+}
+
+type agentExportCheckpointServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentExportCheckpointServer) Send(m *ExportCheckpointResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Agent_ImportCheckpoint_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).ImportCheckpoint(&agentImportCheckpointServer{stream})
+}
+
+type Agent_ImportCheckpointServer interface {
+	SendAndClose(*ImportCheckpointResponse) error
+	Recv() (*ImportCheckpointRequest, error)
+	grpc.ServerStream
+}
+
+type agentImportCheckpointServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentImportCheckpointServer) SendAndClose(m *ImportCheckpointResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentImportCheckpointServer) Recv() (*ImportCheckpointRequest, error) {
+	m := new(ImportCheckpointRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Agent_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "io.boss.v1.Agent",
 	HandlerType: (*AgentServer)(nil),
@@ -2246,115 +5827,327 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Agent_Restore_Handler,
 		},
 		{
-			MethodName: "Migrate",
-			Handler:    _Agent_Migrate_Handler,
+			MethodName: "Audit",
+			Handler:    _Agent_Audit_Handler,
+		},
+		{
+			MethodName: "Doctor",
+			Handler:    _Agent_Doctor_Handler,
+		},
+		{
+			MethodName: "Scale",
+			Handler:    _Agent_Scale_Handler,
+		},
+		{
+			MethodName: "Jobs",
+			Handler:    _Agent_Jobs_Handler,
+		},
+		{
+			MethodName: "Run",
+			Handler:    _Agent_Run_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _Agent_CreateGroup_Handler,
+		},
+		{
+			MethodName: "UpdateGroup",
+			Handler:    _Agent_UpdateGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _Agent_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "GroupStatus",
+			Handler:    _Agent_GroupStatus_Handler,
+		},
+		{
+			MethodName: "CreateVolume",
+			Handler:    _Agent_CreateVolume_Handler,
+		},
+		{
+			MethodName: "ListVolumes",
+			Handler:    _Agent_ListVolumes_Handler,
+		},
+		{
+			MethodName: "DeleteVolume",
+			Handler:    _Agent_DeleteVolume_Handler,
+		},
+		{
+			MethodName: "SnapshotVolume",
+			Handler:    _Agent_SnapshotVolume_Handler,
+		},
+		{
+			MethodName: "ListCheckpoints",
+			Handler:    _Agent_ListCheckpoints_Handler,
+		},
+		{
+			MethodName: "InspectCheckpoint",
+			Handler:    _Agent_InspectCheckpoint_Handler,
+		},
+		{
+			MethodName: "DeleteCheckpoint",
+			Handler:    _Agent_DeleteCheckpoint_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Migrate",
+			Handler:       _Agent_Migrate_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportVolume",
+			Handler:       _Agent_ExportVolume_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Receive",
+			Handler:       _Agent_Receive_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportCheckpoint",
+			Handler:       _Agent_ExportCheckpoint_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportCheckpoint",
+			Handler:       _Agent_ImportCheckpoint_Handler,
+			ClientStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "github.com/crosbymichael/boss/api/v1/boss.proto",
 }
 
 func init() {
-	proto.RegisterFile("github.com/crosbymichael/boss/api/v1/boss.proto", fileDescriptor_boss_c670a4faf431fe91)
-}
-
-var fileDescriptor_boss_c670a4faf431fe91 = []byte{
-	// 1550 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x6d, 0x6f, 0x1b, 0xc5,
-	0x13, 0xaf, 0x9f, 0xed, 0x71, 0x9c, 0xa6, 0xfb, 0xcf, 0x3f, 0x3d, 0x2e, 0x40, 0xcc, 0x51, 0x68,
-	0x82, 0xa8, 0xad, 0xa6, 0xa2, 0xa5, 0x14, 0xa8, 0x9a, 0x87, 0x86, 0xaa, 0x2d, 0x8a, 0x36, 0x04,
-	0x21, 0x84, 0x54, 0x9d, 0xcf, 0x6b, 0x7b, 0x95, 0xf3, 0xed, 0x71, 0xbb, 0xe7, 0xe2, 0x7e, 0x04,
-	0x5e, 0xf1, 0x8e, 0xaf, 0xc4, 0xa7, 0x28, 0x12, 0x5f, 0x81, 0x77, 0xf0, 0x06, 0xed, 0xc3, 0x9d,
-	0xef, 0x1c, 0xbb, 0x29, 0xf4, 0xdd, 0xcc, 0xce, 0xd3, 0x6f, 0xd6, 0x33, 0x73, 0xb3, 0x86, 0xee,
-	0x90, 0x8a, 0x51, 0xdc, 0xeb, 0x78, 0x6c, 0xdc, 0xf5, 0x22, 0xc6, 0x7b, 0xd3, 0x31, 0xf5, 0x46,
-	0x2e, 0xf1, 0xbb, 0x3d, 0xc6, 0x79, 0xd7, 0x0d, 0x69, 0x77, 0x72, 0x53, 0xd1, 0x9d, 0x30, 0x62,
-	0x82, 0x21, 0xa0, 0xac, 0xa3, 0xd8, 0xc9, 0x4d, 0x7b, 0x7d, 0xc8, 0x86, 0x4c, 0x1d, 0x77, 0x25,
-	0xa5, 0x35, 0xec, 0xcd, 0x21, 0x63, 0x43, 0x9f, 0x74, 0x15, 0xd7, 0x8b, 0x07, 0x5d, 0x32, 0x0e,
-	0xc5, 0xd4, 0x08, 0xb7, 0xe6, 0x85, 0x82, 0x8e, 0x09, 0x17, 0xee, 0x38, 0xd4, 0x0a, 0xce, 0x0f,
-	0xd0, 0xda, 0x8f, 0x88, 0x2b, 0x08, 0x26, 0x3f, 0xc6, 0x84, 0x0b, 0x74, 0x0b, 0x1a, 0x1e, 0x0b,
-	0x84, 0x4b, 0x03, 0x12, 0x59, 0x85, 0x76, 0x61, 0xbb, 0xb9, 0xfb, 0xff, 0xce, 0x0c, 0x44, 0x67,
-	0x3f, 0x11, 0xe2, 0x99, 0x1e, 0xda, 0x80, 0x6a, 0x1c, 0xf6, 0x5d, 0x41, 0xac, 0x62, 0xbb, 0xb0,
-	0x5d, 0xc7, 0x86, 0x73, 0xae, 0x43, 0xeb, 0x80, 0xf8, 0x64, 0xe6, 0x7d, 0x03, 0x8a, 0xb4, 0xaf,
-	0xdc, 0x36, 0xf6, 0xaa, 0x7f, 0xbc, 0xdc, 0x2a, 0x3e, 0x3a, 0xc0, 0x45, 0xda, 0x77, 0xae, 0x01,
-	0x1c, 0x11, 0x71, 0x91, 0xd6, 0x43, 0x68, 0x2a, 0x2d, 0x1e, 0xb2, 0x80, 0x13, 0x74, 0xe7, 0x3c,
-	0xd4, 0xb7, 0x16, 0x42, 0x7d, 0x14, 0x0c, 0x58, 0x06, 0xae, 0xf3, 0x05, 0x34, 0x1f, 0x53, 0xdf,
-	0xbf, 0x20, 0x9c, 0xcc, 0x8a, 0xd3, 0x61, 0xe0, 0xfa, 0x2a, 0xab, 0x16, 0x36, 0x9c, 0xd3, 0x82,
-	0xe6, 0x13, 0xca, 0x13, 0xb4, 0xce, 0x23, 0x58, 0xd1, 0xac, 0x81, 0x75, 0x17, 0x20, 0x0d, 0xc5,
-	0xad, 0x42, 0xbb, 0xf4, 0x6a, 0x5c, 0x19, 0x65, 0xe7, 0xef, 0x22, 0xb4, 0x72, 0xd2, 0xa5, 0xd8,
-	0xd6, 0xa1, 0x42, 0xc7, 0xee, 0x50, 0x5f, 0x78, 0x03, 0x6b, 0x46, 0x21, 0x16, 0xae, 0x88, 0xb9,
-	0x55, 0x52, 0xc7, 0x86, 0x53, 0x5e, 0x42, 0xab, 0x9c, 0xf1, 0x72, 0x8c, 0x8b, 0x34, 0x44, 0x6b,
-	0x50, 0xf2, 0xc2, 0xd8, 0xaa, 0xb4, 0x0b, 0xdb, 0x65, 0x2c, 0x49, 0xf4, 0x1e, 0xac, 0x8c, 0xc9,
-	0x98, 0x45, 0xd3, 0x67, 0x31, 0x97, 0xee, 0xab, 0xed, 0xc2, 0x76, 0x01, 0x37, 0xf5, 0xd9, 0xa9,
-	0x3c, 0xca, 0xa8, 0xf8, 0x74, 0x4c, 0x85, 0x55, 0xcb, 0xaa, 0x3c, 0x91, 0x47, 0x68, 0x13, 0x1a,
-	0x21, 0xed, 0x1b, 0x17, 0x75, 0xe5, 0xbd, 0x1e, 0xd2, 0xbe, 0xb6, 0x37, 0x42, 0x6d, 0xdc, 0x48,
-	0x85, 0xda, 0xf2, 0x2a, 0xd4, 0x06, 0xfc, 0x19, 0xa7, 0x2f, 0x88, 0x05, 0xed, 0xc2, 0x76, 0x09,
-	0x57, 0x07, 0xfc, 0x84, 0xbe, 0x20, 0xe8, 0x06, 0x54, 0x3d, 0x16, 0x0c, 0xe8, 0xd0, 0x6a, 0xbe,
-	0xaa, 0x28, 0x8d, 0x12, 0xda, 0x85, 0x06, 0x0f, 0xdc, 0x90, 0x8f, 0x98, 0xe0, 0xd6, 0x8a, 0xfa,
-	0x0d, 0xd6, 0xb3, 0x16, 0x27, 0x46, 0x88, 0x67, 0x6a, 0xce, 0xaf, 0x05, 0xa8, 0x27, 0xe7, 0x4b,
-	0x2f, 0xfe, 0x4b, 0xa8, 0x79, 0xaa, 0x61, 0xfa, 0xea, 0xea, 0x9b, 0xbb, 0x76, 0x47, 0xf7, 0x58,
-	0x27, 0xe9, 0xb1, 0xce, 0x37, 0x49, 0x8f, 0xed, 0xd5, 0x7f, 0x7b, 0xb9, 0x75, 0xe9, 0x97, 0xdf,
-	0xb7, 0x0a, 0x38, 0x31, 0x42, 0x36, 0xd4, 0xc3, 0x88, 0x4c, 0x28, 0x4b, 0x7f, 0xa4, 0x94, 0xcf,
-	0x26, 0x5f, 0xce, 0x26, 0xef, 0xec, 0xc0, 0x65, 0xcc, 0x7c, 0xbf, 0xe7, 0x7a, 0x67, 0x17, 0xf5,
-	0xc8, 0x11, 0xac, 0xcd, 0x54, 0x4d, 0x45, 0xfe, 0x97, 0x9e, 0x76, 0x3e, 0x84, 0x95, 0x13, 0xe1,
-	0x46, 0x17, 0x36, 0xe5, 0x07, 0xd0, 0x3c, 0x11, 0x2c, 0xbc, 0x48, 0xed, 0x00, 0x5a, 0xa7, 0x6a,
-	0x28, 0xbc, 0xc9, 0xa0, 0x71, 0x0e, 0x61, 0x35, 0xf1, 0xf2, 0x26, 0xb9, 0x5d, 0x83, 0xb5, 0xe3,
-	0x98, 0x8f, 0xf6, 0x62, 0xea, 0xf7, 0x13, 0x3c, 0x6b, 0x50, 0x8a, 0xc8, 0x40, 0x23, 0xc7, 0x92,
-	0x74, 0x3e, 0x81, 0xa6, 0xd4, 0x5a, 0xaa, 0x20, 0x9b, 0xb0, 0x27, 0x5d, 0x98, 0xa9, 0xa7, 0x19,
-	0x87, 0xc0, 0x95, 0xfd, 0x11, 0xf1, 0xce, 0x42, 0x46, 0x83, 0x8b, 0x6e, 0x2f, 0x71, 0x5a, 0x9c,
-	0x39, 0x45, 0x50, 0xf6, 0xe9, 0x84, 0xa8, 0xe2, 0xa8, 0x63, 0x45, 0xcb, 0x33, 0xf2, 0x13, 0x15,
-	0xaa, 0x2a, 0xea, 0x58, 0xd1, 0xce, 0x3a, 0xa0, 0x6c, 0x18, 0x7d, 0x1d, 0xce, 0x6d, 0x58, 0xc5,
-	0x84, 0x0b, 0x16, 0x91, 0xe5, 0xb0, 0x93, 0x08, 0xc5, 0x59, 0x04, 0xe7, 0x0a, 0x5c, 0x4e, 0xed,
-	0x8c, 0xab, 0x9f, 0x0b, 0xb0, 0xfa, 0x94, 0x0e, 0x23, 0xf7, 0xc2, 0xf1, 0xfd, 0xfa, 0x59, 0x70,
-	0xc1, 0xc2, 0x24, 0x0b, 0x49, 0xa3, 0x55, 0x28, 0x0a, 0xa6, 0x06, 0x50, 0x03, 0x17, 0x85, 0x9c,
-	0x77, 0xd5, 0xbe, 0xfa, 0x62, 0xa8, 0xc9, 0x53, 0xc7, 0x86, 0x93, 0xf8, 0x52, 0x2c, 0x06, 0xdf,
-	0x9f, 0x65, 0x68, 0xec, 0x67, 0x3e, 0x41, 0xff, 0x66, 0x50, 0x5a, 0x50, 0x0b, 0x88, 0x78, 0xce,
-	0xa2, 0x33, 0xd3, 0x84, 0x09, 0x8b, 0x6e, 0x40, 0x2d, 0x8c, 0x98, 0x47, 0x38, 0x57, 0x38, 0x9b,
-	0xbb, 0xff, 0xcb, 0x56, 0xd3, 0xb1, 0x16, 0xe1, 0x44, 0x07, 0xed, 0x40, 0x75, 0xcc, 0xe2, 0x40,
-	0x70, 0xab, 0xa2, 0x86, 0xcc, 0x95, 0xac, 0xf6, 0x53, 0x29, 0xc1, 0x46, 0x41, 0x56, 0x6a, 0x44,
-	0x38, 0x8b, 0x23, 0x8f, 0x70, 0x95, 0xdd, 0x5c, 0xa5, 0xe2, 0x44, 0x88, 0x67, 0x7a, 0xe8, 0x1a,
-	0x94, 0x87, 0x61, 0xcc, 0xd5, 0x90, 0x6d, 0xee, 0xae, 0x65, 0xf5, 0x8f, 0x8e, 0x4f, 0x39, 0x56,
-	0x52, 0x74, 0x1f, 0xea, 0x9c, 0x44, 0x13, 0x2a, 0x3d, 0xd7, 0x15, 0x8e, 0xf7, 0x17, 0xf6, 0x40,
-	0xe7, 0xc4, 0x68, 0x1d, 0x06, 0x22, 0x9a, 0xe2, 0xd4, 0x08, 0x7d, 0x0e, 0x35, 0x3d, 0x38, 0xb9,
-	0xd5, 0x50, 0xf6, 0xce, 0x62, 0xfb, 0x7d, 0xad, 0xa4, 0xcd, 0x13, 0x13, 0x39, 0xd3, 0x22, 0xe2,
-	0xf6, 0x59, 0xe0, 0x4f, 0xd5, 0xd4, 0xae, 0xe3, 0x94, 0x47, 0x1f, 0x43, 0x6d, 0xc2, 0xfc, 0x78,
-	0x4c, 0xb8, 0xd5, 0x54, 0x9e, 0x51, 0xd6, 0xf3, 0xb7, 0x4a, 0x84, 0x13, 0x15, 0xfb, 0x18, 0x5a,
-	0x39, 0x88, 0xb2, 0xb2, 0xce, 0xc8, 0x34, 0xa9, 0xde, 0x33, 0x32, 0x45, 0x3b, 0x50, 0x99, 0xb8,
-	0x7e, 0x4c, 0xcc, 0xf8, 0xcd, 0xfd, 0x3c, 0xc6, 0x16, 0x6b, 0x8d, 0xcf, 0x8a, 0x9f, 0x16, 0xec,
-	0xaf, 0x61, 0x25, 0x0b, 0x7a, 0x81, 0xc3, 0xed, 0xbc, 0x43, 0x34, 0x97, 0xf9, 0x80, 0x0e, 0x33,
-	0xfe, 0x1c, 0x0c, 0x55, 0x0d, 0x7a, 0x69, 0xc5, 0xb5, 0xa1, 0xd9, 0x27, 0x5c, 0xd0, 0xc0, 0x15,
-	0x94, 0x05, 0xa6, 0xee, 0xb2, 0x47, 0xb2, 0xe8, 0xa3, 0xe7, 0xa6, 0x35, 0x8a, 0xd1, 0x73, 0x67,
-	0x00, 0x55, 0x1d, 0x48, 0xb6, 0x48, 0xe8, 0x8a, 0x91, 0x81, 0xa7, 0x68, 0xf5, 0x51, 0x57, 0xd5,
-	0x60, 0x5c, 0x19, 0x2e, 0xb3, 0x9e, 0x24, 0x1f, 0x7b, 0xc5, 0xc9, 0xda, 0x96, 0x93, 0x8e, 0x04,
-	0x7a, 0x5e, 0x34, 0x70, 0xc2, 0x3a, 0x13, 0xa8, 0x99, 0x1b, 0x52, 0x81, 0x58, 0x24, 0x54, 0xa0,
-	0x12, 0x56, 0xb4, 0x74, 0xe8, 0xbb, 0x3d, 0xe2, 0x73, 0xab, 0xd8, 0x2e, 0x49, 0x87, 0x9a, 0x93,
-	0x57, 0x16, 0x47, 0x49, 0x14, 0x49, 0xa2, 0x1b, 0x50, 0xf1, 0xe4, 0xec, 0x31, 0x2d, 0x72, 0x35,
-	0x7b, 0x65, 0x5f, 0x11, 0xd7, 0x17, 0x23, 0x35, 0x9a, 0xb0, 0xd6, 0x72, 0x18, 0x34, 0x33, 0xa7,
-	0x32, 0xb6, 0x98, 0x86, 0x24, 0x49, 0x52, 0xd2, 0xb2, 0x84, 0x68, 0x20, 0x48, 0x34, 0x31, 0xdb,
-	0x56, 0x09, 0xa7, 0xbc, 0x4c, 0x48, 0xae, 0xad, 0x2c, 0x16, 0x0a, 0x43, 0x09, 0x27, 0xac, 0x44,
-	0x3c, 0x26, 0x62, 0xc4, 0xfa, 0x26, 0x53, 0xc3, 0x39, 0x07, 0x50, 0x96, 0xdd, 0x21, 0x2d, 0xfb,
-	0x44, 0xb7, 0x85, 0xdc, 0xc3, 0x4a, 0x38, 0x61, 0x91, 0x03, 0x2b, 0x9e, 0x1b, 0xba, 0x3d, 0xea,
-	0x53, 0x41, 0x49, 0x92, 0x71, 0xee, 0xcc, 0x19, 0x40, 0x23, 0xed, 0x49, 0x09, 0xda, 0x93, 0x8d,
-	0x58, 0x50, 0xdb, 0x8e, 0xa2, 0x75, 0x78, 0xb9, 0xf5, 0x18, 0xc8, 0x86, 0x93, 0x33, 0x87, 0x7b,
-	0x2c, 0x22, 0x06, 0xae, 0x66, 0xe4, 0xd7, 0x3d, 0x60, 0xcf, 0x06, 0xd4, 0xd7, 0x5f, 0xf7, 0x32,
-	0xae, 0x06, 0xec, 0x21, 0xf5, 0x89, 0xc3, 0xa0, 0xa2, 0x26, 0xc5, 0xc2, 0x8b, 0x59, 0xf6, 0xeb,
-	0xcf, 0x55, 0x59, 0xe9, 0x7c, 0x95, 0x59, 0x50, 0x63, 0xa1, 0xa4, 0xe4, 0x24, 0x93, 0xd9, 0x25,
-	0xac, 0x33, 0x85, 0x9a, 0x19, 0x64, 0x72, 0xbe, 0xc4, 0x3c, 0xfd, 0x72, 0xe6, 0xe6, 0xcb, 0x29,
-	0x27, 0x11, 0x56, 0x52, 0x09, 0xcc, 0x8d, 0x86, 0xc9, 0x2d, 0x29, 0x5a, 0x56, 0x05, 0x09, 0x26,
-	0x56, 0x49, 0x1d, 0x49, 0xf2, 0xdc, 0x9d, 0x96, 0x17, 0xdc, 0xe9, 0x47, 0x50, 0x96, 0x7e, 0x55,
-	0x4d, 0x99, 0xee, 0x69, 0x61, 0x49, 0xca, 0x93, 0x21, 0xed, 0x9b, 0x55, 0x5b, 0x92, 0xbb, 0x7f,
-	0x55, 0xa1, 0xf2, 0x60, 0x48, 0x02, 0x81, 0xee, 0x41, 0x55, 0xbf, 0x52, 0x50, 0x7e, 0x91, 0xce,
-	0xbe, 0x5c, 0xec, 0x8d, 0x73, 0x8b, 0xd8, 0xa1, 0x7c, 0x09, 0x49, 0x63, 0xfd, 0x08, 0xc9, 0x1b,
-	0xe7, 0x1e, 0x26, 0x4b, 0x8d, 0x6f, 0x43, 0xe9, 0x88, 0x08, 0xb4, 0x91, 0x1b, 0xbc, 0xe9, 0x4b,
-	0xc5, 0xbe, 0x7a, 0xee, 0x3c, 0x7d, 0x9b, 0x94, 0xe5, 0x13, 0x03, 0xe5, 0x14, 0x32, 0x8f, 0x8e,
-	0xa5, 0x01, 0xef, 0x42, 0x59, 0xbe, 0x26, 0xf2, 0x86, 0x99, 0xe7, 0x86, 0x6d, 0x9d, 0x17, 0x98,
-	0x98, 0x87, 0x50, 0x4f, 0x56, 0x3f, 0xb4, 0x99, 0xfb, 0xb2, 0xe4, 0x77, 0x47, 0xfb, 0xed, 0xc5,
-	0xc2, 0xf4, 0xfd, 0x52, 0x51, 0x8b, 0x1f, 0xca, 0x45, 0xca, 0xee, 0x82, 0x4b, 0xc1, 0xdf, 0x81,
-	0xb2, 0xdc, 0x05, 0xf3, 0xe0, 0x33, 0xdb, 0xe1, 0x52, 0xc3, 0xfb, 0x50, 0xd5, 0x7b, 0x5d, 0xfe,
-	0x37, 0xca, 0x6d, 0x8c, 0xb6, 0xbd, 0x48, 0x64, 0x40, 0x3f, 0x80, 0x46, 0xba, 0xd1, 0xa1, 0x5c,
-	0x7e, 0xf3, 0x8b, 0xde, 0xab, 0xc0, 0x4b, 0xdd, 0x3c, 0xf8, 0xcc, 0x02, 0xb8, 0xd4, 0xf0, 0x31,
-	0xc0, 0x6c, 0x13, 0x43, 0xef, 0xe4, 0x2a, 0x74, 0x7e, 0x11, 0xb4, 0xdf, 0x5d, 0x26, 0x36, 0x89,
-	0xec, 0x41, 0xcd, 0x2c, 0x62, 0xc8, 0x9e, 0xdb, 0x0e, 0x32, 0x5b, 0x9d, 0xbd, 0xb9, 0x50, 0x36,
-	0xf3, 0x61, 0x96, 0xa5, 0xbc, 0x8f, 0xfc, 0x36, 0x97, 0xf7, 0x31, 0xb7, 0x5d, 0xed, 0xed, 0x7c,
-	0x7f, 0xfd, 0x75, 0xfe, 0xab, 0xb8, 0x37, 0xb9, 0xf9, 0xdd, 0xa5, 0x5e, 0x55, 0xdd, 0xc8, 0xad,
-	0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x2f, 0x25, 0xc8, 0xb7, 0xdf, 0x10, 0x00, 0x00,
+	proto.RegisterFile("github.com/crosbymichael/boss/api/v1/boss.proto", fileDescriptor_boss_acbcc5ac7f08a73a)
+}
+
+var fileDescriptor_boss_acbcc5ac7f08a73a = []byte{
+	// 3548 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x3a, 0xd9, 0x72, 0xdc, 0xc6,
+	0x76, 0x9e, 0x7d, 0xe6, 0xcc, 0x70, 0x51, 0x8b, 0x16, 0xa1, 0x91, 0x2d, 0xd2, 0x90, 0x2c, 0x51,
+	0xb2, 0x44, 0x6a, 0x29, 0xef, 0x72, 0x64, 0x49, 0x94, 0x14, 0x7a, 0x0b, 0xab, 0x29, 0xd9, 0x2e,
+	0x27, 0x29, 0x06, 0x04, 0x7a, 0x86, 0x1d, 0x61, 0x00, 0x18, 0x0b, 0x29, 0xba, 0xf2, 0x92, 0x3c,
+	0x24, 0x8f, 0xf1, 0x5b, 0xf2, 0x09, 0xfe, 0x83, 0xfc, 0x42, 0xaa, 0x52, 0xa9, 0x54, 0x3e, 0xe0,
+	0xfa, 0x56, 0xf9, 0xed, 0x7e, 0xc5, 0xbd, 0xd5, 0x1b, 0xd0, 0x18, 0x00, 0x33, 0x94, 0xfc, 0x86,
+	0xd3, 0x7d, 0xfa, 0xf4, 0xe9, 0xee, 0xb3, 0x1f, 0xc0, 0xd6, 0x98, 0xc6, 0x87, 0xc9, 0xc1, 0xa6,
+	0xed, 0x4f, 0xb6, 0xec, 0xd0, 0x8f, 0x0e, 0x4e, 0x26, 0xd4, 0x3e, 0xb4, 0x88, 0xbb, 0x75, 0xe0,
+	0x47, 0xd1, 0x96, 0x15, 0xd0, 0xad, 0xa3, 0xdb, 0xfc, 0x7b, 0x33, 0x08, 0xfd, 0xd8, 0x47, 0x40,
+	0xfd, 0x4d, 0x0e, 0x1e, 0xdd, 0x1e, 0xae, 0x8c, 0xfd, 0xb1, 0xcf, 0x87, 0xb7, 0xd8, 0x97, 0xc0,
+	0x18, 0x5e, 0x18, 0xfb, 0xfe, 0xd8, 0x25, 0x5b, 0x1c, 0x3a, 0x48, 0x46, 0x5b, 0x64, 0x12, 0xc4,
+	0x27, 0x72, 0x72, 0x6d, 0x7a, 0x32, 0xa6, 0x13, 0x12, 0xc5, 0xd6, 0x24, 0x90, 0x08, 0x17, 0xa7,
+	0x11, 0x9c, 0x24, 0xb4, 0x62, 0xea, 0x7b, 0x62, 0xde, 0xfc, 0x3b, 0x58, 0x78, 0x14, 0x12, 0x2b,
+	0x26, 0x98, 0xfc, 0x98, 0x90, 0x28, 0x46, 0x77, 0xa1, 0x67, 0xfb, 0x5e, 0x6c, 0x51, 0x8f, 0x84,
+	0x46, 0x6d, 0xbd, 0xb6, 0xd1, 0xbf, 0xf3, 0xe6, 0x66, 0xc6, 0xe4, 0xe6, 0x23, 0x35, 0x89, 0x33,
+	0x3c, 0x74, 0x0e, 0xda, 0x49, 0xe0, 0x58, 0x31, 0x31, 0xea, 0xeb, 0xb5, 0x8d, 0x2e, 0x96, 0x90,
+	0x79, 0x15, 0x16, 0xb6, 0x89, 0x4b, 0x32, 0xea, 0xe7, 0xa0, 0x4e, 0x1d, 0x4e, 0xb6, 0xf7, 0xb0,
+	0xfd, 0xdb, 0xaf, 0x6b, 0xf5, 0x9d, 0x6d, 0x5c, 0xa7, 0x8e, 0x79, 0x19, 0xe0, 0x29, 0x89, 0xe7,
+	0x61, 0x3d, 0x81, 0x3e, 0xc7, 0x8a, 0x02, 0xdf, 0x8b, 0x08, 0xfa, 0xb0, 0xc8, 0xea, 0xf9, 0x52,
+	0x56, 0x77, 0xbc, 0x91, 0xaf, 0xb1, 0x6b, 0x7e, 0x06, 0xfd, 0x2f, 0xa9, 0xeb, 0xce, 0xd9, 0x8e,
+	0x9d, 0x2a, 0xa2, 0x63, 0xcf, 0x72, 0xf9, 0xa9, 0x16, 0xb0, 0x84, 0xcc, 0x05, 0xe8, 0x7f, 0x45,
+	0x23, 0xc5, 0xad, 0xb9, 0x03, 0x03, 0x01, 0x4a, 0xb6, 0x3e, 0x06, 0x48, 0xb7, 0x8a, 0x8c, 0xda,
+	0x7a, 0x63, 0x36, 0x5f, 0x1a, 0xb2, 0xf9, 0x4b, 0x13, 0x16, 0x72, 0xb3, 0x95, 0xbc, 0xad, 0x40,
+	0x8b, 0x4e, 0xac, 0xb1, 0xb8, 0xf0, 0x1e, 0x16, 0x00, 0xe7, 0x38, 0xb6, 0xe2, 0x24, 0x32, 0x1a,
+	0x7c, 0x58, 0x42, 0x9c, 0x4a, 0x60, 0x34, 0x35, 0x2a, 0xbb, 0xb8, 0x4e, 0x03, 0xb4, 0x0c, 0x0d,
+	0x3b, 0x48, 0x8c, 0xd6, 0x7a, 0x6d, 0xa3, 0x89, 0xd9, 0x27, 0x7a, 0x07, 0x06, 0x13, 0x32, 0xf1,
+	0xc3, 0x93, 0xfd, 0x24, 0x62, 0xe4, 0xdb, 0xeb, 0xb5, 0x8d, 0x1a, 0xee, 0x8b, 0xb1, 0xe7, 0x6c,
+	0x48, 0x43, 0x71, 0xe9, 0x84, 0xc6, 0x46, 0x47, 0x47, 0xf9, 0x8a, 0x0d, 0xa1, 0x0b, 0xd0, 0x0b,
+	0xa8, 0x23, 0x49, 0x74, 0x39, 0xf5, 0x6e, 0x40, 0x1d, 0xb1, 0x5e, 0x4e, 0x8a, 0xc5, 0xbd, 0x74,
+	0x52, 0xac, 0x5c, 0x85, 0xce, 0x28, 0xda, 0x8f, 0xe8, 0x4f, 0xc4, 0x80, 0xf5, 0xda, 0x46, 0x03,
+	0xb7, 0x47, 0xd1, 0x1e, 0xfd, 0x89, 0xa0, 0x9b, 0xd0, 0xb6, 0x7d, 0x6f, 0x44, 0xc7, 0x46, 0x7f,
+	0x96, 0x50, 0x4a, 0x24, 0x74, 0x07, 0x7a, 0x91, 0x67, 0x05, 0xd1, 0xa1, 0x1f, 0x47, 0xc6, 0x80,
+	0xbf, 0xc1, 0x8a, 0xbe, 0x62, 0x4f, 0x4e, 0xe2, 0x0c, 0x8d, 0xdd, 0xe9, 0x38, 0xf4, 0x93, 0xc0,
+	0x58, 0x10, 0x77, 0xca, 0x01, 0x74, 0x0f, 0xc0, 0x3e, 0x24, 0xf6, 0x8b, 0xc0, 0xa7, 0x5e, 0x6c,
+	0x2c, 0xf2, 0xcd, 0xdf, 0xca, 0x6d, 0x9e, 0xce, 0xee, 0xf1, 0xdb, 0xc6, 0x1a, 0x3e, 0x3a, 0x0f,
+	0x0d, 0x1a, 0x7c, 0x60, 0x2c, 0xf1, 0xab, 0xef, 0xfc, 0xf6, 0xeb, 0x5a, 0x63, 0x67, 0xf7, 0x03,
+	0xcc, 0xc6, 0x18, 0x61, 0xea, 0xc5, 0x24, 0x1c, 0x59, 0x36, 0x89, 0x8c, 0xe5, 0xf5, 0xc6, 0x34,
+	0xe1, 0x6f, 0x48, 0x7c, 0xec, 0x87, 0x2f, 0x76, 0x14, 0x12, 0xd6, 0xf0, 0xcd, 0xbf, 0x85, 0xe5,
+	0xe9, 0x79, 0x84, 0xa0, 0xe9, 0x59, 0x13, 0x22, 0xc4, 0x05, 0xf3, 0x6f, 0x64, 0x40, 0xc7, 0x13,
+	0x78, 0x52, 0x54, 0x14, 0x28, 0x58, 0x63, 0x92, 0xd2, 0xc8, 0x58, 0x8b, 0x18, 0x6b, 0x91, 0x19,
+	0xc3, 0xf2, 0xf4, 0xa9, 0x98, 0xac, 0x84, 0x64, 0x24, 0x69, 0xb3, 0x4f, 0xf4, 0x11, 0x34, 0x5d,
+	0x2b, 0x8a, 0x39, 0xdd, 0xfe, 0x9d, 0xe1, 0xa6, 0x30, 0x35, 0x9b, 0xca, 0xd4, 0x6c, 0x3e, 0x53,
+	0xb6, 0xe8, 0x61, 0xf7, 0xbf, 0x7f, 0x5d, 0x7b, 0xe3, 0xe7, 0x3f, 0xae, 0xd5, 0x30, 0x5f, 0xc1,
+	0x6e, 0x9a, 0x84, 0xa1, 0x1f, 0x4a, 0x31, 0x15, 0x80, 0xf9, 0x1f, 0x35, 0xe8, 0xaa, 0x77, 0xa9,
+	0x14, 0xfc, 0xbf, 0x82, 0x8e, 0xcd, 0x0d, 0x96, 0xf3, 0x4a, 0xfb, 0xaa, 0x45, 0x68, 0x08, 0xdd,
+	0x20, 0x24, 0x47, 0xd4, 0x4f, 0x95, 0x24, 0x85, 0x75, 0xe1, 0x6b, 0xea, 0xc2, 0x67, 0x5e, 0x83,
+	0x25, 0xec, 0xbb, 0xee, 0x81, 0x65, 0xbf, 0x98, 0x67, 0xa3, 0x9e, 0xc2, 0x72, 0x86, 0x2a, 0x2d,
+	0xc2, 0xeb, 0xd8, 0x54, 0xf3, 0x0a, 0x0c, 0xf6, 0x62, 0x2b, 0x9c, 0x6b, 0x14, 0xdf, 0x85, 0xfe,
+	0x5e, 0xec, 0x07, 0xf3, 0xd0, 0xfe, 0x1e, 0x16, 0x9e, 0x73, 0xa3, 0xfc, 0xbb, 0x0c, 0xfd, 0x2a,
+	0x74, 0x9c, 0xf0, 0x64, 0x3f, 0x4c, 0x3c, 0x65, 0xe9, 0x9d, 0xf0, 0x04, 0x27, 0x9e, 0xf9, 0x23,
+	0x2c, 0x2a, 0xf2, 0xbf, 0xe3, 0xd0, 0xe8, 0x3a, 0x34, 0x1d, 0x3a, 0x1a, 0xc9, 0xa7, 0x3d, 0xa7,
+	0xe3, 0x0b, 0xf2, 0xdb, 0x74, 0x34, 0xc2, 0x1c, 0xc7, 0xfc, 0x53, 0x0d, 0x20, 0x1b, 0x44, 0xb7,
+	0xa1, 0x63, 0x1f, 0x5a, 0xde, 0x98, 0x28, 0x9b, 0xbb, 0xaa, 0xaf, 0x7e, 0x42, 0x89, 0xeb, 0x3c,
+	0xe2, 0xf3, 0x58, 0xe1, 0x31, 0x4b, 0xc6, 0xed, 0xe6, 0xbe, 0x43, 0xc7, 0x44, 0x0a, 0x72, 0x0f,
+	0xf7, 0xf9, 0xd8, 0x36, 0x1f, 0x42, 0x1b, 0xb0, 0xec, 0x91, 0xe3, 0xfd, 0x1c, 0x9a, 0x10, 0x9b,
+	0x45, 0x8f, 0x1c, 0xef, 0x68, 0x98, 0x06, 0x74, 0x42, 0x26, 0x76, 0x61, 0xcc, 0x85, 0xa7, 0x8b,
+	0x15, 0xc8, 0xb6, 0x61, 0x34, 0x98, 0x94, 0x45, 0xd4, 0xf7, 0xb8, 0xb9, 0xed, 0xe2, 0xbe, 0x47,
+	0x8e, 0xb1, 0x1c, 0x42, 0x17, 0x01, 0x1c, 0x12, 0x92, 0x31, 0x8d, 0x62, 0x12, 0x1a, 0x6d, 0xa6,
+	0x92, 0x58, 0x1b, 0x31, 0x1f, 0x43, 0x5f, 0x3b, 0x01, 0x53, 0xf4, 0xc0, 0x8a, 0x0f, 0x95, 0xa2,
+	0xb3, 0x6f, 0xa6, 0x9f, 0xbe, 0xeb, 0xc8, 0x33, 0xb0, 0x4f, 0x36, 0xe2, 0x91, 0x63, 0xc9, 0x2e,
+	0xfb, 0x34, 0x2f, 0xc3, 0xf2, 0x6e, 0x12, 0x1d, 0x3e, 0x4c, 0xa8, 0xeb, 0x28, 0x39, 0x28, 0xe8,
+	0xb5, 0xf9, 0x3e, 0xf4, 0x19, 0x56, 0x25, 0x02, 0x53, 0xdf, 0x83, 0x84, 0xca, 0xcd, 0xba, 0x58,
+	0x00, 0xe6, 0x2f, 0x35, 0x38, 0x93, 0x59, 0x8d, 0x79, 0xce, 0x55, 0x52, 0xad, 0x67, 0x54, 0x11,
+	0x34, 0x5d, 0x7a, 0x44, 0x38, 0xbf, 0x5d, 0xcc, 0xbf, 0xd9, 0x18, 0x79, 0x49, 0xd5, 0x8d, 0xf2,
+	0x6f, 0x76, 0xd1, 0x47, 0xbe, 0x9b, 0x4c, 0x48, 0x64, 0xb4, 0xf8, 0x45, 0x29, 0x10, 0x5d, 0x85,
+	0x25, 0xf2, 0xd2, 0x76, 0x13, 0x87, 0xec, 0x2b, 0x0c, 0x71, 0x95, 0x8b, 0x72, 0xf8, 0x5b, 0x31,
+	0x6a, 0xae, 0x00, 0xd2, 0x39, 0x15, 0x12, 0x6b, 0xfe, 0x57, 0x0d, 0x16, 0x31, 0x89, 0x62, 0x3f,
+	0x24, 0xd5, 0x67, 0x57, 0x5c, 0xd6, 0x35, 0x2e, 0xc5, 0x19, 0x1b, 0x85, 0x33, 0x6a, 0xb6, 0xb7,
+	0x99, 0xb7, 0xbd, 0x77, 0xa1, 0xe7, 0x1f, 0x91, 0x30, 0xa4, 0x0e, 0x3f, 0xc5, 0x2c, 0xe5, 0x48,
+	0xf1, 0x18, 0xb9, 0x43, 0x62, 0xb9, 0xf1, 0xe1, 0x09, 0x77, 0xcb, 0x5d, 0xac, 0x40, 0x6e, 0x9f,
+	0x14, 0xe3, 0x52, 0xfd, 0x84, 0xcb, 0xaf, 0x4d, 0xbb, 0x7c, 0xf3, 0x5f, 0xeb, 0xb0, 0xf8, 0x35,
+	0x1d, 0x87, 0xd6, 0xdc, 0xa0, 0xec, 0xf4, 0x4f, 0x14, 0xc5, 0x7e, 0xa0, 0x9e, 0x88, 0x7d, 0xa3,
+	0x45, 0xa8, 0xc7, 0x3e, 0x3f, 0x57, 0x0f, 0xd7, 0x63, 0x16, 0xc5, 0xb4, 0x1d, 0x1e, 0x07, 0x4a,
+	0xc6, 0x25, 0xa4, 0x3f, 0x65, 0x67, 0xee, 0x53, 0x76, 0xcb, 0x9e, 0x92, 0x47, 0x13, 0x21, 0xd9,
+	0x77, 0x92, 0x49, 0x10, 0xf1, 0x68, 0xa2, 0xc1, 0x0d, 0xfa, 0x36, 0x83, 0x99, 0xb1, 0x17, 0x2a,
+	0x14, 0x9e, 0xf0, 0x70, 0xa2, 0x8b, 0x53, 0xd8, 0x7c, 0x00, 0x4b, 0xe9, 0x3d, 0xc8, 0x3b, 0xdb,
+	0x84, 0x56, 0x70, 0x68, 0x45, 0x44, 0x9a, 0x2b, 0x43, 0x7f, 0x11, 0x89, 0xbb, 0xcb, 0xe6, 0xb1,
+	0x40, 0x33, 0x6d, 0x18, 0xe8, 0xc3, 0xa5, 0xfe, 0xf7, 0x3e, 0x74, 0x55, 0xc8, 0x2d, 0xad, 0xda,
+	0xf9, 0x82, 0xc3, 0xda, 0x96, 0x08, 0xc2, 0x5f, 0xfd, 0x27, 0xf3, 0x57, 0xe9, 0x22, 0xf3, 0x00,
+	0x06, 0x0f, 0x12, 0x87, 0xa6, 0x0a, 0xf5, 0x09, 0xb4, 0x22, 0xea, 0xd9, 0x8a, 0xc9, 0xd3, 0xb9,
+	0x3f, 0xb1, 0x44, 0xbe, 0x74, 0xbd, 0xe0, 0x1c, 0x1e, 0xc0, 0x82, 0xdc, 0x43, 0xde, 0xc4, 0x2d,
+	0xe8, 0x10, 0x2f, 0x0e, 0x69, 0x6a, 0x4c, 0x73, 0xa6, 0x98, 0xe3, 0x3e, 0xf6, 0xe2, 0xf0, 0x04,
+	0x2b, 0x34, 0xf3, 0xdf, 0xea, 0x00, 0xd9, 0x38, 0x7a, 0x08, 0xbd, 0x34, 0x15, 0x79, 0x25, 0x4e,
+	0xb3, 0x65, 0xec, 0xf5, 0xa8, 0x43, 0xbc, 0x98, 0xc6, 0x27, 0x52, 0x08, 0x53, 0x98, 0xcb, 0x66,
+	0x60, 0x2b, 0xdb, 0x16, 0x06, 0xb6, 0x3c, 0x5b, 0xb3, 0x4c, 0x09, 0x43, 0x71, 0x75, 0x52, 0x20,
+	0x15, 0x98, 0x45, 0x21, 0x6d, 0x2d, 0x0a, 0xc9, 0x3d, 0x58, 0xe7, 0x75, 0x1e, 0xec, 0x21, 0x0c,
+	0xf6, 0x6c, 0xcb, 0x9d, 0xab, 0x5e, 0x5c, 0x38, 0x03, 0x97, 0xda, 0x56, 0xc4, 0x8f, 0xd7, 0xc0,
+	0x29, 0x6c, 0x7e, 0x0c, 0xfd, 0x2f, 0xfc, 0x83, 0x68, 0x1e, 0x09, 0xa6, 0x8f, 0xfe, 0x38, 0x4a,
+	0x8d, 0x91, 0x3f, 0x8e, 0xcc, 0xbb, 0x30, 0x10, 0x4b, 0xe5, 0x53, 0x5e, 0x82, 0xe6, 0x3f, 0xfa,
+	0x07, 0xea, 0x1d, 0x97, 0xf4, 0x77, 0xfc, 0xc2, 0x3f, 0xc0, 0x7c, 0xd2, 0xfc, 0x27, 0x68, 0x7c,
+	0xe1, 0x1f, 0xcc, 0x62, 0x35, 0xb2, 0x0f, 0x89, 0x93, 0xb8, 0x2a, 0xe1, 0x48, 0x61, 0x74, 0x1e,
+	0xba, 0x1e, 0x79, 0x19, 0xf3, 0x98, 0xa0, 0xa1, 0xac, 0xdc, 0xcb, 0x18, 0x27, 0x1e, 0xba, 0x02,
+	0xcd, 0x30, 0xf1, 0x22, 0xa3, 0xc9, 0xb7, 0x46, 0xd3, 0x5b, 0x27, 0x1e, 0xe6, 0xf3, 0xe6, 0xff,
+	0xd7, 0xa0, 0x2d, 0x06, 0x58, 0x78, 0xc7, 0x9d, 0x26, 0x71, 0x5e, 0x49, 0x6a, 0xd4, 0x22, 0xf4,
+	0x39, 0x74, 0x47, 0xd4, 0xa3, 0xd1, 0xe1, 0x2b, 0xc6, 0x87, 0xe9, 0x2a, 0x66, 0x50, 0x98, 0x9b,
+	0xd9, 0xb7, 0x7d, 0x47, 0x18, 0xba, 0x06, 0xee, 0xb2, 0x81, 0x47, 0xbe, 0x43, 0x32, 0x91, 0x69,
+	0xea, 0x22, 0xa3, 0x9e, 0x41, 0xc8, 0x97, 0x78, 0x86, 0xcb, 0x00, 0xec, 0x80, 0x73, 0xa2, 0x32,
+	0x96, 0x20, 0xfb, 0x76, 0xec, 0x87, 0x19, 0x62, 0x3b, 0x24, 0x81, 0x45, 0x45, 0xc8, 0xd4, 0xc5,
+	0x12, 0x32, 0x1f, 0xc0, 0xa2, 0x42, 0x94, 0xef, 0xba, 0xc5, 0x4f, 0xea, 0x50, 0x6f, 0xac, 0xde,
+	0xf6, 0x6c, 0x3e, 0xe0, 0xe1, 0x73, 0x38, 0x45, 0x32, 0xff, 0xb9, 0x06, 0x1d, 0x39, 0x3a, 0x4b,
+	0xa0, 0x5e, 0x50, 0x4f, 0x45, 0x11, 0xfc, 0x9b, 0x29, 0xd0, 0x84, 0x44, 0x3c, 0x95, 0x93, 0xef,
+	0x2b, 0x41, 0x29, 0xc1, 0x16, 0x0d, 0x89, 0x23, 0xcd, 0x7f, 0x0a, 0x67, 0x37, 0xd5, 0xd2, 0x43,
+	0xfc, 0xff, 0xe9, 0x40, 0xef, 0x91, 0x56, 0x36, 0x78, 0x95, 0xe4, 0x56, 0xf3, 0xa6, 0x8d, 0xbc,
+	0x37, 0xbd, 0x09, 0x9d, 0x20, 0xf4, 0x6d, 0x12, 0x45, 0x9c, 0x8d, 0xa9, 0x9b, 0xd8, 0x15, 0x53,
+	0x58, 0xe1, 0xa0, 0x6b, 0xd0, 0x9e, 0xf8, 0x89, 0x17, 0x8b, 0xf8, 0xa1, 0x7f, 0xe7, 0x4c, 0xce,
+	0xce, 0xb3, 0x19, 0x2c, 0x11, 0x98, 0x9f, 0x0e, 0x49, 0xe4, 0x27, 0xa1, 0xcd, 0x63, 0x89, 0x82,
+	0x9f, 0xc6, 0x6a, 0x12, 0x67, 0x78, 0xe8, 0x32, 0x34, 0xc7, 0x41, 0x12, 0x49, 0xeb, 0xb1, 0xac,
+	0xe3, 0x3f, 0xdd, 0x7d, 0x1e, 0x61, 0x3e, 0xcb, 0xec, 0x4c, 0x44, 0xc2, 0x23, 0x6a, 0x4b, 0xd7,
+	0xd6, 0xbf, 0x73, 0xa9, 0x34, 0x02, 0xd8, 0xdc, 0x93, 0x58, 0xc2, 0xe0, 0xa6, 0x8b, 0xd0, 0x3d,
+	0xe8, 0x88, 0x64, 0x97, 0xf9, 0x3d, 0xb6, 0xde, 0x2c, 0x5f, 0xff, 0x48, 0x20, 0x49, 0x7b, 0x2d,
+	0x97, 0x88, 0xb7, 0xb3, 0x1c, 0xdf, 0x73, 0x35, 0xd7, 0x28, 0x60, 0x74, 0x23, 0x73, 0xcb, 0xfd,
+	0xa2, 0xea, 0x0a, 0xcf, 0x9b, 0xb9, 0xea, 0x5b, 0x69, 0xf1, 0x67, 0x50, 0x74, 0x9b, 0x22, 0x40,
+	0xdf, 0xf5, 0x5d, 0x6a, 0x9f, 0xa8, 0xb2, 0x50, 0xce, 0xf2, 0x2d, 0xe4, 0x2d, 0x5f, 0x2a, 0x81,
+	0x8b, 0x9a, 0x04, 0xea, 0xe6, 0x67, 0x69, 0xca, 0xfc, 0x5c, 0x81, 0x26, 0xf5, 0x68, 0x6c, 0x2c,
+	0x17, 0x19, 0xfd, 0x6b, 0xe2, 0x06, 0x24, 0xc4, 0x7c, 0x1e, 0x6d, 0x42, 0x37, 0xa2, 0x0e, 0xb1,
+	0xad, 0x30, 0x32, 0xce, 0x54, 0xe2, 0xa6, 0x38, 0x53, 0x69, 0x3f, 0x9a, 0x95, 0xf6, 0xcb, 0xd3,
+	0x69, 0xf8, 0x8c, 0x63, 0x29, 0x9c, 0x91, 0x71, 0x96, 0xc7, 0x2d, 0x29, 0x8c, 0xae, 0x40, 0x2b,
+	0xf0, 0xc3, 0x38, 0x32, 0x56, 0xd6, 0x1b, 0xd3, 0xf2, 0xb1, 0xeb, 0x87, 0x31, 0x16, 0xd3, 0xc3,
+	0x5d, 0x58, 0xc8, 0x3d, 0x3d, 0xf3, 0x79, 0x2f, 0xc8, 0x89, 0x0a, 0x46, 0x5f, 0x90, 0x13, 0x74,
+	0x0d, 0x5a, 0x47, 0x96, 0x9b, 0x10, 0xa3, 0x5e, 0x14, 0x7b, 0xb9, 0x16, 0x0b, 0x8c, 0x4f, 0xea,
+	0x1f, 0xd5, 0x86, 0xdf, 0xc0, 0x40, 0x17, 0x86, 0x12, 0x82, 0x1b, 0x79, 0x82, 0x68, 0x4a, 0xa2,
+	0x46, 0x74, 0xac, 0xd1, 0x33, 0x9f, 0x41, 0x93, 0x31, 0xcc, 0xde, 0xec, 0xd0, 0x8f, 0x62, 0x4e,
+	0xa8, 0x81, 0xf9, 0x37, 0x7a, 0x4b, 0x4f, 0xff, 0x84, 0x7b, 0xcb, 0x06, 0x44, 0x16, 0xee, 0xc7,
+	0xbe, 0xed, 0xbb, 0x59, 0x16, 0x2e, 0x60, 0xd3, 0x83, 0xe5, 0xe9, 0xbb, 0x65, 0xf8, 0xbc, 0xf6,
+	0x71, 0x64, 0xb9, 0x72, 0x97, 0x14, 0xae, 0x88, 0xc8, 0xdb, 0x21, 0x61, 0x7b, 0x49, 0x0b, 0x2e,
+	0x21, 0x86, 0x1b, 0x24, 0xd1, 0xa1, 0x34, 0xdf, 0xfc, 0xdb, 0xfc, 0xf7, 0x1a, 0xb4, 0xc5, 0xf3,
+	0x97, 0x06, 0x70, 0xe5, 0xc6, 0x48, 0x33, 0x39, 0x8d, 0x53, 0x98, 0x1c, 0x4d, 0xa3, 0x9a, 0x73,
+	0x35, 0xca, 0x1c, 0xc3, 0x40, 0xd7, 0x1b, 0x2e, 0xff, 0x31, 0x0b, 0x33, 0xc7, 0xea, 0xb1, 0x52,
+	0x18, 0xbd, 0x0b, 0x8b, 0x22, 0x0b, 0xd8, 0x67, 0x81, 0x93, 0x9f, 0xc4, 0xf2, 0xb2, 0x17, 0xc4,
+	0xe8, 0x33, 0x31, 0xc8, 0x4e, 0xe1, 0x84, 0xd9, 0x7d, 0x08, 0xc0, 0xc4, 0xd0, 0x16, 0x7b, 0x57,
+	0x9a, 0xe2, 0x75, 0xe8, 0x3b, 0x24, 0x8a, 0xa9, 0x97, 0x45, 0xb0, 0x3d, 0xac, 0x0f, 0xb1, 0x58,
+	0x3f, 0x3c, 0x96, 0x19, 0x41, 0x3d, 0x3c, 0x36, 0x47, 0xd0, 0x16, 0x92, 0x52, 0x9a, 0xa5, 0xb2,
+	0x0a, 0x25, 0x37, 0x93, 0x92, 0x94, 0x84, 0xb4, 0x5a, 0xab, 0xaa, 0x5c, 0x72, 0x88, 0x19, 0x7d,
+	0x26, 0x35, 0xc4, 0x8b, 0x55, 0x0a, 0x25, 0x41, 0xf3, 0xe7, 0x1a, 0x74, 0xa4, 0x8c, 0xf3, 0x9d,
+	0xfc, 0x30, 0x15, 0x40, 0xf6, 0xcd, 0x28, 0xba, 0xd6, 0x01, 0x71, 0x59, 0x74, 0xc4, 0x14, 0x50,
+	0x42, 0x4c, 0xe8, 0x93, 0x50, 0x6d, 0xc3, 0x3e, 0xd1, 0x4d, 0x68, 0x71, 0xd5, 0x95, 0xce, 0x63,
+	0x35, 0x6f, 0x17, 0xd8, 0x2d, 0x72, 0x79, 0xc4, 0x02, 0x4b, 0xf7, 0x43, 0xad, 0x9c, 0x1f, 0x32,
+	0x7d, 0xe8, 0x6b, 0xf8, 0x8c, 0xab, 0xf8, 0x24, 0x48, 0xa5, 0x89, 0x7d, 0xe7, 0x04, 0xb9, 0x3e,
+	0x25, 0xc8, 0x06, 0x74, 0xd4, 0x1b, 0x8a, 0x57, 0x52, 0x20, 0x3b, 0xcb, 0x84, 0xc4, 0x87, 0xbe,
+	0x8c, 0x6f, 0xb1, 0x84, 0xcc, 0x6d, 0x68, 0x32, 0x8f, 0xc2, 0x56, 0x3a, 0x44, 0xb8, 0x12, 0x16,
+	0x0a, 0x34, 0xb0, 0x02, 0x91, 0x09, 0x03, 0xdb, 0x0a, 0xac, 0x03, 0xea, 0xd2, 0x98, 0x12, 0x75,
+	0x17, 0xb9, 0x31, 0x73, 0x04, 0xbd, 0xd4, 0x8f, 0x31, 0xa6, 0x6d, 0xe6, 0xbc, 0x6a, 0xbc, 0xaa,
+	0xcb, 0xbf, 0xc5, 0xf6, 0xac, 0xba, 0x2b, 0x59, 0x96, 0x10, 0x13, 0xaa, 0xc8, 0xf6, 0x43, 0x15,
+	0x26, 0x09, 0x80, 0xd5, 0x88, 0x3c, 0x7f, 0x7f, 0x44, 0x5d, 0x51, 0x45, 0x6b, 0xe2, 0xb6, 0xe7,
+	0x3f, 0xa1, 0x2e, 0x31, 0x7d, 0x68, 0x71, 0xef, 0x5a, 0x7a, 0x31, 0x55, 0x82, 0x31, 0x25, 0x80,
+	0x8d, 0xa2, 0x00, 0x1a, 0xd0, 0xf1, 0x03, 0xf6, 0x25, 0x74, 0xab, 0x87, 0x15, 0x68, 0x9e, 0x40,
+	0x47, 0x6a, 0x22, 0xf3, 0xc9, 0x49, 0x94, 0x16, 0xa2, 0x72, 0x36, 0xf7, 0x79, 0xc4, 0x9c, 0x04,
+	0x9b, 0x65, 0x8c, 0x59, 0xe1, 0x58, 0xdd, 0x12, 0xff, 0x66, 0xf2, 0x42, 0xbc, 0x23, 0x51, 0x26,
+	0xc5, 0xec, 0xb3, 0x70, 0xa7, 0xcd, 0x92, 0x3b, 0xbd, 0x0e, 0x4d, 0x46, 0x97, 0x4b, 0x9b, 0x54,
+	0xac, 0x05, 0xcc, 0x3e, 0xd9, 0xc8, 0x58, 0x26, 0x61, 0x0b, 0x98, 0x7d, 0x9a, 0x18, 0x5a, 0x4f,
+	0x79, 0xa9, 0xb9, 0x4a, 0x09, 0x6f, 0xb3, 0x08, 0x6c, 0x72, 0x40, 0x42, 0xc1, 0xd9, 0x94, 0x88,
+	0xf2, 0xb5, 0x5f, 0xf3, 0x79, 0xac, 0xf0, 0xcc, 0xef, 0xa1, 0xaf, 0x8d, 0xbf, 0x5e, 0x31, 0x6e,
+	0x05, 0x5a, 0xd6, 0x28, 0xe6, 0xe6, 0x9b, 0x1d, 0x50, 0x00, 0xe6, 0x67, 0x80, 0x44, 0xc7, 0x88,
+	0xd3, 0x57, 0x71, 0xeb, 0x55, 0x55, 0x3b, 0x17, 0xc4, 0xcf, 0x14, 0x18, 0x94, 0xe5, 0x74, 0xf3,
+	0x5b, 0x40, 0xc2, 0xb6, 0xbd, 0xd6, 0xf2, 0xea, 0x02, 0xe4, 0x1f, 0x6a, 0x70, 0x36, 0x47, 0x58,
+	0x86, 0xc9, 0x9f, 0x43, 0x8b, 0x55, 0x0b, 0x55, 0x8c, 0x7c, 0xbd, 0x18, 0x9c, 0xe4, 0xf0, 0x37,
+	0x59, 0x2d, 0x51, 0xc6, 0x4a, 0x62, 0x21, 0x37, 0x41, 0x69, 0xc5, 0x99, 0xcb, 0x97, 0x04, 0x85,
+	0xda, 0xb9, 0x84, 0xcd, 0x08, 0xf1, 0x50, 0xe0, 0x70, 0x17, 0x20, 0x23, 0x54, 0xe2, 0x67, 0x6f,
+	0xe4, 0xfd, 0x6c, 0x55, 0xa1, 0x53, 0xf3, 0xb5, 0x37, 0x00, 0x89, 0x56, 0x5a, 0xee, 0xde, 0xaa,
+	0xf2, 0x8a, 0x1b, 0x80, 0x38, 0x9e, 0xec, 0x48, 0xcc, 0xed, 0xab, 0x9d, 0xcd, 0x61, 0xa7, 0x19,
+	0x46, 0x9b, 0x5f, 0x7a, 0x69, 0x41, 0x55, 0x5f, 0x20, 0xd1, 0xcc, 0x9f, 0xa0, 0xaf, 0x0d, 0x57,
+	0x8a, 0x73, 0xbe, 0x41, 0x56, 0x7f, 0x85, 0x06, 0x19, 0xcf, 0x45, 0x68, 0x14, 0x51, 0x6f, 0xac,
+	0x6e, 0x5c, 0x82, 0xe6, 0x18, 0xce, 0x0a, 0xb1, 0x94, 0xce, 0x74, 0x4e, 0xe6, 0xbc, 0x02, 0xad,
+	0x1f, 0x13, 0x3f, 0xb6, 0xa4, 0x45, 0x13, 0x80, 0xd2, 0xd6, 0x46, 0x41, 0x5b, 0x9b, 0x99, 0xb6,
+	0xae, 0x00, 0x62, 0xed, 0x3e, 0xb1, 0x8d, 0xba, 0x5a, 0xf3, 0x29, 0x9c, 0xcd, 0x8d, 0x66, 0x75,
+	0x14, 0xe5, 0xf7, 0x4b, 0xea, 0x28, 0x02, 0x9b, 0x1f, 0x32, 0xf5, 0xfd, 0xff, 0x5b, 0x03, 0xc8,
+	0xc6, 0x67, 0x25, 0x6a, 0xbc, 0x4f, 0x21, 0xd8, 0xe7, 0xdf, 0xd9, 0x99, 0x1a, 0xfa, 0x99, 0xde,
+	0x06, 0xe0, 0x1f, 0xfb, 0xdc, 0xe4, 0x0a, 0xff, 0xd1, 0xe3, 0x23, 0xcf, 0x98, 0xdd, 0xbd, 0x98,
+	0x7b, 0x0c, 0x51, 0x50, 0xd5, 0x6f, 0x5c, 0xeb, 0xb7, 0xb4, 0x5f, 0xa3, 0xdf, 0x62, 0xde, 0x84,
+	0xb3, 0x42, 0x6e, 0x4f, 0xf5, 0x2e, 0xe6, 0x03, 0x78, 0x53, 0xb5, 0x80, 0x4e, 0xf7, 0x90, 0x85,
+	0x22, 0xa5, 0xb9, 0x0d, 0xe7, 0xa6, 0x49, 0xa4, 0x35, 0xd1, 0xb6, 0x2c, 0xe1, 0x0b, 0x55, 0x94,
+	0x50, 0xd9, 0x65, 0x32, 0xbe, 0x1f, 0xbf, 0x64, 0x81, 0xc4, 0xe9, 0xf8, 0xbe, 0x0e, 0x2b, 0x79,
+	0x74, 0xb9, 0x25, 0x82, 0xa6, 0x63, 0xc5, 0x16, 0x5f, 0x31, 0xc0, 0xfc, 0xdb, 0xfc, 0x17, 0x5e,
+	0x67, 0xb6, 0x09, 0x3d, 0x22, 0x33, 0x6b, 0xec, 0xd4, 0x73, 0xc8, 0x4b, 0xce, 0xd4, 0x00, 0x0b,
+	0x40, 0x3b, 0x41, 0x63, 0xfa, 0x04, 0x7c, 0x9b, 0x66, 0xb6, 0x0d, 0x8b, 0xc0, 0x27, 0xbc, 0x3a,
+	0xa9, 0x7a, 0x0e, 0x3d, 0x9c, 0x0d, 0x98, 0xef, 0xc1, 0x52, 0xca, 0x83, 0xe4, 0x55, 0x53, 0xae,
+	0x5a, 0x5e, 0xb9, 0x6e, 0xc1, 0x39, 0x26, 0xdd, 0x59, 0x58, 0x3e, 0xd7, 0xa4, 0x7c, 0x07, 0xab,
+	0x85, 0x15, 0x72, 0x9b, 0x7b, 0xd0, 0xcf, 0x32, 0x25, 0xa5, 0x17, 0xc3, 0xf2, 0xd4, 0x8a, 0xeb,
+	0x86, 0x8e, 0x6e, 0xfe, 0x5f, 0x1d, 0x16, 0xf3, 0xf3, 0x25, 0x97, 0x57, 0x51, 0xe7, 0xe4, 0xf1,
+	0xbd, 0x2a, 0xeb, 0xb0, 0xf8, 0x9e, 0x95, 0x74, 0x34, 0x01, 0x6f, 0xbe, 0x4e, 0x43, 0x51, 0x09,
+	0x4f, 0x4b, 0xd3, 0xc4, 0x55, 0xe8, 0x84, 0xc7, 0xa2, 0x91, 0xd8, 0x96, 0xf9, 0xc7, 0x31, 0xef,
+	0x62, 0xab, 0x5c, 0xa5, 0xa3, 0xe5, 0x2a, 0xb9, 0x0a, 0x76, 0x77, 0xaa, 0x82, 0xad, 0x55, 0xc8,
+	0x7b, 0xf9, 0x0a, 0x79, 0xd6, 0x10, 0x87, 0xd3, 0x34, 0xc4, 0x19, 0x21, 0x12, 0xf2, 0xfe, 0x53,
+	0x5f, 0x04, 0x97, 0x12, 0x34, 0x6f, 0x80, 0xb1, 0xe3, 0x45, 0x01, 0xb1, 0xe3, 0x62, 0xf7, 0xa6,
+	0xd8, 0x1c, 0xfa, 0x0e, 0xce, 0x97, 0x60, 0xcb, 0xa7, 0xfd, 0x24, 0x97, 0x34, 0xab, 0x02, 0x5e,
+	0xf5, 0xcb, 0x6a, 0xd8, 0xe6, 0x7b, 0xb0, 0x2a, 0x0c, 0xc5, 0x69, 0xb8, 0x78, 0x0f, 0x56, 0x85,
+	0xba, 0x9d, 0x06, 0x79, 0x13, 0x8c, 0x22, 0xf2, 0x0c, 0xfd, 0xbc, 0x0f, 0xab, 0x3b, 0x93, 0x53,
+	0x12, 0x4f, 0x09, 0xd4, 0x35, 0x02, 0x9b, 0x60, 0xec, 0x4c, 0xaa, 0x37, 0x0c, 0x89, 0x0c, 0x47,
+	0x7a, 0x98, 0x7f, 0xdf, 0xf9, 0x33, 0x82, 0xd6, 0x83, 0x31, 0xf1, 0x62, 0xf4, 0x29, 0xb4, 0x85,
+	0x17, 0x43, 0x79, 0x87, 0xa8, 0xff, 0xa2, 0x33, 0x3c, 0x57, 0x10, 0xd0, 0xc7, 0xec, 0x97, 0x20,
+	0xb6, 0x58, 0xdc, 0x60, 0x7e, 0x71, 0xee, 0x0f, 0x9c, 0xca, 0xc5, 0x1f, 0x40, 0xe3, 0x29, 0x89,
+	0x51, 0xce, 0x3f, 0x65, 0xbf, 0xe4, 0x0c, 0x57, 0x0b, 0xe3, 0xe9, 0x4f, 0x38, 0x4d, 0xf6, 0x2f,
+	0x0d, 0xca, 0x21, 0x68, 0x7f, 0xd7, 0x54, 0x6e, 0xf8, 0x31, 0x34, 0x99, 0x85, 0xc8, 0x2f, 0xd4,
+	0xfe, 0xab, 0x19, 0x1a, 0xc5, 0x09, 0xb9, 0xe7, 0x63, 0xe8, 0xaa, 0x1e, 0x3b, 0xba, 0xa0, 0x63,
+	0x4d, 0x35, 0xe9, 0x87, 0x6f, 0x95, 0x4f, 0xa6, 0x3f, 0xea, 0xb4, 0x78, 0x87, 0x1d, 0xe5, 0x76,
+	0xd2, 0x9b, 0xee, 0x95, 0xcc, 0x7f, 0x08, 0x4d, 0xd6, 0x74, 0xcf, 0x33, 0xaf, 0xb5, 0xe1, 0x2b,
+	0x17, 0xde, 0x87, 0xb6, 0x88, 0xef, 0xf2, 0x6f, 0x94, 0x6b, 0xcd, 0x0f, 0x87, 0x65, 0x53, 0x92,
+	0xe9, 0x07, 0xd0, 0x4b, 0x5b, 0xb8, 0x28, 0x77, 0xbe, 0xe9, 0xce, 0xee, 0x2c, 0xe6, 0x19, 0x6e,
+	0x9e, 0x79, 0xad, 0xe3, 0x5b, 0xb9, 0xf0, 0x4b, 0x80, 0x4c, 0xa2, 0xd1, 0xdb, 0xe5, 0x8a, 0xad,
+	0x88, 0x5c, 0xac, 0x9a, 0x96, 0x07, 0x79, 0x08, 0x1d, 0xd9, 0xb3, 0x44, 0xc3, 0xa9, 0x92, 0xaa,
+	0xd6, 0x81, 0x1d, 0x5e, 0x28, 0x9d, 0x93, 0x34, 0xb6, 0xa1, 0x23, 0x1b, 0x70, 0x79, 0x1a, 0xf9,
+	0x06, 0xe7, 0xf0, 0x42, 0xe9, 0x9c, 0xa0, 0x71, 0xab, 0x86, 0xee, 0x41, 0x8b, 0x77, 0xae, 0xf2,
+	0x72, 0xa0, 0x37, 0xdd, 0x86, 0xe7, 0x4b, 0x66, 0x24, 0x0f, 0xf7, 0xa1, 0x2d, 0x2a, 0xf3, 0x53,
+	0x5a, 0xa7, 0x97, 0xf5, 0x87, 0xc3, 0xb2, 0x29, 0x4d, 0x0c, 0x59, 0xbf, 0x68, 0x4a, 0x0c, 0xb5,
+	0x16, 0xd2, 0x2c, 0x1d, 0x62, 0xbd, 0x9e, 0xfc, 0x4b, 0x6a, 0x8d, 0xa3, 0xa1, 0x51, 0x9c, 0x90,
+	0xbb, 0xbe, 0x0f, 0x0d, 0xd6, 0x6f, 0xc9, 0xe9, 0x7b, 0xd6, 0xb0, 0xa8, 0xdc, 0xf1, 0x31, 0xf4,
+	0xb5, 0xec, 0x0f, 0x5d, 0x2c, 0x5a, 0x29, 0x3d, 0x3f, 0xa9, 0x24, 0xf3, 0x0d, 0xf4, 0xb5, 0xe4,
+	0x2b, 0x4f, 0xa6, 0x98, 0x1e, 0x0e, 0xd7, 0xe6, 0x64, 0x6d, 0x8c, 0x2d, 0x2d, 0x3b, 0xca, 0xd3,
+	0x2b, 0xa6, 0x4d, 0xb3, 0xd8, 0xd2, 0x13, 0x98, 0x8b, 0x55, 0x09, 0x4f, 0x19, 0x5b, 0x65, 0x19,
+	0xd4, 0x53, 0x18, 0xe8, 0x49, 0x09, 0x5a, 0x2b, 0x5e, 0x57, 0x2e, 0xbc, 0x9c, 0xc5, 0x98, 0x96,
+	0x5e, 0xe4, 0x19, 0x2b, 0x66, 0x23, 0xc3, 0xb5, 0xca, 0xf9, 0x8c, 0x31, 0x3d, 0x2a, 0xcf, 0x33,
+	0x56, 0x12, 0xaf, 0x57, 0x32, 0xf6, 0x1d, 0x2c, 0xe6, 0x83, 0x6d, 0xf4, 0x4e, 0xd9, 0x6f, 0x76,
+	0x79, 0x62, 0xe6, 0x2c, 0x14, 0xc9, 0xe1, 0x1e, 0x0c, 0xf4, 0x80, 0x3a, 0xcf, 0x61, 0x49, 0x64,
+	0x3e, 0x5c, 0xaf, 0x46, 0x48, 0x35, 0xfd, 0x09, 0x74, 0x64, 0xd0, 0x3b, 0x6d, 0x73, 0xf4, 0x68,
+	0x7c, 0x78, 0xa1, 0x74, 0x4e, 0x50, 0xd9, 0xa8, 0xdd, 0xaa, 0xa1, 0x1f, 0x60, 0x69, 0x2a, 0xba,
+	0x45, 0xe6, 0xf4, 0x95, 0x17, 0x83, 0xe5, 0xe1, 0xa5, 0x99, 0x38, 0xf2, 0xe0, 0xff, 0x00, 0x67,
+	0x0a, 0x01, 0x16, 0xba, 0xac, 0xaf, 0xac, 0x8a, 0xd6, 0x86, 0xef, 0xce, 0xc1, 0x92, 0x3b, 0xfc,
+	0x0d, 0x2c, 0x4f, 0x47, 0x5a, 0xe8, 0x52, 0x51, 0x00, 0x8a, 0xf4, 0xab, 0x84, 0x60, 0x1f, 0x96,
+	0xa7, 0x03, 0xac, 0x3c, 0xc1, 0x8a, 0x58, 0x6d, 0x78, 0x79, 0x36, 0x52, 0xfa, 0x6e, 0xfb, 0xb0,
+	0xbc, 0x33, 0x99, 0xb5, 0xc1, 0xce, 0xe4, 0x14, 0x1b, 0x54, 0xc5, 0x64, 0x1b, 0xb5, 0x87, 0xd7,
+	0x7e, 0xb8, 0x7a, 0x9a, 0x3f, 0xb7, 0x3f, 0x3d, 0xba, 0xfd, 0xfd, 0x1b, 0x07, 0x6d, 0x7e, 0xfc,
+	0xbb, 0x7f, 0x19, 0x00, 0x1d, 0x19, 0x82, 0x26, 0xed, 0x2d, 0x00, 0x00,
 }
@@ -9,28 +9,54 @@ import (
 
 const (
 	Root             = "/var/lib/boss"
-	state            = "/run/boss"
+	State            = "/run/boss"
 	DefaultRuntime   = "io.containerd.runc.v1"
 	DefaultNamespace = "boss"
+	DefaultSocket    = "/run/boss/agent.sock"
+	DefaultAddress   = "0.0.0.0:1337"
+
+	// KindService is a long running container, the default kind
+	KindService = "service"
+	// KindJob is a container that runs to completion on demand or on a schedule
+	KindJob = "job"
 )
 
 func StatePath(id string) string {
-	return filepath.Join(state, id)
+	return filepath.Join(State, id)
 }
 
 // Register is an object that registers and manages service information in its backend
 type Register interface {
-	Register(id, name, ip string, s *Service) error
+	// Register the service at each of the container's ips, ipv4 first
+	Register(id, name string, ips []string, s *Service) error
 	Deregister(id, name string) error
 	EnableMaintainance(id, name, msg string) error
 	DisableMaintainance(id, name string) error
+	// Services returns the names of the registered services for each container id
+	Services() (map[string][]string, error)
 }
 
 type Network interface {
-	Create(context.Context, containerd.Container) (string, error)
+	// Create returns the container's interfaces, the first on its primary network,
+	// with their ips ipv4 first
+	Create(context.Context, containerd.Container) ([]*NetworkInterface, error)
 	Remove(context.Context, containerd.Container) error
 }
 
+// IsCNI returns true if the network is created by cni in the container's own network namespace
+func IsCNI(network string) bool {
+	switch network {
+	case "", "none", "host":
+		return false
+	}
+	return true
+}
+
+// IsJob returns true if the container runs as a job
+func IsJob(c *Container) bool {
+	return c.Kind == KindJob
+}
+
 func NetworkPath(id string) string {
 	return filepath.Join(StatePath(id), "net")
 }
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/urfave/cli"
+)
+
+var auditCommand = cli.Command{
+	Name:  "audit",
+	Usage: "show the audit log of mutating agent operations",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "since",
+			Usage: "show entries since a duration ago or an RFC3339 timestamp",
+			Value: "24h",
+		},
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "only show entries for a container",
+		},
+		cli.BoolFlag{
+			Name:  "requests",
+			Usage: "include the redacted request",
+		},
+	},
+	Action: func(clix *cli.Context) error {
+		since, err := parseSince(clix.String("since"))
+		if err != nil {
+			return err
+		}
+		ctx := Context()
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.Audit(ctx, &v1.AuditRequest{
+			Since: since,
+			ID:    clix.String("id"),
+		})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+		const tfmt = "%s\t%s\t%s\t%s\t%s\t%s\n"
+		fmt.Fprint(w, "TIME\tIDENTITY\tRPC\tID\tDURATION\tRESULT\n")
+		for _, e := range resp.Entries {
+			result := "ok"
+			if e.Error != "" {
+				result = e.Error
+			}
+			fmt.Fprintf(w, tfmt,
+				e.Timestamp.Local().Format(time.RFC3339),
+				e.Identity,
+				e.Rpc,
+				e.ID,
+				e.Duration,
+				result,
+			)
+			if clix.Bool("requests") && e.Request != "" {
+				fmt.Fprintf(w, "\t%s\n", e.Request)
+			}
+		}
+		return w.Flush()
+	},
+}
+
+func parseSince(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
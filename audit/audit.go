@@ -0,0 +1,314 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/gogo/protobuf/proto"
+	"github.com/sirupsen/logrus"
+)
+
+const redacted = "<redacted>"
+
+// mutating rpcs are recorded in the audit log, exports are included as they copy
+// container data off the agent
+var mutating = map[string]bool{
+	"Create":    true,
+	"Delete":    true,
+	"Kill":      true,
+	"Rollback":  true,
+	"Start":     true,
+	"Stop":      true,
+	"Update":    true,
+	"PushBuild": true,
+	"Push":      true,
+	"Scale":     true,
+	"Run":       true,
+	"Doctor":    true,
+
+	"Checkpoint": true,
+	"Restore":    true,
+	"Migrate":    true,
+	"Receive":    true,
+
+	"CreateGroup": true,
+	"UpdateGroup": true,
+	"DeleteGroup": true,
+
+	"CreateVolume":   true,
+	"DeleteVolume":   true,
+	"SnapshotVolume": true,
+	"ExportVolume":   true,
+
+	"DeleteCheckpoint": true,
+	"ExportCheckpoint": true,
+	"ImportCheckpoint": true,
+}
+
+// Mutating returns true if the rpc changes state on the agent
+func Mutating(rpc string) bool {
+	return mutating[rpc]
+}
+
+// Entry is a single record of an agent operation
+type Entry struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Identity  string          `json:"identity"`
+	RPC       string          `json:"rpc"`
+	ID        string          `json:"id,omitempty"`
+	Request   json.RawMessage `json:"request,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Duration  time.Duration   `json:"duration"`
+}
+
+// Proto returns the entry as an api type
+func (e *Entry) Proto() *v1.AuditEntry {
+	return &v1.AuditEntry{
+		Timestamp: e.Timestamp,
+		Identity:  e.Identity,
+		Rpc:       e.RPC,
+		ID:        e.ID,
+		Request:   string(e.Request),
+		Error:     e.Error,
+		Duration:  e.Duration,
+	}
+}
+
+// Open the audit log at the path, rotating files once they reach maxSize bytes
+// and keeping maxFiles rotated files
+func Open(path string, maxSize int64, maxFiles int) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	l := &Log{
+		path:     path,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Log is an append only json lines log of agent operations
+type Log struct {
+	mu       sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	f        *os.File
+	size     int64
+}
+
+// Write appends the entry to the log
+func (l *Log) Write(e *Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.maxSize > 0 && l.size+int64(len(data)) > l.maxSize && l.size > 0 {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.f.Write(data)
+	l.size += int64(n)
+	if err != nil {
+		return err
+	}
+	return l.f.Sync()
+}
+
+// Read calls fn for every entry at or after since, oldest first.
+// The files are opened under the lock and read after it is released so that writes are not blocked,
+// rotation only renames the open files and the current file is read up to its size when opened
+func (l *Log) Read(since time.Time, fn func(*Entry) error) error {
+	files, err := l.openFiles()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	for _, f := range files {
+		if err := readFile(f, since, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// logFile is an audit log file limited to the size it had when it was opened
+type logFile struct {
+	*os.File
+	r io.Reader
+}
+
+func (l *Log) openFiles() (files []*logFile, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	defer func() {
+		if err != nil {
+			for _, f := range files {
+				f.Close()
+			}
+		}
+	}()
+	for i := l.maxFiles; i >= 0; i-- {
+		f, err := os.Open(l.name(i))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return files, err
+		}
+		lf := &logFile{
+			File: f,
+			r:    f,
+		}
+		if i == 0 {
+			lf.r = io.LimitReader(f, l.size)
+		}
+		files = append(files, lf)
+	}
+	return files, nil
+}
+
+// Close the log
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Close()
+}
+
+func (l *Log) open() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.f = f
+	l.size = info.Size()
+	return nil
+}
+
+func (l *Log) rotate() error {
+	if err := l.f.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(l.name(l.maxFiles)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := l.maxFiles - 1; i >= 0; i-- {
+		if err := os.Rename(l.name(i), l.name(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return l.open()
+}
+
+func (l *Log) name(i int) string {
+	if i == 0 {
+		return l.path
+	}
+	return fmt.Sprintf("%s.%d", l.path, i)
+}
+
+func readFile(f *logFile, since time.Time, fn func(*Entry) error) error {
+	s := bufio.NewScanner(f.r)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for s.Scan() {
+		var e Entry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			// a partial write from a crash should not hide the rest of the log
+			logrus.WithError(err).Warnf("invalid audit entry in %s", f.Name())
+			continue
+		}
+		if e.Timestamp.Before(since) {
+			continue
+		}
+		if err := fn(&e); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+// Summary returns the request as json with secrets redacted and transferred content left out
+func Summary(req interface{}) json.RawMessage {
+	if req == nil {
+		return nil
+	}
+	if m, ok := req.(proto.Message); ok {
+		m = proto.Clone(m)
+		redact(m)
+		req = m
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func redact(m proto.Message) {
+	switch r := m.(type) {
+	case *v1.ReceiveRequest:
+		// the index and blobs are content, the ref and digest are kept
+		r.Index, r.Data = nil, nil
+	case interface{ GetContainer() *v1.Container }:
+		redactContainer(r.GetContainer())
+	case *v1.RestoreRequest:
+		redactContainer(r.Overrides)
+	case interface{ GetGroup() *v1.Group }:
+		for _, member := range r.GetGroup().GetMembers() {
+			redactContainer(member.Container)
+		}
+	}
+}
+
+// redactContainer redacts the env of the container and its init and sidecar containers
+// and the content of its configs
+func redactContainer(c *v1.Container) {
+	if c == nil {
+		return
+	}
+	redactProcess(c.Process)
+	for _, helpers := range [][]*v1.Helper{c.Init, c.Sidecars} {
+		for _, h := range helpers {
+			redactProcess(h.Process)
+		}
+	}
+	for _, cfg := range c.Configs {
+		if cfg.Content != "" {
+			cfg.Content = redacted
+		}
+	}
+}
+
+func redactProcess(p *v1.Process) {
+	if p == nil {
+		return
+	}
+	for i, e := range p.Env {
+		p.Env[i] = strings.SplitN(e, "=", 2)[0] + "=" + redacted
+	}
+}
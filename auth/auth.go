@@ -0,0 +1,277 @@
+package auth
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/config"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ReadOnly = "read-only"
+	Operator = "operator"
+	Admin    = "admin"
+
+	service = "io.boss.v1.Agent"
+)
+
+var builtin = map[string]*config.Role{
+	ReadOnly: {
+		RPCs: []string{"Get", "List", "Jobs", "GroupStatus", "ListVolumes", "ListCheckpoints", "InspectCheckpoint"},
+	},
+	Operator: {
+		RPCs: []string{
+			"Get", "List", "Jobs", "GroupStatus", "ListVolumes", "ListCheckpoints", "InspectCheckpoint",
+			"Create", "Start", "Stop", "Kill", "Update", "Rollback",
+			"Checkpoint", "Restore", "Receive", "Push", "PushBuild", "Audit", "Doctor", "Scale", "Run",
+			"CreateGroup", "UpdateGroup", "DeleteGroup",
+			"CreateVolume", "DeleteVolume", "SnapshotVolume", "ExportVolume",
+			"DeleteCheckpoint", "ExportCheckpoint", "ImportCheckpoint",
+		},
+	},
+	Admin: {
+		RPCs: []string{"*"},
+	},
+}
+
+// New returns an Authorizer for the config's roles
+func New(c *config.Auth) (*Authorizer, error) {
+	roles := make(map[string]*config.Role)
+	for name, r := range builtin {
+		roles[name] = r
+	}
+	for name, r := range c.Roles {
+		if len(r.RPCs) == 0 {
+			b, ok := builtin[name]
+			if !ok {
+				return nil, errors.Errorf("role %s has no rpcs", name)
+			}
+			r = &config.Role{
+				RPCs:       b.RPCs,
+				Containers: r.Containers,
+				Volumes:    r.Volumes,
+			}
+		}
+		for _, g := range r.Containers {
+			if _, err := path.Match(g, ""); err != nil {
+				return nil, errors.Wrapf(err, "role %s container glob %q", name, g)
+			}
+		}
+		for _, g := range r.Volumes {
+			if _, err := path.Match(g, ""); err != nil {
+				return nil, errors.Wrapf(err, "role %s volume glob %q", name, g)
+			}
+		}
+		roles[name] = r
+	}
+	for id, role := range c.Identities {
+		if _, ok := roles[role]; !ok {
+			return nil, errors.Errorf("identity %s has unknown role %s", id, role)
+		}
+	}
+	if c.DefaultRole != "" {
+		if _, ok := roles[c.DefaultRole]; !ok {
+			return nil, errors.Errorf("unknown default role %s", c.DefaultRole)
+		}
+	}
+	return &Authorizer{
+		c:     c,
+		roles: roles,
+	}, nil
+}
+
+// Authorizer checks if a caller's role allows an rpc
+type Authorizer struct {
+	c     *config.Auth
+	roles map[string]*config.Role
+}
+
+// Authorize returns a permission denied error if the identity is not allowed
+// to call the method with the request.
+// The request may be nil when it is not yet known, for example when a stream is opened
+func (a *Authorizer) Authorize(identity, fullMethod string, req interface{}) error {
+	svc, rpc := split(fullMethod)
+	if svc != service {
+		// only the agent's rpcs require authorization, health checks are open
+		return nil
+	}
+	role, r, err := a.role(identity)
+	if err != nil {
+		return err
+	}
+	if !allowed(r.RPCs, rpc) {
+		return status.Errorf(codes.PermissionDenied, "%q with role %s cannot call %s", identity, role, rpc)
+	}
+	if req == nil {
+		return nil
+	}
+	if id := VolumeID(req); id != "" {
+		return a.volume(identity, rpc, id)
+	}
+	// container globs only apply to requests that name a container
+	return a.container(identity, rpc, ContainerID(req))
+}
+
+// WithCaller returns a context carrying the caller of the method so that rpcs can authorize
+// the containers they operate on that are not named by the request
+func (a *Authorizer) WithCaller(ctx context.Context, identity, fullMethod string) context.Context {
+	return context.WithValue(ctx, callerKey{}, &caller{
+		a:        a,
+		identity: identity,
+		rpc:      RPC(fullMethod),
+	})
+}
+
+// Container returns a permission denied error if the caller in the context is not
+// allowed to call its rpc on the container.
+// It returns nil when the context has no caller, such as when authorization is disabled
+func Container(ctx context.Context, id string) error {
+	c, ok := ctx.Value(callerKey{}).(*caller)
+	if !ok {
+		return nil
+	}
+	return c.a.container(c.identity, c.rpc, id)
+}
+
+type callerKey struct{}
+
+type caller struct {
+	a        *Authorizer
+	identity string
+	rpc      string
+}
+
+func (a *Authorizer) role(identity string) (string, *config.Role, error) {
+	role := a.c.Identities[identity]
+	if role == "" {
+		role = a.c.DefaultRole
+	}
+	r, ok := a.roles[role]
+	if !ok {
+		return "", nil, status.Errorf(codes.PermissionDenied, "%q has no role", identity)
+	}
+	return role, r, nil
+}
+
+// container checks the id against the container globs of the identity's role
+func (a *Authorizer) container(identity, rpc, id string) error {
+	role, r, err := a.role(identity)
+	if err != nil {
+		return err
+	}
+	if id == "" || match(r.Containers, id) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "%q with role %s cannot call %s on %s", identity, role, rpc, id)
+}
+
+// volume checks the id against the volume globs of the identity's role
+func (a *Authorizer) volume(identity, rpc, id string) error {
+	role, r, err := a.role(identity)
+	if err != nil {
+		return err
+	}
+	if match(r.Volumes, id) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "%q with role %s cannot call %s on volume %s", identity, role, rpc, id)
+}
+
+// match returns true if the id matches one of the globs or there are none
+func match(globs []string, id string) bool {
+	if len(globs) == 0 {
+		return true
+	}
+	for _, g := range globs {
+		if ok, _ := path.Match(g, id); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Identity returns the common name of the caller's verified client certificate
+// or unix:<uid> for callers on the agent's unix socket
+func Identity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	switch info := p.AuthInfo.(type) {
+	case UnixAuthInfo:
+		return info.Identity()
+	case credentials.TLSInfo:
+		if len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+			return ""
+		}
+		return info.State.VerifiedChains[0][0].Subject.CommonName
+	}
+	return ""
+}
+
+// RPC returns the rpc name of a grpc full method
+func RPC(fullMethod string) string {
+	_, rpc := split(fullMethod)
+	return rpc
+}
+
+// AgentRPC returns the rpc name if the method belongs to the agent service
+func AgentRPC(fullMethod string) (string, bool) {
+	svc, rpc := split(fullMethod)
+	return rpc, svc == service
+}
+
+// ContainerID returns the container id a request operates on,
+// volume requests are authorized by VolumeID instead
+func ContainerID(req interface{}) string {
+	if isVolume(req) {
+		return ""
+	}
+	switch r := req.(type) {
+	case interface{ GetContainer() *v1.Container }:
+		return r.GetContainer().GetID()
+	case interface{ GetID() string }:
+		return r.GetID()
+	}
+	return ""
+}
+
+// VolumeID returns the volume id a volume request operates on
+func VolumeID(req interface{}) string {
+	if !isVolume(req) {
+		return ""
+	}
+	return req.(interface{ GetID() string }).GetID()
+}
+
+func isVolume(req interface{}) bool {
+	switch req.(type) {
+	case *v1.CreateVolumeRequest, *v1.DeleteVolumeRequest, *v1.SnapshotVolumeRequest, *v1.ExportVolumeRequest:
+		return true
+	}
+	return false
+}
+
+func split(fullMethod string) (string, string) {
+	parts := strings.SplitN(strings.TrimPrefix(fullMethod, "/"), "/", 2)
+	if len(parts) != 2 {
+		return "", fullMethod
+	}
+	return parts[0], parts[1]
+}
+
+func allowed(rpcs []string, rpc string) bool {
+	for _, r := range rpcs {
+		if r == "*" || r == rpc {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/credentials"
+)
+
+// UnixCredentials identifies callers on a unix socket by their peer uid
+func UnixCredentials() credentials.TransportCredentials {
+	return &unixCredentials{}
+}
+
+// UnixAuthInfo is the peer information of a unix socket caller
+type UnixAuthInfo struct {
+	UID uint32
+	GID uint32
+	Pid int32
+}
+
+func (u UnixAuthInfo) AuthType() string {
+	return "unix"
+}
+
+// Identity of the caller, unix:<uid>
+func (u UnixAuthInfo) Identity() string {
+	return fmt.Sprintf("unix:%d", u.UID)
+}
+
+type unixCredentials struct {
+}
+
+func (c *unixCredentials) ClientHandshake(_ context.Context, _ string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, nil, nil
+}
+
+func (c *unixCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, nil, errors.New("unix credentials require a unix socket")
+	}
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		cred    *unix.Ucred
+		credErr error
+	)
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return nil, nil, err
+	}
+	if credErr != nil {
+		return nil, nil, errors.Wrap(credErr, "get peer credentials")
+	}
+	return conn, UnixAuthInfo{
+		UID: cred.Uid,
+		GID: cred.Gid,
+		Pid: cred.Pid,
+	}, nil
+}
+
+func (c *unixCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: "unix",
+	}
+}
+
+func (c *unixCredentials) Clone() credentials.TransportCredentials {
+	return &unixCredentials{}
+}
+
+func (c *unixCredentials) OverrideServerName(string) error {
+	return nil
+}
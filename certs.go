@@ -0,0 +1,216 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/crosbymichael/boss/config"
+	"github.com/crosbymichael/boss/util"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var certsCommand = cli.Command{
+	Name:  "certs",
+	Usage: "manage tls certificates for the agent",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "dir",
+			Usage: "directory holding the certificates",
+			Value: config.TLSDir,
+		},
+		cli.IntFlag{
+			Name:  "days",
+			Usage: "number of days the certificate is valid",
+			Value: 365,
+		},
+	},
+	Subcommands: []cli.Command{
+		certsCACommand,
+		certsServerCommand,
+		certsClientCommand,
+	},
+}
+
+var certsCACommand = cli.Command{
+	Name:  "ca",
+	Usage: "generate a new node CA",
+	Action: func(clix *cli.Context) error {
+		dir := clix.Parent().String("dir")
+		if _, err := os.Stat(filepath.Join(dir, "ca.pem")); err == nil {
+			return errors.Errorf("ca already exists in %s", dir)
+		}
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return err
+		}
+		template, err := newCertTemplate("boss-ca", clix.Parent().Int("days"))
+		if err != nil {
+			return err
+		}
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+		der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+		if err != nil {
+			return err
+		}
+		return writeKeyPair(dir, "ca", der, key)
+	},
+}
+
+var certsServerCommand = cli.Command{
+	Name:  "server",
+	Usage: "issue the agent certificate for this node",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "host",
+			Usage: "additional dns names or ips for the certificate",
+			Value: &cli.StringSlice{},
+		},
+	},
+	Action: func(clix *cli.Context) error {
+		c, err := config.Load()
+		if err != nil {
+			return err
+		}
+		hosts := append([]string{c.ID, "localhost", "127.0.0.1", "0.0.0.0"}, clix.StringSlice("host")...)
+		if ip, err := util.GetIP(c.Iface); err == nil {
+			hosts = append(hosts, ip)
+		}
+		if c.Domain != "" {
+			hosts = append(hosts, fmt.Sprintf("%s.node.%s", c.ID, c.Domain))
+		}
+		// the agent cert is also used as a client when migrating to other agents
+		return issue(clix, "agent", c.ID, hosts, x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth)
+	},
+}
+
+var certsClientCommand = cli.Command{
+	Name:      "client",
+	Usage:     "issue a client certificate",
+	ArgsUsage: "<name>",
+	Action: func(clix *cli.Context) error {
+		name := clix.Args().First()
+		if name == "" {
+			return errors.New("client name required")
+		}
+		return issue(clix, name, name, nil, x509.ExtKeyUsageClientAuth)
+	},
+}
+
+func issue(clix *cli.Context, name, cn string, hosts []string, usage ...x509.ExtKeyUsage) error {
+	dir := clix.Parent().String("dir")
+	ca, caKey, err := loadCA(dir)
+	if err != nil {
+		return err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	template, err := newCertTemplate(cn, clix.Parent().Int("days"))
+	if err != nil {
+		return err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.ExtKeyUsage = usage
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+			continue
+		}
+		template.DNSNames = append(template.DNSNames, h)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		return err
+	}
+	return writeKeyPair(dir, name, der, key)
+}
+
+func newCertTemplate(cn string, days int) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   cn,
+			Organization: []string{"boss"},
+		},
+		NotBefore: now.Add(-5 * time.Minute),
+		NotAfter:  now.AddDate(0, 0, days),
+	}, nil
+}
+
+func loadCA(dir string) (*x509.Certificate, crypto.Signer, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, "ca.pem"))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "read ca, run `boss certs ca` first")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, nil, errors.New("invalid ca pem")
+	}
+	ca, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if data, err = ioutil.ReadFile(filepath.Join(dir, "ca-key.pem")); err != nil {
+		return nil, nil, errors.Wrap(err, "read ca key")
+	}
+	if block, _ = pem.Decode(data); block == nil {
+		return nil, nil, errors.New("invalid ca key pem")
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ca, key, nil
+}
+
+func writeKeyPair(dir, name string, der []byte, key *ecdsa.PrivateKey) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	kd, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := writePEM(filepath.Join(dir, name+"-key.pem"), "EC PRIVATE KEY", kd, 0600); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, name+".pem")
+	if err := writePEM(path, "CERTIFICATE", der, 0644); err != nil {
+		return err
+	}
+	fmt.Println(path)
+	return nil
+}
+
+func writePEM(path, t string, data []byte, mode os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if err := pem.Encode(f, &pem.Block{Type: t, Bytes: data}); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
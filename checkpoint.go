@@ -1,13 +1,30 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
 	"github.com/crosbymichael/boss/api/v1"
+	units "github.com/docker/go-units"
 	"github.com/urfave/cli"
 )
 
 var checkpointCommand = cli.Command{
-	Name:  "checkpoint",
-	Usage: "checkpoint a container",
+	Name:      "checkpoint",
+	Usage:     "checkpoint a container and manage checkpoints",
+	ArgsUsage: "<id>",
+	Subcommands: []cli.Command{
+		checkpointExportCommand,
+		checkpointImportCommand,
+		checkpointInspectCommand,
+		checkpointListCommand,
+		checkpointRemoveCommand,
+	},
 	Flags: []cli.Flag{
 		cli.BoolFlag{
 			Name:  "live",
@@ -25,6 +42,16 @@ var checkpointCommand = cli.Command{
 			Name:  "push",
 			Usage: "push the successful checkpoint",
 		},
+		cli.StringSliceFlag{
+			Name:  "volume",
+			Usage: "volume to include in the checkpoint, all of the container's volumes by default",
+			Value: &cli.StringSlice{},
+		},
+		cli.StringSliceFlag{
+			Name:  "exclude-volume",
+			Usage: "volume to leave out",
+			Value: &cli.StringSlice{},
+		},
 	},
 	Action: func(clix *cli.Context) error {
 		ctx := Context()
@@ -35,10 +62,12 @@ var checkpointCommand = cli.Command{
 		defer agent.Close()
 		ref := clix.String("ref")
 		if _, err := agent.Checkpoint(ctx, &v1.CheckpointRequest{
-			ID:   clix.Args().First(),
-			Ref:  ref,
-			Live: clix.Bool("live"),
-			Exit: clix.Bool("exit"),
+			ID:             clix.Args().First(),
+			Ref:            ref,
+			Live:           clix.Bool("live"),
+			Exit:           clix.Bool("exit"),
+			Volumes:        clix.StringSlice("volume"),
+			ExcludeVolumes: clix.StringSlice("exclude-volume"),
 		}); err != nil {
 			return err
 		}
@@ -51,3 +80,186 @@ var checkpointCommand = cli.Command{
 		return nil
 	},
 }
+
+var checkpointListCommand = cli.Command{
+	Name:      "ls",
+	Aliases:   []string{"list"},
+	Usage:     "list checkpoints",
+	ArgsUsage: "[id]",
+	Action: func(clix *cli.Context) error {
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.ListCheckpoints(Context(), &v1.ListCheckpointsRequest{
+			ID: clix.Args().First(),
+		})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+		const tfmt = "%s\t%s\t%s\t%s\t%s\t%t\t%s\n"
+		fmt.Fprint(w, "REF\tID\tNODE\tCREATED\tSIZE\tLIVE\tVOLUMES\n")
+		for _, c := range resp.Checkpoints {
+			fmt.Fprintf(w, tfmt,
+				c.Ref,
+				c.ID,
+				orDash(c.Node),
+				c.Created.Local().Format(time.RFC3339),
+				units.BytesSize(float64(c.Size_)),
+				c.Live,
+				orDash(strings.Join(c.Volumes, ",")),
+			)
+		}
+		return w.Flush()
+	},
+}
+
+var checkpointInspectCommand = cli.Command{
+	Name:      "inspect",
+	Usage:     "show a checkpoint's source container config, node and contents",
+	ArgsUsage: "<ref>",
+	Action: func(clix *cli.Context) error {
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.InspectCheckpoint(Context(), &v1.InspectCheckpointRequest{
+			Ref: clix.Args().First(),
+		})
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(os.Stdout).Encode(resp.Checkpoint)
+	},
+}
+
+var checkpointRemoveCommand = cli.Command{
+	Name:      "rm",
+	Aliases:   []string{"delete"},
+	Usage:     "remove a checkpoint",
+	ArgsUsage: "<ref>",
+	Action: func(clix *cli.Context) error {
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		_, err = agent.DeleteCheckpoint(Context(), &v1.DeleteCheckpointRequest{
+			Ref: clix.Args().First(),
+		})
+		return err
+	},
+}
+
+var checkpointExportCommand = cli.Command{
+	Name:      "export",
+	Usage:     "export a checkpoint as an oci image layout tar",
+	ArgsUsage: "<ref>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output,o",
+			Usage: "write to a file instead of stdout",
+		},
+	},
+	Action: func(clix *cli.Context) error {
+		var out io.Writer = os.Stdout
+		if path := clix.String("output"); path != "" {
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		stream, err := agent.ExportCheckpoint(Context(), &v1.ExportCheckpointRequest{
+			Ref: clix.Args().First(),
+		})
+		if err != nil {
+			return err
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := out.Write(resp.Data); err != nil {
+				return err
+			}
+		}
+	},
+}
+
+var checkpointImportCommand = cli.Command{
+	Name:      "import",
+	Usage:     "import a checkpoint from an oci image layout tar",
+	ArgsUsage: "[ref]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "input,i",
+			Usage: "read from a file instead of stdin",
+		},
+	},
+	Action: func(clix *cli.Context) error {
+		var in io.Reader = os.Stdin
+		if path := clix.String("input"); path != "" {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		stream, err := agent.ImportCheckpoint(Context())
+		if err != nil {
+			return err
+		}
+		req := &v1.ImportCheckpointRequest{
+			Ref: clix.Args().First(),
+		}
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := in.Read(buf)
+			if n > 0 {
+				req.Data = buf[:n]
+				if err := stream.Send(req); err != nil {
+					if err == io.EOF {
+						// the agent failed the import, its error is returned by CloseAndRecv
+						break
+					}
+					return err
+				}
+				req = &v1.ImportCheckpointRequest{}
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+		}
+		resp, err := stream.CloseAndRecv()
+		if err != nil {
+			return err
+		}
+		for _, ref := range resp.Refs {
+			fmt.Println(ref)
+		}
+		return nil
+	},
+}
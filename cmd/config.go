@@ -1,6 +1,12 @@
 package cmd
 
-import "github.com/crosbymichael/boss/api/v1"
+import (
+	"strconv"
+	"strings"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/pkg/errors"
+)
 
 const Version = "v1"
 
@@ -16,27 +22,47 @@ type Container struct {
 	UID           *int               `toml:"uid"`
 	GID           *int               `toml:"gid"`
 	Network       string             `toml:"network"`
+	Networks      []string           `toml:"networks"`
+	Ports         []Port             `toml:"ports"`
 	Services      map[string]Service `toml:"services"`
 	Configs       map[string]File    `toml:"configs"`
 	Readonly      bool               `toml:"readonly"`
 	Capabilities  []string           `toml:"caps"`
 	Volumes       map[string]Volume  `toml:"volumes"`
+	Update        *UpdatePolicy      `toml:"update"`
+	Replicas      int64              `toml:"replicas"`
+	Kind          string             `toml:"kind"`
+	Schedule      string             `toml:"schedule"`
+	Init          []Helper           `toml:"init"`
+	Sidecars      []Helper           `toml:"sidecars"`
+	Checkpoint    *CheckpointPolicy  `toml:"checkpoint"`
 }
 
 func (c *Container) Proto() *v1.Container {
 	container := &v1.Container{
-		ID:      c.ID,
-		Image:   c.Image,
-		Network: c.Network,
+		ID:       c.ID,
+		Image:    c.Image,
+		Network:  c.Network,
+		Networks: c.Networks,
 		Process: &v1.Process{
 			Args:         c.Args,
 			Env:          c.Env,
 			Capabilities: c.Capabilities,
 		},
 		Readonly: c.Readonly,
+		Replicas: c.Replicas,
+		Kind:     c.Kind,
+		Schedule: c.Schedule,
 		Services: make(map[string]*v1.Service),
 		Configs:  make(map[string]*v1.Config),
 	}
+	for _, p := range c.Ports {
+		container.Ports = append(container.Ports, &v1.Port{
+			Host:      p.Host,
+			Container: p.Container,
+			Protocol:  p.Protocol,
+		})
+	}
 	for _, m := range c.Mounts {
 		container.Mounts = append(container.Mounts, &v1.Mount{
 			Type:        m.Type,
@@ -71,9 +97,10 @@ func (c *Container) Proto() *v1.Container {
 	}
 	for name, s := range c.Services {
 		container.Services[name] = &v1.Service{
-			Port:   s.Port,
-			Labels: s.Labels,
-			Url:    s.URL,
+			Port:    s.Port,
+			Labels:  s.Labels,
+			Url:     s.URL,
+			Network: s.Network,
 		}
 		if s.CheckType != "" {
 			container.Services[name].Check = &v1.HealthCheck{
@@ -92,6 +119,21 @@ func (c *Container) Proto() *v1.Container {
 			Content: cfg.Content,
 		}
 	}
+	if c.Update != nil {
+		container.Update = &v1.UpdatePolicy{
+			Strategy:      c.Update.Strategy,
+			HealthTimeout: c.Update.HealthTimeout,
+			Drain:         c.Update.Drain,
+		}
+	}
+	if c.Checkpoint != nil {
+		container.Checkpoint = &v1.CheckpointPolicy{
+			Interval: c.Checkpoint.Interval,
+			Live:     c.Checkpoint.Live,
+			Retain:   c.Checkpoint.Retain,
+			Push:     c.Checkpoint.Push,
+		}
+	}
 	for id, vol := range c.Volumes {
 		container.Volumes = append(container.Volumes, &v1.Volume{
 			ID:          id,
@@ -99,9 +141,103 @@ func (c *Container) Proto() *v1.Container {
 			Rw:          vol.RW,
 		})
 	}
+	for _, h := range c.Init {
+		container.Init = append(container.Init, h.proto(c.Volumes))
+	}
+	for _, h := range c.Sidecars {
+		container.Sidecars = append(container.Sidecars, h.proto(c.Volumes))
+	}
 	return container
 }
 
+// Group is a set of containers deployed together from one file
+type Group struct {
+	ConfigVersion string `toml:"config_version"`
+	ID            string `toml:"id"`
+	// Network is used by the containers that do not set their own
+	Network string `toml:"network"`
+	// Volumes are mounted into every container of the group
+	Volumes    map[string]Volume `toml:"volumes"`
+	Containers []GroupContainer  `toml:"containers"`
+}
+
+// GroupContainer is a container of a group
+type GroupContainer struct {
+	Container
+	// After are the ids of the containers that must be ready before this one is started
+	After []string `toml:"after"`
+}
+
+func (g *Group) Proto() *v1.Group {
+	group := &v1.Group{
+		ID: g.ID,
+	}
+	for _, gc := range g.Containers {
+		c := gc.Container
+		if c.Network == "" {
+			c.Network = g.Network
+		}
+		if len(g.Volumes) > 0 {
+			volumes := make(map[string]Volume)
+			for id, v := range g.Volumes {
+				volumes[id] = v
+			}
+			for id, v := range c.Volumes {
+				volumes[id] = v
+			}
+			c.Volumes = volumes
+		}
+		group.Members = append(group.Members, &v1.GroupMember{
+			Container: c.Proto(),
+			After:     gc.After,
+		})
+	}
+	return group
+}
+
+// Helper is an init or sidecar container
+type Helper struct {
+	Name  string   `toml:"name"`
+	Image string   `toml:"image"`
+	Env   []string `toml:"env"`
+	Args  []string `toml:"args"`
+	UID   *int     `toml:"uid"`
+	GID   *int     `toml:"gid"`
+	// Volumes of the container to mount at the same destination
+	Volumes []string `toml:"volumes"`
+}
+
+func (h *Helper) proto(volumes map[string]Volume) *v1.Helper {
+	helper := &v1.Helper{
+		Name:  h.Name,
+		Image: h.Image,
+		Process: &v1.Process{
+			Args: h.Args,
+			Env:  h.Env,
+		},
+	}
+	if h.UID != nil {
+		gid := 0
+		if h.GID != nil {
+			gid = *h.GID
+		}
+		helper.Process.User = &v1.User{
+			Uid: uint32(*h.UID),
+			Gid: uint32(gid),
+		}
+	}
+	for _, id := range h.Volumes {
+		// unknown volumes are passed through for the agent to reject
+		vol := volumes[id]
+		helper.Volumes = append(helper.Volumes, &v1.Volume{
+			ID:          id,
+			Destination: vol.Destination,
+			Rw:          vol.RW,
+		})
+	}
+	return helper
+}
+
 type File struct {
 	Path    string `toml:"path"`
 	Source  string `toml:"source"`
@@ -118,6 +254,34 @@ type Service struct {
 	CheckInterval int64     `toml:"check_interval"`
 	CheckTimeout  int64     `toml:"check_timeout"`
 	CheckMethod   string    `toml:"check_method"`
+	Network       string    `toml:"network"`
+}
+
+// Port is published on the host as host:container[/protocol]
+type Port struct {
+	Host      int64
+	Container int64
+	Protocol  string
+}
+
+func (p *Port) UnmarshalText(text []byte) error {
+	s := string(text)
+	if i := strings.LastIndex(s, "/"); i != -1 {
+		p.Protocol = s[i+1:]
+		s = s[:i]
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return errors.Errorf("invalid port %q, expected host:container[/protocol]", text)
+	}
+	var err error
+	if p.Host, err = strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return errors.Wrapf(err, "invalid host port %q", parts[0])
+	}
+	if p.Container, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return errors.Wrapf(err, "invalid container port %q", parts[1])
+	}
+	return nil
 }
 
 type CheckType string
@@ -147,6 +311,19 @@ type Mount struct {
 	Options     []string `toml:"options"`
 }
 
+type UpdatePolicy struct {
+	Strategy      string `toml:"strategy"`
+	HealthTimeout int64  `toml:"health_timeout"`
+	Drain         int64  `toml:"drain"`
+}
+
+type CheckpointPolicy struct {
+	Interval int64  `toml:"interval"`
+	Live     bool   `toml:"live"`
+	Retain   int64  `toml:"retain"`
+	Push     string `toml:"push"`
+}
+
 type Volume struct {
 	Destination string `toml:"destination"`
 	RW          bool   `toml:"rw"`
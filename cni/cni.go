@@ -2,6 +2,7 @@ package cni
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"os"
 	"os/exec"
@@ -18,80 +19,174 @@ import (
 	"golang.org/x/sys/unix"
 )
 
-func New(t, iface, mvlanAddress string, n networking.CNI) (v1.Network, error) {
-	if t == "macvlan" {
-		if err := route.Create(iface, mvlanAddress); err != nil {
-			return nil, err
+// Attachment is a network attached to the container, the first as eth0 and the others as eth1 and on
+type Attachment struct {
+	// Network is the name of the network in the container config
+	Network string
+	// Link is the macvlan bridge from the host to the containers on the network, if any
+	Link           string
+	Master         string
+	BridgeAddress  string
+	BridgeAddress6 string
+}
+
+func New(n networking.CNI, attachments ...*Attachment) (v1.Network, error) {
+	for _, a := range attachments {
+		if a.Link == "" {
+			continue
+		}
+		if err := route.Create(a.Link, a.Master, a.BridgeAddress, a.BridgeAddress6); err != nil {
+			return nil, errors.Wrapf(err, "create %s bridge", a.Network)
 		}
 	}
 	return &cni{
-		network: n,
-		nt:      t,
+		network:     n,
+		attachments: attachments,
 	}, nil
 }
 
 type cni struct {
-	network networking.CNI
-	nt      string
+	network     networking.CNI
+	attachments []*Attachment
 }
 
-func (n *cni) Create(ctx context.Context, task containerd.Container) (string, error) {
+func (n *cni) Create(ctx context.Context, task containerd.Container) ([]*v1.NetworkInterface, error) {
 	path := v1.NetworkPath(task.ID())
 	if _, err := os.Lstat(path); err != nil {
 		if !os.IsNotExist(err) {
-			return "", err
+			return nil, err
 		}
 		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
-			return "", err
+			return nil, err
 		}
-		if err := createNetns(path); err != nil {
-			return "", err
+		if err := CreateNetns(path); err != nil {
+			return nil, err
+		}
+		ports, err := withPorts(ctx, task)
+		if err != nil {
+			return nil, err
 		}
-		result, err := n.network.Setup(task.ID(), path)
+		result, err := n.network.Setup(task.ID(), path, ports)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		var ip net.IP
-		for _, ipc := range result.Interfaces["eth0"].IPConfigs {
-			if f := ipc.IP.To4(); f != nil {
-				ip = f
-				break
+		var interfaces []*v1.NetworkInterface
+		for i, a := range n.attachments {
+			name := fmt.Sprintf("eth%d", i)
+			ips := addresses(result.Interfaces[name])
+			if len(ips) == 0 {
+				return nil, errors.Errorf("no ip assigned to %s", name)
+			}
+			interfaces = append(interfaces, &v1.NetworkInterface{
+				Name:    name,
+				Network: a.Network,
+				IPs:     ips,
+			})
+			if a.Link != "" {
+				for _, ip := range ips {
+					route.Remove(a.Link, ip)
+					if err := route.Add(a.Link, ip); err != nil {
+						return nil, err
+					}
+				}
 			}
 		}
-		if err := task.Update(ctx, opts.WithIP(ip.String())); err != nil {
-			return "", err
+		if err := task.Update(ctx, opts.WithInterfaces(interfaces)); err != nil {
+			return nil, err
 		}
-		if n.nt == "macvlan" {
-			route.Remove(ip.String())
-			if err := route.Add(ip.String()); err != nil {
-				return "", err
-			}
+		spec, err := task.Spec(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := opts.UpdateHostsFile(task.ID(), spec.Hostname, interfaces[0].IPs); err != nil {
+			return nil, errors.Wrap(err, "update hosts file")
 		}
-		return ip.String(), nil
+		return interfaces, nil
 	}
 	l, err := task.Labels(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	return n.interfaces(l)
+}
+
+// interfaces returns the container's interfaces from its labels,
+// containers created before they were stored only have the ips of their primary network
+func (n *cni) interfaces(labels map[string]string) ([]*v1.NetworkInterface, error) {
+	interfaces, err := opts.Interfaces(labels)
+	if err != nil {
+		return nil, err
+	}
+	if interfaces != nil {
+		return interfaces, nil
+	}
+	ips := opts.IPs(labels)
+	if len(ips) == 0 {
+		return nil, nil
+	}
+	return []*v1.NetworkInterface{
+		{
+			Name:    "eth0",
+			Network: n.attachments[0].Network,
+			IPs:     ips,
+		},
+	}, nil
+}
+
+// addresses returns the interface's first ipv4 and first ipv6 address, ipv4 first
+func addresses(iface *networking.Config) []string {
+	if iface == nil {
+		return nil
+	}
+	var ip4, ip6 net.IP
+	for _, ipc := range iface.IPConfigs {
+		if f := ipc.IP.To4(); f != nil {
+			if ip4 == nil {
+				ip4 = f
+			}
+		} else if ip6 == nil && ipc.IP.To16() != nil {
+			ip6 = ipc.IP
+		}
 	}
-	return l[opts.IPLabel], nil
+	var ips []string
+	for _, ip := range []net.IP{ip4, ip6} {
+		if ip != nil {
+			ips = append(ips, ip.String())
+		}
+	}
+	return ips
 }
 
 func (n *cni) Remove(ctx context.Context, c containerd.Container) error {
 	path := v1.NetworkPath(c.ID())
-	if err := n.network.Remove(c.ID(), path); err != nil {
+	// the rest of the teardown does not depend on the ports
+	var o []networking.NamespaceOpts
+	if ports, err := withPorts(ctx, c); err != nil {
+		logrus.WithError(err).Error("published ports")
+	} else {
+		o = append(o, ports)
+	}
+	if err := n.network.Remove(c.ID(), path, o...); err != nil {
 		logrus.WithError(err).Error("remove cni networking")
 	}
 	if err := unix.Unmount(path, 0); err != nil {
 		logrus.WithError(err).Error("unmount netns")
 	}
-	if n.nt == "macvlan" {
-		info, err := c.Info(ctx)
-		if err != nil {
-			return err
+	info, err := c.Info(ctx)
+	if err != nil {
+		return err
+	}
+	interfaces, err := n.interfaces(info.Labels)
+	if err != nil {
+		return err
+	}
+	for _, i := range interfaces {
+		link := n.link(i.Network)
+		if link == "" {
+			continue
 		}
-		ip := info.Labels[opts.IPLabel]
-		if ip != "" {
-			if err := route.Remove(ip); err != nil {
+		for _, ip := range i.IPs {
+			if err := route.Remove(link, ip); err != nil {
 				logrus.WithError(err).Error("remove routes")
 			}
 		}
@@ -100,7 +195,38 @@ func (n *cni) Remove(ctx context.Context, c containerd.Container) error {
 	return os.RemoveAll(filepath.Dir(path))
 }
 
-func createNetns(path string) error {
+// withPorts passes the container's published ports to the portmap plugin of its network
+func withPorts(ctx context.Context, c containerd.Container) (networking.NamespaceOpts, error) {
+	config, err := opts.GetConfig(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	var ports []networking.PortMapping
+	for _, p := range config.Ports {
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		ports = append(ports, networking.PortMapping{
+			HostPort:      int32(p.Host),
+			ContainerPort: int32(p.Container),
+			Protocol:      protocol,
+		})
+	}
+	return networking.WithCapabilityPortMap(ports), nil
+}
+
+func (n *cni) link(network string) string {
+	for _, a := range n.attachments {
+		if a.Network == network {
+			return a.Link
+		}
+	}
+	return ""
+}
+
+// CreateNetns creates a network namespace with only loopback bind mounted at path
+func CreateNetns(path string) error {
 	cmd := exec.Command("boss", "network", "create", path)
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Cloneflags: unix.CLONE_NEWNET,
@@ -23,6 +23,32 @@ WantedBy=multi-user.target`
 type Agent struct {
 	PlainRemotes []string `toml:"plain_remotes"`
 	VolumeRoot   string   `toml:"volume_root"`
+	TLS          *TLS     `toml:"tls"`
+	Auth         *Auth    `toml:"auth"`
+	Audit        Audit    `toml:"audit"`
+	// Listen on tcp://host:port or unix:///path addresses
+	// defaults to the --agent address and the unix socket
+	Listen []string `toml:"listen"`
+	// SocketMode is the octal permissions of the unix socket
+	SocketMode string `toml:"socket_mode"`
+	// SocketGID is the group owning the unix socket
+	SocketGID int `toml:"socket_gid"`
+	// ShutdownTimeout is how long in-flight rpcs have to finish when the agent stops
+	ShutdownTimeout Duration `toml:"shutdown_timeout"`
+	// Timeouts are deadlines by rpc name, "default" applies to rpcs without an entry
+	Timeouts map[string]Duration `toml:"timeouts"`
+	// ReconcileInterval is how often drift is checked and repaired after the agent starts
+	ReconcileInterval Duration `toml:"reconcile_interval"`
+	// VolumeQuota is how volume quotas are enforced, with "loop" filesystem images or xfs "project" quotas
+	VolumeQuota string `toml:"volume_quota"`
+}
+
+// Audit configures the agent's audit log
+type Audit struct {
+	Path string `toml:"path"`
+	// MaxSize in MB before the log is rotated
+	MaxSize  int64 `toml:"max_size"`
+	MaxFiles int   `toml:"max_files"`
 }
 
 func (s *Agent) Name() string {
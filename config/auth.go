@@ -0,0 +1,22 @@
+package config
+
+// Auth maps client identities to roles for the agent's api
+type Auth struct {
+	// Identities maps the common name of a client certificate to a role
+	Identities map[string]string `toml:"identities"`
+	// DefaultRole is used for callers without a mapped identity
+	DefaultRole string `toml:"default_role"`
+	// Roles extend or override the builtin read-only, operator, and admin roles
+	Roles map[string]*Role `toml:"roles"`
+}
+
+// Role is a set of rpcs that can be called on containers matching the globs
+type Role struct {
+	// RPCs are the agent rpc names, "*" allows all
+	// builtin roles keep their rpcs when none are specified
+	RPCs []string `toml:"rpcs"`
+	// Containers are id globs, an empty list allows all containers
+	Containers []string `toml:"containers"`
+	// Volumes are id globs for the volume rpcs, an empty list allows all volumes
+	Volumes []string `toml:"volumes"`
+}
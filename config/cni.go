@@ -9,14 +9,18 @@ import (
 )
 
 type CNI struct {
-	Image         string `toml:"image" json:"-"`
-	Version       string `toml:"-" json:"cniVersion,omitempty"`
-	NetworkName   string `toml:"name" json:"name"`
-	Type          string `toml:"type" json:"type"`
-	Master        string `toml:"master" json:"master,omitempty"`
+	Image       string `toml:"image" json:"-"`
+	Version     string `toml:"-" json:"cniVersion,omitempty"`
+	NetworkName string `toml:"name" json:"name"`
+	Type        string `toml:"type" json:"type"`
+	Master      string `toml:"master" json:"master,omitempty"`
+	// Mode of macvlan and ipvlan networks
+	Mode          string `toml:"mode" json:"mode,omitempty"`
 	IPAM          IPAM   `toml:"ipam" json:"ipam"`
 	Bridge        string `toml:"bridge" json:"bridge,omitempty"`
 	BridgeAddress string `toml:"bridge_address" json:"-"`
+	// BridgeAddress6 is the host's ipv6 address on the macvlan bridge for dual-stack networks
+	BridgeAddress6 string `toml:"bridge_address6" json:"-"`
 }
 
 func (c *CNI) SubSteps() (o []Step) {
@@ -34,9 +38,74 @@ func (c *CNI) Bytes() []byte {
 	return data
 }
 
+type confList struct {
+	Version string            `json:"cniVersion,omitempty"`
+	Name    string            `json:"name"`
+	Plugins []json.RawMessage `json:"plugins"`
+}
+
+// portMap is the plugin that publishes the container's ports on the host
+type portMap struct {
+	Type         string          `json:"type"`
+	Capabilities map[string]bool `json:"capabilities"`
+}
+
+// ListBytes returns the network as a config list, with the portmap plugin chained after it
+// when the container publishes ports on the network
+func (c *CNI) ListBytes(ports bool) []byte {
+	list := confList{
+		Version: c.Version,
+		Name:    c.NetworkName,
+		Plugins: []json.RawMessage{c.Bytes()},
+	}
+	if ports {
+		data, err := json.Marshal(portMap{
+			Type: "portmap",
+			Capabilities: map[string]bool{
+				"portMappings": true,
+			},
+		})
+		if err != nil {
+			panic(err)
+		}
+		list.Plugins = append(list.Plugins, data)
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
 type IPAM struct {
 	Type   string `toml:"type" json:"type"`
-	Subnet string `toml:"subnet" json:"subnet"`
+	Subnet string `toml:"subnet" json:"subnet,omitempty"`
+	// Subnet6 allocates an ipv6 address alongside the ipv4 one from Subnet for dual-stack containers,
+	// it can also be used on its own for ipv6 only networks
+	Subnet6 string `toml:"subnet6" json:"-"`
+}
+
+type ipamRange struct {
+	Subnet string `json:"subnet"`
+}
+
+// MarshalJSON adds Subnet6 as an additional range of the host-local ipam plugin
+func (i IPAM) MarshalJSON() ([]byte, error) {
+	type ipam IPAM
+	v := struct {
+		ipam
+		Ranges [][]ipamRange `json:"ranges,omitempty"`
+	}{
+		ipam: ipam(i),
+	}
+	if i.Subnet6 != "" {
+		v.Ranges = [][]ipamRange{
+			{
+				{Subnet: i.Subnet6},
+			},
+		}
+	}
+	return json.Marshal(v)
 }
 
 func (s *CNI) Name() string {
@@ -2,8 +2,15 @@ package config
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/containerd/containerd"
@@ -11,6 +18,7 @@ import (
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/crosbymichael/boss/cni"
 	"github.com/crosbymichael/boss/consulregister"
+	"github.com/crosbymichael/boss/route"
 	"github.com/crosbymichael/boss/util"
 	"github.com/hashicorp/consul/api"
 	"github.com/pkg/errors"
@@ -42,6 +50,24 @@ func Load() (*Config, error) {
 	if c.Iface == "" {
 		c.Iface = "eth0"
 	}
+	if c.Agent.Audit.Path == "" {
+		c.Agent.Audit.Path = filepath.Join(v1.Root, "audit.log")
+	}
+	if c.Agent.Audit.MaxSize == 0 {
+		c.Agent.Audit.MaxSize = 64
+	}
+	if c.Agent.Audit.MaxFiles == 0 {
+		c.Agent.Audit.MaxFiles = 5
+	}
+	if c.Agent.ShutdownTimeout.Duration == 0 {
+		c.Agent.ShutdownTimeout.Duration = 2 * time.Minute
+	}
+	if c.Agent.ReconcileInterval.Duration == 0 {
+		c.Agent.ReconcileInterval.Duration = 5 * time.Minute
+	}
+	if c.Agent.VolumeQuota == "" {
+		c.Agent.VolumeQuota = "loop"
+	}
 	return &c, nil
 }
 
@@ -56,20 +82,22 @@ func getConsul() {
 }
 
 type Config struct {
-	ID           string        `toml:"id"`
-	Iface        string        `toml:"iface"`
-	Domain       string        `toml:"domain"`
-	Buildkit     *Buildkit     `toml:"buildkit"`
-	CNI          *CNI          `toml:"cni"`
-	Consul       *Consul       `toml:"consul"`
-	NodeExporter *NodeExporter `toml:"nodeexporter"`
-	Nameservers  []string      `toml:"nameservers"`
-	Timezone     string        `toml:"timezone"`
-	MOTD         *MOTD         `toml:"motd"`
-	SSH          *SSH          `toml:"ssh"`
-	Agent        Agent         `toml:"agent"`
-	Containerd   Containerd    `toml:"containerd"`
-	Criu         *Criu         `toml:"criu"`
+	ID       string    `toml:"id"`
+	Iface    string    `toml:"iface"`
+	Domain   string    `toml:"domain"`
+	Buildkit *Buildkit `toml:"buildkit"`
+	CNI      *CNI      `toml:"cni"`
+	// Networks are named cni networks that containers can attach to in addition to their network
+	Networks     map[string]*CNI `toml:"networks"`
+	Consul       *Consul         `toml:"consul"`
+	NodeExporter *NodeExporter   `toml:"nodeexporter"`
+	Nameservers  []string        `toml:"nameservers"`
+	Timezone     string          `toml:"timezone"`
+	MOTD         *MOTD           `toml:"motd"`
+	SSH          *SSH            `toml:"ssh"`
+	Agent        Agent           `toml:"agent"`
+	Containerd   Containerd      `toml:"containerd"`
+	Criu         *Criu           `toml:"criu"`
 }
 
 func (c *Config) Store() (ConfigStore, error) {
@@ -85,43 +113,111 @@ func (c *Config) Store() (ConfigStore, error) {
 	return &nullStore{}, nil
 }
 
-// GetNetwork returns a network for the givin name
-func (c *Config) GetNetwork(name string) (v1.Network, error) {
-	ip, err := util.GetIP(c.Iface)
-	if err != nil {
-		return nil, err
-	}
+// GetNetwork returns the container's network with its named networks attached after it
+func (c *Config) GetNetwork(container *v1.Container) (v1.Network, error) {
+	name, networks := container.Network, container.Networks
 	switch name {
 	case "", "none":
 		return &none{}, nil
 	case "host":
-		return &host{ip: ip}, nil
-	case "cni":
+		ips, err := util.GetIPs(c.Iface)
+		if err != nil {
+			return nil, err
+		}
+		return &host{iface: c.Iface, ips: ips}, nil
+	}
+	var (
+		confs       []*CNI
+		attachments []*cni.Attachment
+	)
+	for _, name := range append([]string{name}, networks...) {
+		conf, err := c.cniNetwork(name)
+		if err != nil {
+			return nil, err
+		}
+		a := &cni.Attachment{
+			Network: name,
+		}
+		if conf.Type == "macvlan" {
+			if conf.BridgeAddress == "" {
+				return nil, errors.Errorf("bridge_address must be specified with macvlan on %s", name)
+			}
+			a.Link = bridgeLink(name)
+			a.Master = conf.Master
+			a.BridgeAddress = conf.BridgeAddress
+			a.BridgeAddress6 = conf.BridgeAddress6
+		}
+		confs = append(confs, conf)
+		attachments = append(attachments, a)
+	}
+	n, err := newCNI(confs, len(container.Ports) > 0)
+	if err != nil {
+		return nil, err
+	}
+	return cni.New(n, attachments...)
+}
+
+// cniNetwork returns the cni config of the [cni] network or of a named network
+func (c *Config) cniNetwork(name string) (*CNI, error) {
+	conf := c.Networks[name]
+	if name == "cni" {
 		if c.CNI == nil {
 			return nil, errors.New("[cni] is not enabled in the system config")
 		}
-		if c.CNI.Type == "macvlan" && c.CNI.BridgeAddress == "" {
-			return nil, errors.New("bridge_address must be specified with macvlan")
-		}
+		conf = c.CNI
 		// populate cni data from main config if fields are missing
-		c.CNI.Version = "0.3.1"
-		if c.CNI.NetworkName == "" {
-			c.CNI.NetworkName = c.Domain
-		}
-		if c.CNI.Master == "" {
-			c.CNI.Master = c.Iface
-		}
-		n, err := gocni.New(
-			gocni.WithPluginDir([]string{"/opt/containerd/bin"}),
-			gocni.WithConf(c.CNI.Bytes()),
-			gocni.WithLoNetwork,
-		)
-		if err != nil {
+		if conf.NetworkName == "" {
+			conf.NetworkName = c.Domain
+		}
+	}
+	if conf == nil {
+		return nil, errors.Errorf("network %s does not exist", name)
+	}
+	conf.Version = "0.3.1"
+	if conf.NetworkName == "" {
+		conf.NetworkName = name
+	}
+	if conf.Master == "" {
+		conf.Master = c.Iface
+	}
+	return conf, nil
+}
+
+// newCNI loads the networks in order, the first as eth0 and the others as eth1 and on.
+// The container's ports are published on the first network when it is a bridge
+func newCNI(confs []*CNI, ports bool) (gocni.CNI, error) {
+	// go-cni only names the interfaces after eth0 when loading a directory of configs
+	dir, err := ioutil.TempDir("", "boss-cni-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	for i, conf := range confs {
+		if err := ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("%02d.conflist", i)), conf.ListBytes(i == 0 && ports && conf.Type == "bridge"), 0600); err != nil {
 			return nil, err
 		}
-		return cni.New(c.CNI.Type, c.Iface, c.CNI.BridgeAddress, n)
 	}
-	return nil, errors.Errorf("network %s does not exist", name)
+	return gocni.New(
+		gocni.WithPluginDir([]string{"/opt/containerd/bin"}),
+		gocni.WithPluginConfDir(dir),
+		gocni.WithAllConf,
+		gocni.WithLoNetwork,
+	)
+}
+
+// bridgeLink returns the name of the network's macvlan bridge on the host.
+// Interface names are limited to 15 characters so long network names are shortened
+// with a hash of the name to keep them unique
+func bridgeLink(network string) string {
+	if network == "cni" {
+		return route.Interface
+	}
+	link := "mvlan-" + network
+	if len(link) > 15 {
+		sum := sha256.Sum256([]byte(network))
+		link = "mvlan-" + network[:3] + hex.EncodeToString(sum[:])[:6]
+	}
+	return link
 }
 
 func (c *Config) GetNameservers() ([]string, error) {
@@ -172,6 +268,11 @@ func (c *Config) Steps() []Step {
 		c.Consul.c = c
 		steps = append(steps, c.Consul)
 		steps = append(steps, c.Consul.SubSteps()...)
+		check := "grpc"
+		if c.Agent.TLS != nil {
+			// consul cannot present a client cert for grpc checks
+			check = "tcp"
+		}
 		steps = append(steps, &RegisterService{
 			Config: c,
 			ID:     "agent",
@@ -180,7 +281,7 @@ func (c *Config) Steps() []Step {
 			},
 			Port: 1337,
 			Check: &v1.HealthCheck{
-				Type: "grpc",
+				Type: check,
 			},
 		})
 	}
@@ -208,10 +309,7 @@ func (c *Config) Steps() []Step {
 			})
 		}
 	}
-	if c.CNI != nil {
-		steps = append(steps, c.CNI)
-		steps = append(steps, c.CNI.SubSteps()...)
-	}
+	steps = append(steps, c.cniSteps()...)
 	if c.MOTD != nil {
 		steps = append(steps, c.MOTD)
 	}
@@ -227,6 +325,39 @@ func (c *Config) Steps() []Step {
 	return steps
 }
 
+// cniSteps installs the plugins of the [cni] network and of the named networks, once for each image
+func (c *Config) cniSteps() (steps []Step) {
+	var (
+		names  []string
+		images = make(map[string]bool)
+		dhcp   bool
+	)
+	for name := range c.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	networks := []*CNI{c.CNI}
+	for _, name := range names {
+		networks = append(networks, c.Networks[name])
+	}
+	for _, n := range networks {
+		if n == nil {
+			continue
+		}
+		if !images[n.Image] {
+			images[n.Image] = true
+			steps = append(steps, n)
+		}
+		if !dhcp {
+			for _, s := range n.SubSteps() {
+				dhcp = true
+				steps = append(steps, s)
+			}
+		}
+	}
+	return steps
+}
+
 func (c *Config) consul() bool {
 	return c.Consul != nil
 }
@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+// Duration is a time.Duration that can be decoded from a toml string like "30s"
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalText(text []byte) (err error) {
+	d.Duration, err = time.ParseDuration(string(text))
+	return err
+}
@@ -2,29 +2,55 @@ package config
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 
 	"github.com/containerd/containerd"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/cni"
+	"golang.org/x/sys/unix"
 )
 
 type host struct {
-	ip string
+	iface string
+	ips   []string
 }
 
-func (n *host) Create(_ context.Context, _ containerd.Container) (string, error) {
-	return n.ip, nil
+func (n *host) Create(_ context.Context, _ containerd.Container) ([]*v1.NetworkInterface, error) {
+	return []*v1.NetworkInterface{
+		{
+			Name:    n.iface,
+			Network: "host",
+			IPs:     n.ips,
+		},
+	}, nil
 }
 
 func (n *host) Remove(_ context.Context, _ containerd.Container) error {
 	return nil
 }
 
+// none creates a network namespace with only loopback for the container
+// so that its init and sidecar containers can join it
 type none struct {
 }
 
-func (n *none) Create(_ context.Context, _ containerd.Container) (string, error) {
-	return "", nil
+func (n *none) Create(_ context.Context, c containerd.Container) ([]*v1.NetworkInterface, error) {
+	path := v1.NetworkPath(c.ID())
+	if _, err := os.Lstat(path); err == nil {
+		return nil, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	return nil, cni.CreateNetns(path)
 }
 
-func (n *none) Remove(_ context.Context, _ containerd.Container) error {
+func (n *none) Remove(_ context.Context, c containerd.Container) error {
+	if err := unix.Unmount(v1.NetworkPath(c.ID()), 0); err != nil && err != unix.EINVAL && err != unix.ENOENT {
+		return err
+	}
 	return nil
 }
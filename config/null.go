@@ -6,7 +6,7 @@ type nullRegister struct {
 }
 
 // Register sends the provided service registration to the local agent
-func (c *nullRegister) Register(id, name, ip string, s *v1.Service) error {
+func (c *nullRegister) Register(id, name string, ips []string, s *v1.Service) error {
 	return nil
 }
 
@@ -24,3 +24,8 @@ func (c *nullRegister) EnableMaintainance(_, _, _ string) error {
 func (c *nullRegister) DisableMaintainance(_, _ string) error {
 	return nil
 }
+
+// Services returns no services
+func (c *nullRegister) Services() (map[string][]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// TLSDir is the default location for the node's certificates
+const TLSDir = "/etc/boss/tls"
+
+// TLS configures mutual tls for the agent's grpc api
+type TLS struct {
+	CA   string `toml:"ca"`
+	Cert string `toml:"cert"`
+	Key  string `toml:"key"`
+	// ServerName overrides the name used to verify the agent's certificate
+	ServerName string `toml:"server_name"`
+}
+
+// ServerConfig returns a tls config that requires client certificates
+// signed by the configured CA
+func (t *TLS) ServerConfig() (*tls.Config, error) {
+	pool, cert, err := t.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ClientConfig returns a tls config that presents the configured certificate
+// and verifies the agent against the CA
+func (t *TLS) ClientConfig() (*tls.Config, error) {
+	pool, cert, err := t.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   t.ServerName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func (t *TLS) load() (*x509.CertPool, tls.Certificate, error) {
+	if t.CA == "" || t.Cert == "" || t.Key == "" {
+		return nil, tls.Certificate{}, errors.New("tls requires a ca, cert, and key")
+	}
+	data, err := ioutil.ReadFile(t.CA)
+	if err != nil {
+		return nil, tls.Certificate{}, errors.Wrap(err, "read ca")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, tls.Certificate{}, errors.Errorf("no certificates found in %s", t.CA)
+	}
+	cert, err := tls.LoadX509KeyPair(t.Cert, t.Key)
+	if err != nil {
+		return nil, tls.Certificate{}, errors.Wrap(err, "load key pair")
+	}
+	return pool, cert, nil
+}
@@ -2,12 +2,19 @@ package consulregister
 
 import (
 	"fmt"
+	"net"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/hashicorp/consul/api"
+	"github.com/pkg/errors"
 )
 
+// ContainerMeta is the service meta key holding the boss container id
+const ContainerMeta = "boss-container"
+
 func New(client *api.Client) *Consul {
 	return &Consul{
 		client: client,
@@ -19,37 +26,120 @@ type Consul struct {
 	client *api.Client
 }
 
-// Register sends the provided service registration to the local agent
-func (c *Consul) Register(id, name, ip string, s *v1.Service) error {
-	reg := c.registration(id, name, ip, s)
-	if err := c.client.Agent().ServiceRegister(reg); err != nil {
+// Register sends the provided service registration to the local agent.
+// Dual-stack containers get a second instance of the service at their ipv6 address
+// so that the service resolves to both A and AAAA records
+func (c *Consul) Register(id, name string, ips []string, s *v1.Service) error {
+	if len(ips) == 0 {
+		return errors.Errorf("no ip to register %s-%s", id, name)
+	}
+	regs := []*api.AgentServiceRegistration{
+		c.registration(serviceID(id, name), id, name, ips[0], s),
+	}
+	if len(ips) > 1 {
+		regs = append(regs, c.registration(serviceID6(id, name), id, name, ips[1], s))
+	} else if err := c.deregisterIfExists(serviceID6(id, name)); err != nil {
 		return err
 	}
-	return c.client.Agent().EnableServiceMaintenance(serviceID(id, name), "created")
+	for _, reg := range regs {
+		if err := c.client.Agent().ServiceRegister(reg); err != nil {
+			return err
+		}
+		if err := c.client.Agent().EnableServiceMaintenance(reg.ID, "created"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Services returns the names of the services registered for boss containers
+func (c *Consul) Services() (map[string][]string, error) {
+	services, err := c.client.Agent().Services()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]string)
+	for _, s := range services {
+		id, ok := s.Meta[ContainerMeta]
+		if !ok {
+			// services registered before the container meta was added only follow the id convention
+			if !strings.HasSuffix(s.ID, "-"+s.Service) {
+				continue
+			}
+			id = strings.TrimSuffix(s.ID, "-"+s.Service)
+		}
+		if id == "" {
+			continue
+		}
+		if s.ID == serviceID6(id, s.Service) {
+			continue
+		}
+		out[id] = append(out[id], s.Service)
+	}
+	return out, nil
 }
 
 // Deregister sends the provided service registration to the local agent
 func (c *Consul) Deregister(id, name string) error {
-	return c.client.Agent().ServiceDeregister(serviceID(id, name))
+	if err := c.client.Agent().ServiceDeregister(serviceID(id, name)); err != nil {
+		return err
+	}
+	return c.deregisterIfExists(serviceID6(id, name))
 }
 
 // EnableMaintainance places the specific service in maintainace mode
 func (c *Consul) EnableMaintainance(id, name, reason string) error {
-	return c.client.Agent().EnableServiceMaintenance(serviceID(id, name), reason)
+	return c.each(id, name, func(sid string) error {
+		return c.client.Agent().EnableServiceMaintenance(sid, reason)
+	})
 }
 
 // DisableMaintainance removes the specific service out of maintainace mode
 func (c *Consul) DisableMaintainance(id, name string) error {
-	return c.client.Agent().DisableServiceMaintenance(serviceID(id, name))
+	return c.each(id, name, func(sid string) error {
+		return c.client.Agent().DisableServiceMaintenance(sid)
+	})
 }
 
-func (c *Consul) registration(id, name, ip string, s *v1.Service) *api.AgentServiceRegistration {
+// each runs fn for the service and its ipv6 instance if the container is dual-stack
+func (c *Consul) each(id, name string, fn func(string) error) error {
+	if err := fn(serviceID(id, name)); err != nil {
+		return err
+	}
+	exists, err := c.exists(serviceID6(id, name))
+	if err != nil || !exists {
+		return err
+	}
+	return fn(serviceID6(id, name))
+}
+
+func (c *Consul) exists(sid string) (bool, error) {
+	services, err := c.client.Agent().Services()
+	if err != nil {
+		return false, err
+	}
+	_, ok := services[sid]
+	return ok, nil
+}
+
+func (c *Consul) deregisterIfExists(sid string) error {
+	exists, err := c.exists(sid)
+	if err != nil || !exists {
+		return err
+	}
+	return c.client.Agent().ServiceDeregister(sid)
+}
+
+func (c *Consul) registration(sid, id, name, ip string, s *v1.Service) *api.AgentServiceRegistration {
 	reg := &api.AgentServiceRegistration{
-		ID:      serviceID(id, name),
+		ID:      sid,
 		Name:    name,
 		Tags:    s.Labels,
 		Port:    int(s.Port),
 		Address: ip,
+		Meta: map[string]string{
+			ContainerMeta: id,
+		},
 	}
 	if s.Check != nil {
 		var check api.AgentServiceCheck
@@ -61,7 +151,7 @@ func (c *Consul) registration(id, name, ip string, s *v1.Service) *api.AgentServ
 		if s.Check.Timeout != 0 {
 			check.Timeout = fmt.Sprintf("%ds", s.Check.Timeout)
 		}
-		addr := fmt.Sprintf("%s:%d", ip, s.Port)
+		addr := net.JoinHostPort(ip, strconv.FormatInt(s.Port, 10))
 		switch s.Check.Type {
 		case "http":
 			url := s.Url
@@ -83,3 +173,8 @@ func (c *Consul) registration(id, name, ip string, s *v1.Service) *api.AgentServ
 func serviceID(id, name string) string {
 	return fmt.Sprintf("%s-%s", id, name)
 }
+
+// serviceID6 is the id of the service's instance at a dual-stack container's ipv6 address
+func serviceID6(id, name string) string {
+	return serviceID(id, name) + "-ipv6"
+}
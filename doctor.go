@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/urfave/cli"
+)
+
+var doctorCommand = cli.Command{
+	Name:  "doctor",
+	Usage: "check for drift between containers, units, networks, and services",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "repair",
+			Usage: "repair the drift that can be fixed",
+		},
+	},
+	Action: func(clix *cli.Context) error {
+		ctx := Context()
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.Doctor(ctx, &v1.DoctorRequest{
+			Repair: clix.Bool("repair"),
+		})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+		const tfmt = "%s\t%s\t%s\t%s\n"
+		fmt.Fprint(w, "ID\tKIND\tMESSAGE\tREPAIR\n")
+		for _, f := range resp.Findings {
+			repair := "-"
+			switch {
+			case f.Error != "":
+				repair = f.Error
+			case f.Repaired:
+				repair = "repaired"
+			}
+			fmt.Fprintf(w, tfmt,
+				f.ID,
+				f.Kind,
+				f.Message,
+				repair,
+			)
+		}
+		return w.Flush()
+	},
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/BurntSushi/toml"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/cmd"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var groupCommand = cli.Command{
+	Name:  "group",
+	Usage: "manage groups of containers deployed from one file",
+	Subcommands: []cli.Command{
+		groupCreateCommand,
+		groupDeleteCommand,
+		groupStatusCommand,
+		groupUpdateCommand,
+	},
+}
+
+var groupCreateCommand = cli.Command{
+	Name:      "create",
+	Usage:     "create the containers of a group",
+	ArgsUsage: "<group.toml>",
+	Action: func(clix *cli.Context) error {
+		var group cmd.Group
+		if _, err := toml.DecodeFile(clix.Args().First(), &group); err != nil {
+			return err
+		}
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		_, err = agent.CreateGroup(Context(), &v1.CreateGroupRequest{
+			Group: group.Proto(),
+		})
+		return err
+	},
+}
+
+var groupUpdateCommand = cli.Command{
+	Name:      "update",
+	Usage:     "update the containers of a group, creating and deleting members",
+	ArgsUsage: "<group.toml>",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "diff",
+			Usage: "show the changes without applying them",
+		},
+	},
+	Action: func(clix *cli.Context) error {
+		var group cmd.Group
+		if _, err := toml.DecodeFile(clix.Args().First(), &group); err != nil {
+			return err
+		}
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.UpdateGroup(Context(), &v1.UpdateGroupRequest{
+			Group:  group.Proto(),
+			DryRun: clix.Bool("diff"),
+		})
+		if err != nil {
+			return err
+		}
+		if !clix.Bool("diff") {
+			return nil
+		}
+		color := terminal.IsTerminal(int(os.Stdout.Fd()))
+		var ids []string
+		for id := range resp.Diffs {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			printDiff(os.Stdout, id, resp.Diffs[id], color)
+		}
+		for _, id := range resp.Created {
+			fmt.Printf("create %s\n", id)
+		}
+		for _, id := range resp.Deleted {
+			fmt.Printf("delete %s\n", id)
+		}
+		return nil
+	},
+}
+
+var groupDeleteCommand = cli.Command{
+	Name:      "delete",
+	Usage:     "delete the containers of a group",
+	ArgsUsage: "<id>",
+	Action: func(clix *cli.Context) error {
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		_, err = agent.DeleteGroup(Context(), &v1.DeleteGroupRequest{
+			ID: clix.Args().First(),
+		})
+		return err
+	},
+}
+
+var groupStatusCommand = cli.Command{
+	Name:      "status",
+	Usage:     "show the containers of a group",
+	ArgsUsage: "[id]",
+	Action: func(clix *cli.Context) error {
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.GroupStatus(Context(), &v1.GroupStatusRequest{
+			ID: clix.Args().First(),
+		})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+		const tfmt = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n"
+		fmt.Fprint(w, "ID\tIMAGE\tSTATUS\tIP\tCPU\tMEMORY\tPIDS\tSIZE\tREVISIONS\n")
+		for _, g := range resp.Groups {
+			fmt.Fprintf(w, "%s\t\t%d containers\n", g.ID, len(g.Containers))
+			for _, c := range g.Containers {
+				printContainer(w, tfmt, "  "+c.ID, c)
+			}
+			for _, id := range g.Missing {
+				fmt.Fprintf(w, "  %s\t\tmissing\n", id)
+			}
+		}
+		return w.Flush()
+	},
+}
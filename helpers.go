@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/opts"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const sidecarStopTimeout = 10 * time.Second
+
+// runInit runs the init containers in order, each must exit successfully
+// before the next one is started
+func runInit(ctx context.Context, client *containerd.Client, parent containerd.Container, config *v1.Container, volumeRoot string) error {
+	for _, h := range config.Init {
+		container, err := newHelper(ctx, client, parent, opts.HelperInit, config, h, volumeRoot)
+		if err != nil {
+			return errors.Wrapf(err, "create init %s", h.Name)
+		}
+		code, err := runHelper(ctx, container)
+		if derr := deleteHelper(ctx, container); derr != nil {
+			logrus.WithError(derr).Errorf("delete init %s", h.Name)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "run init %s", h.Name)
+		}
+		if code != 0 {
+			return errors.Errorf("init %s exited with %d", h.Name, code)
+		}
+	}
+	return nil
+}
+
+func runHelper(ctx context.Context, container containerd.Container) (uint32, error) {
+	task, err := container.NewTask(ctx, cio.NewCreator(cio.WithStdio))
+	if err != nil {
+		return 0, err
+	}
+	defer task.Delete(ctx, containerd.WithProcessKill)
+	wait, err := task.Wait(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := task.Start(ctx); err != nil {
+		return 0, err
+	}
+	status := <-wait
+	code, _, err := status.Result()
+	return code, err
+}
+
+// startSidecars starts the sidecars of the container and returns a func
+// that stops and removes them
+func startSidecars(ctx context.Context, client *containerd.Client, parent containerd.Container, config *v1.Container, volumeRoot string) (func(), error) {
+	var started []containerd.Container
+	stop := func() {
+		for _, container := range started {
+			if err := stopSidecar(ctx, container); err != nil {
+				logrus.WithError(err).Errorf("stop sidecar %s", container.ID())
+			}
+		}
+	}
+	for _, h := range config.Sidecars {
+		container, err := newHelper(ctx, client, parent, opts.HelperSidecar, config, h, volumeRoot)
+		if err != nil {
+			stop()
+			return nil, errors.Wrapf(err, "create sidecar %s", h.Name)
+		}
+		started = append(started, container)
+		task, err := container.NewTask(ctx, cio.NewCreator(cio.WithStdio))
+		if err != nil {
+			stop()
+			return nil, errors.Wrapf(err, "create sidecar %s task", h.Name)
+		}
+		if err := task.Start(ctx); err != nil {
+			stop()
+			return nil, errors.Wrapf(err, "start sidecar %s", h.Name)
+		}
+	}
+	return stop, nil
+}
+
+// stopSidecar sends SIGTERM to the sidecar and kills it if it has not exited in time
+func stopSidecar(ctx context.Context, container containerd.Container) error {
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if !errdefs.IsNotFound(err) {
+			return err
+		}
+		return deleteHelper(ctx, container)
+	}
+	wait, err := task.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	if err := task.Kill(ctx, unix.SIGTERM); err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+	select {
+	case <-wait:
+	case <-time.After(sidecarStopTimeout):
+	}
+	return deleteHelper(ctx, container)
+}
+
+func newHelper(ctx context.Context, client *containerd.Client, parent containerd.Container, kind string, config *v1.Container, h *v1.Helper, volumeRoot string) (containerd.Container, error) {
+	// images are pulled by the agent when the container is created or updated
+	image, err := client.GetImage(ctx, h.Image)
+	if err != nil {
+		return nil, err
+	}
+	id := opts.HelperID(parent.ID(), kind, h.Name)
+	return client.NewContainer(ctx, id,
+		containerd.WithNewSnapshot(id, image),
+		opts.WithHelper(volumeRoot, parent.ID(), config, h, image),
+	)
+}
+
+func deleteHelper(ctx context.Context, container containerd.Container) error {
+	task, err := container.Task(ctx, nil)
+	if err == nil {
+		if _, err := task.Delete(ctx, containerd.WithProcessKill); err != nil && !errdefs.IsNotFound(err) {
+			return err
+		}
+	} else if !errdefs.IsNotFound(err) {
+		return err
+	}
+	return container.Delete(ctx, containerd.WithSnapshotCleanup)
+}
+
+// cleanupHelpers removes init and sidecar containers left behind by a previous run
+func cleanupHelpers(ctx context.Context, client *containerd.Client, id string) error {
+	containers, err := client.Containers(ctx, fmt.Sprintf("labels.%q==%q", opts.HelperLabel, id))
+	if err != nil {
+		return err
+	}
+	for _, c := range containers {
+		if err := deleteHelper(ctx, c); err != nil {
+			return errors.Wrapf(err, "delete %s", c.ID())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/urfave/cli"
+)
+
+var jobsCommand = cli.Command{
+	Name:      "jobs",
+	Usage:     "list job containers and their recent runs",
+	ArgsUsage: "[id]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "logs",
+			Usage: "include the logs of each run",
+		},
+	},
+	Action: func(clix *cli.Context) error {
+		var (
+			id  = clix.Args().First()
+			ctx = Context()
+		)
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.Jobs(ctx, &v1.JobsRequest{
+			ID:   id,
+			Logs: clix.Bool("logs"),
+		})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+		if id == "" {
+			const tfmt = "%s\t%s\t%s\t%s\t%s\t%s\n"
+			fmt.Fprint(w, "ID\tSCHEDULE\tNEXT\tLAST RUN\tEXIT\tDURATION\n")
+			for _, j := range resp.Jobs {
+				last, exit, duration := "-", "-", "-"
+				if len(j.Runs) > 0 {
+					r := j.Runs[len(j.Runs)-1]
+					last, exit, duration = runStarted(r), runExit(r), runDuration(r)
+				}
+				fmt.Fprintf(w, tfmt,
+					j.ID,
+					orDash(j.Schedule),
+					orDash(j.NextRun),
+					last,
+					exit,
+					duration,
+				)
+			}
+			return w.Flush()
+		}
+		const tfmt = "%s\t%s\t%s\t%s\n"
+		fmt.Fprint(w, "STARTED\tEXIT\tDURATION\tERROR\n")
+		for _, j := range resp.Jobs {
+			for _, r := range j.Runs {
+				fmt.Fprintf(w, tfmt,
+					runStarted(r),
+					runExit(r),
+					runDuration(r),
+					orDash(r.Error),
+				)
+				if r.Logs != "" {
+					for _, l := range strings.Split(strings.TrimRight(r.Logs, "\n"), "\n") {
+						fmt.Fprintf(w, "\t%s\n", l)
+					}
+				}
+			}
+		}
+		return w.Flush()
+	},
+}
+
+func runStarted(r *v1.JobRun) string {
+	return r.Started.Local().Format(time.RFC3339)
+}
+
+func runExit(r *v1.JobRun) string {
+	if r.Finished.IsZero() {
+		return "running"
+	}
+	return fmt.Sprint(r.ExitCode)
+}
+
+func runDuration(r *v1.JobRun) string {
+	if r.Finished.IsZero() {
+		return time.Since(r.Started).Truncate(time.Second).String()
+	}
+	return r.Finished.Sub(r.Started).Truncate(time.Second).String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/pkg/errors"
+)
+
+// MaxRuns is the number of runs kept for each job
+const MaxRuns = 20
+
+// Run is a single run of a job container
+type Run struct {
+	// Invocation is the systemd invocation id of the run used to find its logs
+	Invocation string    `json:"invocation"`
+	Started    time.Time `json:"started"`
+	Finished   time.Time `json:"finished,omitempty"`
+	ExitCode   int       `json:"exit_code"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func path(id string) string {
+	return filepath.Join(v1.Root, "jobs", id+".json")
+}
+
+// Runs returns the recorded runs of the job, oldest first
+func Runs(id string) ([]*Run, error) {
+	data, err := ioutil.ReadFile(path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var runs []*Run
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// Record saves the run, replacing an earlier record of the same invocation
+func Record(id string, r *Run) error {
+	runs, err := Runs(id)
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i, existing := range runs {
+		if r.Invocation != "" && existing.Invocation == r.Invocation {
+			runs[i] = r
+			replaced = true
+		}
+	}
+	if !replaced {
+		runs = append(runs, r)
+	}
+	if len(runs) > MaxRuns {
+		runs = runs[len(runs)-MaxRuns:]
+	}
+	data, err := json.Marshal(runs)
+	if err != nil {
+		return err
+	}
+	p := path(id)
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(p), ".run")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
+
+// Remove the recorded runs of the job
+func Remove(id string) error {
+	if err := os.Remove(path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Logs returns the last lines the run wrote to the journal
+func Logs(ctx context.Context, r *Run, lines int) (string, error) {
+	if r.Invocation == "" {
+		return "", nil
+	}
+	out, err := exec.CommandContext(ctx, "journalctl",
+		"--no-pager",
+		"--output=cat",
+		"--lines="+strconv.Itoa(lines),
+		"_SYSTEMD_INVOCATION_ID="+r.Invocation,
+	).Output()
+	if err != nil {
+		return "", errors.Wrap(err, "read journal")
+	}
+	return string(out), nil
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"text/tabwriter"
 	"time"
 
@@ -28,19 +30,49 @@ var listCommand = cli.Command{
 		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
 		const tfmt = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n"
 		fmt.Fprint(w, "ID\tIMAGE\tSTATUS\tIP\tCPU\tMEMORY\tPIDS\tSIZE\tREVISIONS\n")
+		// group replicas under the id of their replicated container
+		var (
+			order  []string
+			groups = make(map[string][]*v1.ContainerInfo)
+		)
 		for _, c := range resp.Containers {
-			fmt.Fprintf(w, tfmt,
-				c.ID,
-				c.Image,
-				c.Status,
-				c.IP,
-				time.Duration(int64(c.Cpu)),
-				fmt.Sprintf("%s/%s", units.HumanSize(c.MemoryUsage), units.HumanSize(c.MemoryLimit)),
-				fmt.Sprintf("%d/%d", c.PidUsage, c.PidLimit),
-				units.HumanSize(float64(c.FsSize)),
-				len(c.Snapshots),
-			)
+			key := c.ID
+			if c.Group != "" {
+				key = "group:" + c.Group
+			}
+			if _, ok := groups[key]; !ok {
+				order = append(order, key)
+			}
+			groups[key] = append(groups[key], c)
+		}
+		for _, key := range order {
+			containers := groups[key]
+			if containers[0].Group == "" {
+				printContainer(w, tfmt, containers[0].ID, containers[0])
+				continue
+			}
+			sort.Slice(containers, func(i, j int) bool {
+				return containers[i].ID < containers[j].ID
+			})
+			fmt.Fprintf(w, "%s\t%s\t%d replicas\n", containers[0].Group, containers[0].Image, len(containers))
+			for _, c := range containers {
+				printContainer(w, tfmt, "  "+c.ID, c)
+			}
 		}
 		return w.Flush()
 	},
 }
+
+func printContainer(w io.Writer, tfmt, id string, c *v1.ContainerInfo) {
+	fmt.Fprintf(w, tfmt,
+		id,
+		c.Image,
+		c.Status,
+		c.IP,
+		time.Duration(int64(c.Cpu)),
+		fmt.Sprintf("%s/%s", units.HumanSize(c.MemoryUsage), units.HumanSize(c.MemoryLimit)),
+		fmt.Sprintf("%d/%d", c.PidUsage, c.PidLimit),
+		units.HumanSize(float64(c.FsSize)),
+		len(c.Snapshots),
+	)
+}
@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"os"
 
 	"github.com/containerd/containerd/namespaces"
 	"github.com/crosbymichael/boss/api"
 	"github.com/crosbymichael/boss/api/v1"
+	"github.com/crosbymichael/boss/config"
 	"github.com/crosbymichael/boss/version"
 	raven "github.com/getsentry/raven-go"
 	"github.com/sirupsen/logrus"
@@ -44,10 +46,29 @@ run containers like a boss`
 		},
 		cli.StringFlag{
 			Name:   "agent",
-			Usage:  "agent address",
-			Value:  "0.0.0.0:1337",
+			Usage:  "agent address, defaults to the local socket when present or " + v1.DefaultAddress,
 			EnvVar: "BOSS_AGENT",
 		},
+		cli.StringFlag{
+			Name:   "tls-ca",
+			Usage:  "ca used to verify the agent",
+			EnvVar: "BOSS_TLS_CA",
+		},
+		cli.StringFlag{
+			Name:   "tls-cert",
+			Usage:  "client certificate presented to the agent",
+			EnvVar: "BOSS_TLS_CERT",
+		},
+		cli.StringFlag{
+			Name:   "tls-key",
+			Usage:  "client key presented to the agent",
+			EnvVar: "BOSS_TLS_KEY",
+		},
+		cli.StringFlag{
+			Name:   "tls-server-name",
+			Usage:  "override the server name used to verify the agent",
+			EnvVar: "BOSS_TLS_SERVER_NAME",
+		},
 		cli.StringFlag{
 			Name:   "sentry-dsn",
 			Usage:  "sentry DSN",
@@ -66,12 +87,17 @@ run containers like a boss`
 	}
 	app.Commands = []cli.Command{
 		agentCommand,
+		auditCommand,
 		buildCommand,
+		certsCommand,
 		checkpointCommand,
 		createCommand,
 		deleteCommand,
+		doctorCommand,
 		getCommand,
+		groupCommand,
 		initCommand,
+		jobsCommand,
 		killCommand,
 		listCommand,
 		migrateCommand,
@@ -79,10 +105,13 @@ run containers like a boss`
 		pushCommand,
 		restoreCommand,
 		rollbackCommand,
+		runCommand,
+		scaleCommand,
 		startCommand,
 		stopCommand,
 		systemdCommand,
 		updateCommand,
+		volumeCommand,
 	}
 	if err := app.Run(os.Args); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -96,5 +125,29 @@ func Context() context.Context {
 }
 
 func Agent(clix *cli.Context) (*api.LocalAgent, error) {
-	return api.Agent(clix.GlobalString("agent"))
+	var tlsConfig *tls.Config
+	if cert := clix.GlobalString("tls-cert"); cert != "" {
+		t := &config.TLS{
+			CA:         clix.GlobalString("tls-ca"),
+			Cert:       cert,
+			Key:        clix.GlobalString("tls-key"),
+			ServerName: clix.GlobalString("tls-server-name"),
+		}
+		c, err := t.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig = c
+	}
+	return api.Agent(agentAddress(clix), tlsConfig)
+}
+
+func agentAddress(clix *cli.Context) string {
+	if address := clix.GlobalString("agent"); address != "" {
+		return address
+	}
+	if _, err := os.Stat(v1.DefaultSocket); err == nil {
+		return "unix://" + v1.DefaultSocket
+	}
+	return v1.DefaultAddress
 }
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/urfave/cli"
 )
@@ -15,11 +18,11 @@ var migrateCommand = cli.Command{
 		},
 		cli.BoolFlag{
 			Name:  "stop",
-			Usage: "stop the container after a successful checkpoint",
+			Usage: "stop the container once it is healthy on the destination agent",
 		},
 		cli.BoolFlag{
 			Name:  "delete",
-			Usage: "delete the container on the local agent after a successful checkpoint",
+			Usage: "delete the container on the local agent once it is healthy on the destination agent",
 		},
 
 		cli.StringFlag{
@@ -30,6 +33,24 @@ var migrateCommand = cli.Command{
 			Name:  "to",
 			Usage: "destination agent",
 		},
+		cli.IntFlag{
+			Name:  "pre-dumps",
+			Usage: "number of memory pre-dumps to push while the container keeps running before a live checkpoint",
+		},
+		cli.BoolFlag{
+			Name:  "registry",
+			Usage: "transfer the checkpoint through the ref's registry instead of sending it to the destination agent",
+		},
+		cli.StringSliceFlag{
+			Name:  "volume",
+			Usage: "volume to migrate, all of the container's volumes by default",
+			Value: &cli.StringSlice{},
+		},
+		cli.StringSliceFlag{
+			Name:  "exclude-volume",
+			Usage: "volume to leave out",
+			Value: &cli.StringSlice{},
+		},
 	},
 
 	Action: func(clix *cli.Context) error {
@@ -39,14 +60,33 @@ var migrateCommand = cli.Command{
 			return err
 		}
 		defer agent.Close()
-		_, err = agent.Migrate(ctx, &v1.MigrateRequest{
-			ID:     clix.Args().First(),
-			Ref:    clix.String("ref"),
-			Stop:   clix.Bool("stop"),
-			Delete: clix.Bool("delete"),
-			To:     clix.String("to"),
-			Live:   clix.Bool("live"),
+		stream, err := agent.Migrate(ctx, &v1.MigrateRequest{
+			ID:             clix.Args().First(),
+			Ref:            clix.String("ref"),
+			Stop:           clix.Bool("stop"),
+			Delete:         clix.Bool("delete"),
+			To:             clix.String("to"),
+			Live:           clix.Bool("live"),
+			Volumes:        clix.StringSlice("volume"),
+			ExcludeVolumes: clix.StringSlice("exclude-volume"),
+			PreDumps:       int64(clix.Int("pre-dumps")),
+			Registry:       clix.Bool("registry"),
 		})
-		return err
+		if err != nil {
+			return err
+		}
+		// phases are printed as they complete so the columns are fixed width
+		const format = "%-24s%s\n"
+		fmt.Printf(format, "PHASE", "DURATION")
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf(format, resp.Phase.Name, resp.Phase.Duration)
+		}
 	},
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"os"
+	"unsafe"
 
 	"github.com/urfave/cli"
 	"golang.org/x/sys/unix"
@@ -32,6 +33,27 @@ var networkCreateCommand = cli.Command{
 		if err := f.Close(); err != nil {
 			return err
 		}
-		return unix.Mount("/proc/self/ns/net", path, "none", unix.MS_BIND, "")
+		if err := unix.Mount("/proc/self/ns/net", path, "none", unix.MS_BIND, ""); err != nil {
+			return err
+		}
+		return loopbackUp()
 	},
 }
+
+// loopbackUp sets the loopback interface of the namespace up, containers on the
+// none network only have loopback and runc does not configure joined namespaces
+func loopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+	// struct ifreq is the interface name followed by its flags
+	var ifr [unix.IFNAMSIZ + 24]byte
+	copy(ifr[:], "lo")
+	*(*uint16)(unsafe.Pointer(&ifr[unix.IFNAMSIZ])) = unix.IFF_UP | unix.IFF_LOOPBACK | unix.IFF_RUNNING
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.SIOCSIFFLAGS, uintptr(unsafe.Pointer(&ifr[0]))); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package opts
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/contrib/apparmor"
+	"github.com/containerd/containerd/contrib/seccomp"
+	"github.com/containerd/containerd/oci"
+	"github.com/crosbymichael/boss/api/v1"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// HelperLabel is set on init and sidecar containers to the containerd id of their container
+	HelperLabel = "io/boss/helper.of"
+
+	HelperInit    = "init"
+	HelperSidecar = "sidecar"
+)
+
+// HelperID returns the containerd id of an init or sidecar container
+func HelperID(parent, kind, name string) string {
+	return fmt.Sprintf("%s.%s.%s", parent, kind, name)
+}
+
+// WithHelper is a containerd.NewContainerOpts for an init or sidecar container
+// that joins the network namespace of its parent container
+func WithHelper(volumeRoot, parent string, config *v1.Container, h *v1.Helper, image containerd.Image) containerd.NewContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		if c.Labels == nil {
+			c.Labels = make(map[string]string)
+		}
+		c.Labels[HelperLabel] = parent
+		return containerd.WithNewSpec(helperSpecOpt(volumeRoot, parent, config, h, image))(ctx, client, c)
+	}
+}
+
+func helperSpecOpt(volumeRoot, parent string, config *v1.Container, h *v1.Helper, image containerd.Image) oci.SpecOpts {
+	process := h.Process
+	if process == nil {
+		process = &v1.Process{}
+	}
+	opts := []oci.SpecOpts{
+		oci.WithImageConfigArgs(image, process.Args),
+		oci.WithHostLocaltime,
+		oci.WithNoNewPrivileges,
+		apparmor.WithDefaultProfile("boss"),
+		seccomp.WithDefaultProfile(),
+		oci.WithEnv(process.Env),
+		withVolumes(volumeRoot, h.Volumes),
+	}
+	if config.Network == "host" {
+		opts = append(opts, oci.WithHostHostsFile, oci.WithHostResolvconf, oci.WithHostNamespace(specs.NetworkNamespace))
+	} else if v1.IsCNI(config.Network) {
+		opts = append(opts, withParentNetwork(parent), withParentFiles(parent), oci.WithHostname(config.ID))
+	} else {
+		opts = append(opts, withParentNetwork(parent))
+	}
+	if process.User != nil {
+		opts = append(opts, oci.WithUIDGID(process.User.Uid, process.User.Gid))
+	}
+	opts = append(opts, withProcessCaps(process.Capabilities))
+	return oci.Compose(opts...)
+}
+
+// withParentNetwork joins the parent's network namespace
+func withParentNetwork(parent string) oci.SpecOpts {
+	return oci.WithLinuxNamespace(specs.LinuxNamespace{
+		Type: specs.NetworkNamespace,
+		Path: v1.NetworkPath(parent),
+	})
+}
+
+// withParentFiles uses the hosts and resolv.conf of the parent's cni network
+func withParentFiles(parent string) oci.SpecOpts {
+	return func(ctx context.Context, client oci.Client, c *containers.Container, s *oci.Spec) error {
+		for _, name := range []string{"hosts", "resolv.conf"} {
+			s.Mounts = append(s.Mounts, specs.Mount{
+				Destination: filepath.Join("/etc", name),
+				Type:        "bind",
+				Source:      filepath.Join(v1.Root, parent, name),
+				Options:     []string{"rbind", "ro"},
+			})
+		}
+		return nil
+	}
+}
@@ -2,10 +2,13 @@ package opts
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/containerd/containerd"
@@ -26,6 +29,12 @@ const (
 	CurrentConfig          = "io.boss/container"
 	LastConfig             = "io.boss/container.last"
 	IPLabel                = "io/boss/container.ip"
+	IP6Label               = "io/boss/container.ip6"
+	InterfacesLabel        = "io/boss/container.interfaces"
+	UnregisteredLabel      = "io/boss/container.unregistered"
+	IDLabel                = "io/boss/container.id"
+	GroupLabel             = "io/boss/container.group"
+	ReplicaLabel           = "io/boss/container.replica"
 	RestoreCheckpointLabel = "io/boss/restore.checkpoint"
 )
 
@@ -46,6 +55,19 @@ func WithSetPreviousConfig(ctx context.Context, client *containerd.Client, c *co
 	return nil
 }
 
+// GetLastConfig returns the config the container had before its last update, nil if there is none
+func GetLastConfig(ctx context.Context, container containerd.Container) (*v1.Container, error) {
+	info, err := container.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d, ok := info.Extensions[LastConfig]
+	if !ok || d.Value == nil {
+		return nil, nil
+	}
+	return UnmarshalConfig(&d)
+}
+
 func WithRollback(ctx context.Context, client *containerd.Client, c *containers.Container) error {
 	d := c.Extensions[LastConfig]
 	if d.Value == nil {
@@ -69,13 +91,12 @@ func specOpt(volumeRoot string, config *v1.Container, image containerd.Image) oc
 	}
 	if config.Network == "host" {
 		opts = append(opts, oci.WithHostHostsFile, oci.WithHostResolvconf, oci.WithHostNamespace(specs.NetworkNamespace))
-	} else if config.Network == "cni" {
-		opts = append(opts, withBossResolvconf, withContainerHostsFile, oci.WithLinuxNamespace(specs.LinuxNamespace{
-			Type: specs.NetworkNamespace,
-			Path: v1.NetworkPath(config.ID),
-		}),
+	} else if v1.IsCNI(config.Network) {
+		opts = append(opts, withBossResolvconf, withContainerHostsFile, withNetworkNamespace,
 			oci.WithHostname(config.ID),
 		)
+	} else {
+		opts = append(opts, withNetworkNamespace)
 	}
 	if config.Resources != nil {
 		opts = append(opts, withResources(config.Resources))
@@ -261,6 +282,15 @@ func withConfigs(files map[string]*v1.Config) oci.SpecOpts {
 	}
 }
 
+// withNetworkNamespace joins the namespace created for the containerd container.
+// It is named by the containerd id as siblings of a container share its config
+func withNetworkNamespace(ctx context.Context, client oci.Client, c *containers.Container, s *oci.Spec) error {
+	return oci.WithLinuxNamespace(specs.LinuxNamespace{
+		Type: specs.NetworkNamespace,
+		Path: v1.NetworkPath(c.ID),
+	})(ctx, client, c, s)
+}
+
 func withContainerHostsFile(ctx context.Context, _ oci.Client, c *containers.Container, s *oci.Spec) error {
 	id := c.ID
 	if err := os.MkdirAll(filepath.Join(v1.Root, id), 0711); err != nil {
@@ -270,8 +300,42 @@ func withContainerHostsFile(ctx context.Context, _ oci.Client, c *containers.Con
 	if hostname == "" {
 		hostname = id
 	}
-	path := filepath.Join(v1.Root, id, "hosts")
-	f, err := os.Create(path)
+	path := hostsPath(id)
+	if err := writeHostsFile(path, hostname, nil); err != nil {
+		return err
+	}
+	s.Mounts = append(s.Mounts, specs.Mount{
+		Destination: "/etc/hosts",
+		Type:        "bind",
+		Source:      path,
+		Options:     []string{"rbind", "ro"},
+	})
+	return nil
+}
+
+// UpdateHostsFile points the container's hostname at its ips once its network is set up
+func UpdateHostsFile(id, hostname string, ips []string) error {
+	path := hostsPath(id)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if hostname == "" {
+		hostname = id
+	}
+	return writeHostsFile(path, hostname, ips)
+}
+
+func hostsPath(id string) string {
+	return filepath.Join(v1.Root, id, "hosts")
+}
+
+// writeHostsFile writes the hosts file in place as it is bind mounted into the container,
+// the hostname resolves to loopback until the container's ips are known
+func writeHostsFile(path, hostname string, ips []string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
 	if err != nil {
 		return err
 	}
@@ -279,22 +343,22 @@ func withContainerHostsFile(ctx context.Context, _ oci.Client, c *containers.Con
 	if err := f.Chmod(0666); err != nil {
 		return err
 	}
-	if _, err := f.WriteString("127.0.0.1       localhost\n"); err != nil {
-		return err
+	lines := []string{
+		"127.0.0.1       localhost",
+		"::1     localhost ip6-localhost ip6-loopback",
+		"fe00::0 ip6-localnet",
+		"ff00::0 ip6-mcastprefix",
+		"ff02::1 ip6-allnodes",
+		"ff02::2 ip6-allrouters",
 	}
-	if _, err := f.WriteString(fmt.Sprintf("127.0.0.1       %s\n", hostname)); err != nil {
-		return err
+	if len(ips) == 0 {
+		lines = append(lines, "127.0.0.1       "+hostname)
 	}
-	if _, err := f.WriteString("::1     localhost ip6-localhost ip6-loopback\n"); err != nil {
-		return err
+	for _, ip := range ips {
+		lines = append(lines, fmt.Sprintf("%-15s %s", ip, hostname))
 	}
-	s.Mounts = append(s.Mounts, specs.Mount{
-		Destination: "/etc/hosts",
-		Type:        "bind",
-		Source:      path,
-		Options:     []string{"rbind", "ro"},
-	})
-	return nil
+	_, err = f.WriteString(strings.Join(lines, "\n") + "\n")
+	return err
 }
 
 func withBossResolvconf(ctx context.Context, _ oci.Client, c *containers.Container, s *oci.Spec) error {
@@ -334,17 +398,154 @@ func UnmarshalConfig(any *types.Any) (*v1.Container, error) {
 	return c, nil
 }
 
-// WithIP sets the ip on the container
-func WithIP(ip string) containerd.UpdateContainerOpts {
+// WithIPs sets the container's ips, the first is its primary ip and the ipv6 address
+// of dual-stack containers is also set on its own label
+func WithIPs(ips []string) containerd.UpdateContainerOpts {
 	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
 		if c.Labels == nil {
 			c.Labels = make(map[string]string)
 		}
-		c.Labels[IPLabel] = ip
+		delete(c.Labels, IPLabel)
+		delete(c.Labels, IP6Label)
+		for _, ip := range ips {
+			if c.Labels[IPLabel] == "" {
+				c.Labels[IPLabel] = ip
+			}
+			if isIP6(ip) && c.Labels[IP6Label] == "" {
+				c.Labels[IP6Label] = ip
+			}
+		}
 		return nil
 	}
 }
 
+// IPs returns the container's ips from its labels, ipv4 first
+func IPs(labels map[string]string) []string {
+	var ips []string
+	if ip := labels[IPLabel]; ip != "" {
+		ips = append(ips, ip)
+	}
+	if ip := labels[IP6Label]; ip != "" && ip != labels[IPLabel] {
+		ips = append(ips, ip)
+	}
+	return ips
+}
+
+// WithInterfaces sets the container's interfaces, the ips of the first are set as its ips
+func WithInterfaces(interfaces []*v1.NetworkInterface) containerd.UpdateContainerOpts {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		var ips []string
+		if len(interfaces) > 0 {
+			ips = interfaces[0].IPs
+		}
+		if err := WithIPs(ips)(ctx, client, c); err != nil {
+			return err
+		}
+		if len(interfaces) == 0 {
+			delete(c.Labels, InterfacesLabel)
+			return nil
+		}
+		data, err := json.Marshal(interfaces)
+		if err != nil {
+			return err
+		}
+		c.Labels[InterfacesLabel] = string(data)
+		return nil
+	}
+}
+
+// Interfaces returns the container's interfaces from its labels
+func Interfaces(labels map[string]string) ([]*v1.NetworkInterface, error) {
+	data, ok := labels[InterfacesLabel]
+	if !ok {
+		return nil, nil
+	}
+	var interfaces []*v1.NetworkInterface
+	if err := json.Unmarshal([]byte(data), &interfaces); err != nil {
+		return nil, err
+	}
+	return interfaces, nil
+}
+
+// ServiceIPs returns the ips that the service is registered at, on its network or the container's
+func ServiceIPs(labels map[string]string, s *v1.Service) ([]string, error) {
+	interfaces, err := Interfaces(labels)
+	if err != nil {
+		return nil, err
+	}
+	if interfaces == nil && s.Network == "" {
+		return IPs(labels), nil
+	}
+	return NetworkIPs(interfaces, s.Network), nil
+}
+
+// NetworkIPs returns the ips of the interface on the network, or of the first interface for the container's network
+func NetworkIPs(interfaces []*v1.NetworkInterface, network string) []string {
+	for i, iface := range interfaces {
+		if (network == "" && i == 0) || iface.Network == network {
+			return iface.IPs
+		}
+	}
+	return nil
+}
+
+func isIP6(ip string) bool {
+	i := net.ParseIP(ip)
+	return i != nil && i.To4() == nil
+}
+
+// WithID labels a containerd container with the logical id of the boss container it runs
+func WithID(id string) func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		if c.Labels == nil {
+			c.Labels = make(map[string]string)
+		}
+		c.Labels[IDLabel] = id
+		return nil
+	}
+}
+
+// WithUnregistered marks the container's services as not registered by its unit, for a blue/green
+// sibling until it is healthy and for the container it replaces once the sibling took over its services
+func WithUnregistered(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+	if c.Labels == nil {
+		c.Labels = make(map[string]string)
+	}
+	c.Labels[UnregisteredLabel] = "true"
+	return nil
+}
+
+// WithRegistered lets the container's unit register its services again
+func WithRegistered(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+	delete(c.Labels, UnregisteredLabel)
+	return nil
+}
+
+// Unregistered returns true if the container's services are not registered by its unit
+func Unregistered(labels map[string]string) bool {
+	return labels[UnregisteredLabel] == "true"
+}
+
+// WithReplica labels a containerd container as a replica of the replicated container
+func WithReplica(group string, replica int) func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+		if c.Labels == nil {
+			c.Labels = make(map[string]string)
+		}
+		c.Labels[GroupLabel] = group
+		c.Labels[ReplicaLabel] = strconv.Itoa(replica)
+		return nil
+	}
+}
+
+// ID returns the logical id of the boss container
+func ID(c containers.Container) string {
+	if id := c.Labels[IDLabel]; id != "" {
+		return id
+	}
+	return c.ID
+}
+
 func WithRestore(m *is.Descriptor) containerd.NewContainerOpts {
 	return func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
 		if c.Extensions == nil {
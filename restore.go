@@ -1,7 +1,10 @@
 package main
 
 import (
+	"strings"
+
 	"github.com/crosbymichael/boss/api/v1"
+	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 )
 
@@ -13,8 +16,33 @@ var restoreCommand = cli.Command{
 			Name:  "live",
 			Usage: "enable live restore(criu must be installed)",
 		},
+		cli.StringFlag{
+			Name:  "id",
+			Usage: "restore as a new container with this id",
+		},
+		cli.StringFlag{
+			Name:  "network",
+			Usage: "override the network of the container",
+		},
+		cli.Float64Flag{
+			Name:  "cpus",
+			Usage: "override the cpus of the container",
+		},
+		cli.Int64Flag{
+			Name:  "memory",
+			Usage: "override the memory of the container in mb",
+		},
+		cli.StringSliceFlag{
+			Name:  "volume",
+			Usage: "replace the volumes of the container as id:destination[:rw]",
+			Value: &cli.StringSlice{},
+		},
 	},
 	Action: func(clix *cli.Context) error {
+		overrides, err := restoreOverrides(clix)
+		if err != nil {
+			return err
+		}
 		ctx := Context()
 		agent, err := Agent(clix)
 		if err != nil {
@@ -22,9 +50,42 @@ var restoreCommand = cli.Command{
 		}
 		defer agent.Close()
 		_, err = agent.Restore(ctx, &v1.RestoreRequest{
-			Ref:  clix.Args().First(),
-			Live: clix.Bool("live"),
+			Ref:       clix.Args().First(),
+			Live:      clix.Bool("live"),
+			ID:        clix.String("id"),
+			Network:   clix.String("network"),
+			Overrides: overrides,
 		})
 		return err
 	},
 }
+
+func restoreOverrides(clix *cli.Context) (*v1.Container, error) {
+	var (
+		c   v1.Container
+		set bool
+	)
+	if clix.IsSet("cpus") || clix.IsSet("memory") {
+		c.Resources = &v1.Resources{
+			Cpus:   clix.Float64("cpus"),
+			Memory: clix.Int64("memory"),
+		}
+		set = true
+	}
+	for _, v := range clix.StringSlice("volume") {
+		parts := strings.Split(v, ":")
+		if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != "rw") {
+			return nil, errors.Errorf("invalid volume %q", v)
+		}
+		c.Volumes = append(c.Volumes, &v1.Volume{
+			ID:          parts[0],
+			Destination: parts[1],
+			Rw:          len(parts) == 3,
+		})
+		set = true
+	}
+	if !set {
+		return nil, nil
+	}
+	return &c, nil
+}
@@ -7,36 +7,59 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Interface is the macvlan bridge of the [cni] network
 const Interface = "mvlan0"
 
-func Create(iface, address string) (err error) {
+// Create the macvlan bridge link to the containers with the host's addresses on it,
+// address6 is optional and only needed for ipv6 containers
+func Create(link, iface, address, address6 string) (err error) {
 	// don't create if it already exists
-	if _, err := net.InterfaceByName(Interface); err == nil {
+	if _, err := net.InterfaceByName(link); err == nil {
 		return nil
 	}
 	defer func() {
 		if err != nil {
-			ip("link", "del", Interface)
+			ip("link", "del", link)
 		}
 	}()
-	if err := ip("link", "add", "link", iface, Interface, "type", "macvlan", "mode", "bridge"); err != nil {
+	if err := ip("link", "add", "link", iface, link, "type", "macvlan", "mode", "bridge"); err != nil {
 		return err
 	}
-	if err := ip("address", "add", address, "dev", Interface); err != nil {
+	if err := ip("address", "add", address, "dev", link); err != nil {
 		return err
 	}
-	if err := ip("link", "set", "dev", Interface, "up"); err != nil {
+	if address6 != "" {
+		if err := ip("-6", "address", "add", address6, "dev", link); err != nil {
+			return err
+		}
+	}
+	if err := ip("link", "set", "dev", link, "up"); err != nil {
 		return err
 	}
-	return ip("route", "flush", "dev", Interface)
+	if err := ip("route", "flush", "dev", link); err != nil {
+		return err
+	}
+	if address6 != "" {
+		return ip("-6", "route", "flush", "dev", link)
+	}
+	return nil
+}
+
+// Add a host route over the link to the container's ipv4 or ipv6 address
+func Add(link, address string) error {
+	return ip(family(address), "route", "add", address, "dev", link, "metric", "0")
 }
 
-func Add(address string) error {
-	return ip("route", "add", address, "dev", Interface, "metric", "0")
+// Remove the host route over the link to the container's address
+func Remove(link, address string) error {
+	return ip(family(address), "route", "del", address, "dev", link)
 }
 
-func Remove(address string) error {
-	return ip("route", "del", address, "dev", Interface)
+func family(address string) string {
+	if i := net.ParseIP(address); i != nil && i.To4() == nil {
+		return "-6"
+	}
+	return "-4"
 }
 
 func ip(args ...string) error {
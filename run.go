@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/urfave/cli"
+)
+
+var runCommand = cli.Command{
+	Name:      "run",
+	Usage:     "start a run of a job container now",
+	ArgsUsage: "<id>",
+	Action: func(clix *cli.Context) error {
+		var (
+			id  = clix.Args().First()
+			ctx = Context()
+		)
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		_, err = agent.Run(ctx, &v1.RunRequest{
+			ID: id,
+		})
+		return err
+	},
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/crosbymichael/boss/api/v1"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var scaleCommand = cli.Command{
+	Name:      "scale",
+	Usage:     "scale the replicas of a container",
+	ArgsUsage: "<id> <replicas>",
+	Action: func(clix *cli.Context) error {
+		var (
+			id  = clix.Args().First()
+			ctx = Context()
+		)
+		replicas, err := strconv.ParseInt(clix.Args().Get(1), 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "replicas")
+		}
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		_, err = agent.Scale(ctx, &v1.ScaleRequest{
+			ID:       id,
+			Replicas: replicas,
+		})
+		return err
+	},
+}
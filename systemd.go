@@ -12,11 +12,13 @@ import (
 	"time"
 
 	"github.com/containerd/containerd"
+	api "github.com/containerd/containerd/api/types"
 	"github.com/containerd/containerd/cio"
 	"github.com/containerd/containerd/contrib/apparmor"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/crosbymichael/boss/config"
+	"github.com/crosbymichael/boss/jobs"
 	"github.com/crosbymichael/boss/opts"
 	"github.com/crosbymichael/boss/system"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
@@ -80,7 +82,15 @@ var systemdExecStopPostCommand = cli.Command{
 		if err != nil {
 			return err
 		}
-		config, err := opts.GetConfig(ctx, container)
+		info, err := container.Info(ctx)
+		if err != nil {
+			return err
+		}
+		// the services of a replaced blue/green container are registered for its sibling
+		if opts.Unregistered(info.Labels) {
+			return nil
+		}
+		config, err := opts.GetConfigFromInfo(ctx, info)
 		if err != nil {
 			return err
 		}
@@ -89,7 +99,7 @@ var systemdExecStopPostCommand = cli.Command{
 			return err
 		}
 		for name := range config.Services {
-			register.EnableMaintainance(id, name, "task exited")
+			register.EnableMaintainance(config.ID, name, "task exited")
 		}
 		return err
 	},
@@ -122,34 +132,28 @@ var systemdExecStartCommand = cli.Command{
 		if err != nil {
 			return err
 		}
-		c, err := config.Load()
-		if err != nil {
-			return err
-		}
-		register, err := c.GetRegister()
-		if err != nil {
-			return err
-		}
-		store, err := c.Store()
-		if err != nil {
-			return err
-		}
-		templateCh, err := store.Watch(ctx, container, cfg)
-		if err != nil {
-			return err
-		}
-		ip, err := setupNetworking(ctx, container, cfg)
-		if err != nil {
-			return err
-		}
-		if err := container.Update(ctx, opts.WithIP(ip), opts.WithoutRestore); err != nil {
-			return err
+		var run *jobs.Run
+		if v1.IsJob(cfg) {
+			run = &jobs.Run{
+				Invocation: os.Getenv("INVOCATION_ID"),
+				Started:    time.Now(),
+			}
+			if err := jobs.Record(id, run); err != nil {
+				logrus.WithError(err).Error("record job run")
+			}
 		}
-		task, err := container.NewTask(ctx, cio.NewCreator(cio.WithStdio), opts.WithTaskRestore(desc))
-		if err != nil {
-			return err
+		status, err := execStart(ctx, client, container, desc, cfg, signals)
+		if run != nil {
+			run.Finished = time.Now()
+			run.ExitCode = status
+			if err != nil {
+				run.ExitCode = 1
+				run.Error = err.Error()
+			}
+			if err := jobs.Record(id, run); err != nil {
+				logrus.WithError(err).Error("record job run")
+			}
 		}
-		status, err := monitorTask(ctx, client, task, cfg, register, signals, templateCh)
 		if err != nil {
 			return err
 		}
@@ -158,6 +162,57 @@ var systemdExecStartCommand = cli.Command{
 	},
 }
 
+// execStart sets up the container's networking and runs its task until it exits
+func execStart(ctx context.Context, client *containerd.Client, container containerd.Container, desc *api.Descriptor, cfg *v1.Container, signals chan os.Signal) (int, error) {
+	c, err := config.Load()
+	if err != nil {
+		return -1, err
+	}
+	register, err := c.GetRegister()
+	if err != nil {
+		return -1, err
+	}
+	labels, err := container.Labels(ctx)
+	if err != nil {
+		return -1, err
+	}
+	if opts.Unregistered(labels) {
+		// the agent registers a blue/green sibling once it is healthy
+		register = nil
+	}
+	store, err := c.Store()
+	if err != nil {
+		return -1, err
+	}
+	templateCh, err := store.Watch(ctx, container, cfg)
+	if err != nil {
+		return -1, err
+	}
+	interfaces, err := setupNetworking(ctx, container, cfg, register)
+	if err != nil {
+		return -1, err
+	}
+	if err := container.Update(ctx, opts.WithInterfaces(interfaces), opts.WithoutRestore); err != nil {
+		return -1, err
+	}
+	// a restored task has already run its init containers
+	if desc == nil {
+		if err := runInit(ctx, client, container, cfg, c.Agent.VolumeRoot); err != nil {
+			return -1, err
+		}
+	}
+	stopSidecars, err := startSidecars(ctx, client, container, cfg, c.Agent.VolumeRoot)
+	if err != nil {
+		return -1, err
+	}
+	defer stopSidecars()
+	task, err := container.NewTask(ctx, cio.NewCreator(cio.WithStdio), opts.WithTaskRestore(desc))
+	if err != nil {
+		return -1, err
+	}
+	return monitorTask(ctx, client, task, cfg, register, signals, templateCh)
+}
+
 func monitorTask(ctx context.Context, client *containerd.Client, task containerd.Task, config *v1.Container, register v1.Register, signals chan os.Signal, templateCh <-chan error) (int, error) {
 	defer task.Delete(ctx, containerd.WithProcessKill)
 	started := make(chan error, 1)
@@ -176,8 +231,11 @@ func monitorTask(ctx context.Context, client *containerd.Client, task containerd
 			if err != nil {
 				return -1, err
 			}
+			if register == nil {
+				continue
+			}
 			for name := range config.Services {
-				if err := register.DisableMaintainance(task.ID(), name); err != nil {
+				if err := register.DisableMaintainance(config.ID, name); err != nil {
 					logrus.WithError(err).Error("disable service maintenance")
 				}
 			}
@@ -233,33 +291,37 @@ func isUnavailable(err error) bool {
 	return errdefs.IsUnavailable(errdefs.FromGRPC(err))
 }
 
-func setupNetworking(ctx context.Context, container containerd.Container, c *v1.Container) (string, error) {
+// setupNetworking creates the container's interfaces and registers its services, unless register is nil
+func setupNetworking(ctx context.Context, container containerd.Container, c *v1.Container, register v1.Register) ([]*v1.NetworkInterface, error) {
 	cfg, err := config.Load()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	network, err := cfg.GetNetwork(c.Network)
+	network, err := cfg.GetNetwork(c)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	register, err := cfg.GetRegister()
+	interfaces, err := network.Create(ctx, container)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	ip, err := network.Create(ctx, container)
-	if err != nil {
-		return "", err
+	for _, i := range interfaces {
+		logrus.WithField("id", container.ID()).WithField("ip", i.IPs).Infof("setup network interface %s", i.Name)
 	}
-	if ip != "" {
-		logrus.WithField("id", container.ID()).WithField("ip", ip).Info("setup network interface")
-		for name, srv := range c.Services {
-			logrus.WithField("id", container.ID()).WithField("ip", ip).Infof("registering %s", name)
-			if err := register.Register(container.ID(), name, ip, srv); err != nil {
-				return ip, err
-			}
+	if register == nil {
+		return interfaces, nil
+	}
+	for name, srv := range c.Services {
+		ips := opts.NetworkIPs(interfaces, srv.Network)
+		if len(ips) == 0 {
+			continue
+		}
+		logrus.WithField("id", container.ID()).WithField("ip", ips).Infof("registering %s", name)
+		if err := register.Register(c.ID, name, ips, srv); err != nil {
+			return interfaces, err
 		}
 	}
-	return ip, nil
+	return interfaces, nil
 }
 
 func systemdPreSetup(clix *cli.Context) error {
@@ -308,6 +370,9 @@ func cleanupPreviousTask(id string) error {
 		return err
 	}
 	defer client.Close()
+	if err := cleanupHelpers(ctx, client, id); err != nil {
+		return err
+	}
 	container, err := client.LoadContainer(ctx, id)
 	if err != nil {
 		return err
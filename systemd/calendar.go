@@ -0,0 +1,182 @@
+package systemd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var weekdays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// Calendar returns the OnCalendar expression for a schedule.
+// Five field cron expressions are converted, anything else is used as a systemd calendar event
+func Calendar(schedule string) (string, error) {
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return schedule, nil
+	}
+	var (
+		minute, hour, dom, month, dow = fields[0], fields[1], fields[2], fields[3], fields[4]
+		err                           error
+	)
+	if minute, err = cronField(minute, "0"); err != nil {
+		return "", errors.Wrap(err, "minute")
+	}
+	if hour, err = cronField(hour, "0"); err != nil {
+		return "", errors.Wrap(err, "hour")
+	}
+	if dom, err = cronField(dom, "1"); err != nil {
+		return "", errors.Wrap(err, "day of month")
+	}
+	if month, err = cronField(month, "1"); err != nil {
+		return "", errors.Wrap(err, "month")
+	}
+	event := fmt.Sprintf("*-%s-%s %s:%s:00", month, dom, hour, minute)
+	if dow == "*" {
+		return event, nil
+	}
+	days, err := cronWeekdays(dow)
+	if err != nil {
+		return "", errors.Wrap(err, "day of week")
+	}
+	return days + " " + event, nil
+}
+
+// cronField converts ranges and steps to systemd's syntax, steps of * start at first.
+// systemd does not support steps on ranges so they are expanded to the values they match
+func cronField(f, first string) (string, error) {
+	var parts []string
+	for _, p := range strings.Split(f, ",") {
+		value, step := p, ""
+		if i := strings.Index(p, "/"); i >= 0 {
+			value, step = p[:i], p[i:]
+		}
+		if value == "*" && step != "" {
+			value = first
+		}
+		if value == "*" {
+			parts = append(parts, value)
+			continue
+		}
+		bounds := strings.SplitN(value, "-", 2)
+		var n []int
+		for _, b := range bounds {
+			i, err := strconv.Atoi(b)
+			if err != nil || i < 0 {
+				return "", errors.Errorf("invalid value %q", p)
+			}
+			n = append(n, i)
+		}
+		every := 0
+		if step != "" {
+			var err error
+			if every, err = strconv.Atoi(step[1:]); err != nil || every <= 0 {
+				return "", errors.Errorf("invalid step %q", p)
+			}
+		}
+		if len(n) == 1 {
+			parts = append(parts, value+step)
+			continue
+		}
+		if n[0] > n[1] {
+			return "", errors.Errorf("invalid range %q", p)
+		}
+		if step == "" {
+			parts = append(parts, fmt.Sprintf("%d..%d", n[0], n[1]))
+			continue
+		}
+		for v := n[0]; v <= n[1]; v += every {
+			parts = append(parts, strconv.Itoa(v))
+		}
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// cronWeekdays converts the day of week field to day names, ranges ending on sunday as 0 or 7
+// run to the end of the week and sunday is split out of ranges starting on it as systemd weeks
+// start on monday. Steps are expanded to the days they match
+func cronWeekdays(f string) (string, error) {
+	var (
+		parts []string
+		seen  = make(map[string]bool)
+	)
+	add := func(day string) {
+		if !seen[day] {
+			seen[day] = true
+			parts = append(parts, day)
+		}
+	}
+	for _, p := range strings.Split(f, ",") {
+		value, every := p, 0
+		if i := strings.Index(p, "/"); i >= 0 {
+			var err error
+			if every, err = strconv.Atoi(p[i+1:]); err != nil || every <= 0 {
+				return "", errors.Errorf("invalid step %q", p)
+			}
+			value = p[:i]
+		}
+		var lo, hi int
+		switch bounds := strings.SplitN(value, "-", 2); {
+		case value == "*":
+			lo, hi = 0, 6
+		case len(bounds) == 1:
+			d, err := weekday(value)
+			if err != nil {
+				return "", errors.Wrapf(err, "invalid value %q", p)
+			}
+			lo, hi = d, d
+			if every > 0 {
+				hi = 7
+			}
+		default:
+			var err error
+			if lo, err = weekday(bounds[0]); err != nil {
+				return "", errors.Wrapf(err, "invalid value %q", p)
+			}
+			if hi, err = weekday(bounds[1]); err != nil {
+				return "", errors.Wrapf(err, "invalid value %q", p)
+			}
+			if hi == 0 && lo > 0 {
+				hi = 7
+			}
+			if lo > hi {
+				return "", errors.Errorf("invalid range %q", p)
+			}
+		}
+		if every > 0 {
+			for d := lo; d <= hi; d += every {
+				add(weekdays[d])
+			}
+			continue
+		}
+		if lo == 0 && hi > 0 {
+			// systemd weeks start on monday so sunday is split out of the range
+			if hi < 7 {
+				add(weekdays[0])
+			}
+			lo = 1
+		}
+		if lo == hi {
+			add(weekdays[lo])
+			continue
+		}
+		add(weekdays[lo] + ".." + weekdays[hi])
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// weekday returns the day of week for a cron number from 0 to 7 or a day name
+func weekday(v string) (int, error) {
+	for i, name := range weekdays {
+		if strings.EqualFold(v, name) {
+			return i, nil
+		}
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil || i < 0 || i >= len(weekdays) {
+		return 0, errors.New("unknown day of week")
+	}
+	return i, nil
+}
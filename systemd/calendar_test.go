@@ -0,0 +1,60 @@
+package systemd
+
+import "testing"
+
+func TestCalendar(t *testing.T) {
+	for _, tc := range []struct {
+		schedule string
+		expected string
+	}{
+		{"*/15 * * * *", "*-*-* *:0/15:00"},
+		{"0 3 * * *", "*-*-* 3:0:00"},
+		{"30 2 1 * *", "*-*-1 2:30:00"},
+		{"0 0 1-10/3 * *", "*-*-1,4,7,10 0:0:00"},
+		{"0 9-17 * * *", "*-*-* 9..17:0:00"},
+		{"0 0 * * 1-5", "Mon..Fri *-*-* 0:0:00"},
+		{"0 0 * * 1-5/2", "Mon,Wed,Fri *-*-* 0:0:00"},
+		{"0 0 * * 0", "Sun *-*-* 0:0:00"},
+		{"0 0 * * 7", "Sun *-*-* 0:0:00"},
+		{"0 0 * * 0-0", "Sun *-*-* 0:0:00"},
+		{"0 0 * * 0-3", "Sun,Mon..Wed *-*-* 0:0:00"},
+		{"0 0 * * 0-7", "Mon..Sun *-*-* 0:0:00"},
+		{"0 0 * * 5-0", "Fri..Sun *-*-* 0:0:00"},
+		{"0 0 * * 5-7", "Fri..Sun *-*-* 0:0:00"},
+		{"0 0 * * */2", "Sun,Tue,Thu,Sat *-*-* 0:0:00"},
+		{"0 0 * * 0,6", "Sun,Sat *-*-* 0:0:00"},
+		{"0 0 * * mon-fri", "Mon..Fri *-*-* 0:0:00"},
+		{"0 0 * * SAT,sun", "Sat,Sun *-*-* 0:0:00"},
+		{"0 0 * * sun-tue", "Sun,Mon..Tue *-*-* 0:0:00"},
+		{"daily", "daily"},
+		{"Mon *-*-* 04:00:00", "Mon *-*-* 04:00:00"},
+	} {
+		t.Run(tc.schedule, func(t *testing.T) {
+			calendar, err := Calendar(tc.schedule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if calendar != tc.expected {
+				t.Errorf("calendar %q != %q", calendar, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCalendarErrors(t *testing.T) {
+	for _, schedule := range []string{
+		"60/0 * * * *",
+		"a * * * *",
+		"10-5 * * * *",
+		"0 0 * * 8",
+		"0 0 * * 5-2",
+		"0 0 * * 1-5/0",
+		"0 0 * * funday",
+	} {
+		t.Run(schedule, func(t *testing.T) {
+			if _, err := Calendar(schedule); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
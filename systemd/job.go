@@ -0,0 +1,86 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TimerRoot is where job timers are written
+const TimerRoot = "/etc/systemd/system"
+
+// EnableJob writes and enables the job's timer when it has a schedule
+func EnableJob(ctx context.Context, id, schedule string) error {
+	if schedule == "" {
+		return DisableJob(ctx, id)
+	}
+	calendar, err := Calendar(schedule)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(TimerRoot, timerName(id))
+	if err := writeService(path, fmt.Sprintf(timer, id, calendar)); err != nil {
+		return err
+	}
+	if err := Command(ctx, "daemon-reload"); err != nil {
+		return err
+	}
+	return Command(ctx, "enable", "--now", timerName(id))
+}
+
+// DisableJob removes the job's timer
+func DisableJob(ctx context.Context, id string) error {
+	path := filepath.Join(TimerRoot, timerName(id))
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := Command(ctx, "disable", "--now", timerName(id)); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	return Command(ctx, "daemon-reload")
+}
+
+// RunJob starts a run of the job without waiting for it to finish
+func RunJob(ctx context.Context, id string) error {
+	return Command(ctx, "start", "--no-block", jobName(id))
+}
+
+// StopJob stops a running job
+func StopJob(ctx context.Context, id string) error {
+	return Command(ctx, "stop", jobName(id))
+}
+
+// QueueStopJob queues the stop of a running job without waiting for it to exit
+func QueueStopJob(ctx context.Context, id string) error {
+	return Command(ctx, "stop", "--no-block", jobName(id))
+}
+
+// JobEnabled returns true if the job's timer is enabled
+func JobEnabled(ctx context.Context, id string) (bool, error) {
+	out, err := exec.CommandContext(ctx, "systemctl", "is-enabled", timerName(id)).Output()
+	state := strings.TrimSpace(string(out))
+	if err != nil && state == "" {
+		return false, errors.Wrap(err, "timer state")
+	}
+	return state == "enabled", nil
+}
+
+// NextRun returns when the job's timer next elapses
+func NextRun(ctx context.Context, id string) (string, error) {
+	out, err := exec.CommandContext(ctx, "systemctl", "show", "--property=NextElapseUSecRealtime", "--value", timerName(id)).Output()
+	if err != nil {
+		return "", errors.Wrap(err, "timer next elapse")
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -32,12 +32,37 @@ RestartSec=5
 WantedBy=multi-user.target
 `
 
-func writeService(path string) error {
+// job runs a container to completion, started by hand or by its timer
+const job = `
+[Unit]
+Description=Boss job proxy for %i
+Wants=network-online.target containerd.service
+After=network-online.target containerd.service network.target
+
+[Service]
+ExecStartPre=/usr/local/bin/boss systemd exec-start-pre %i
+ExecStart=/usr/local/bin/boss systemd exec-start %i
+ExecStopPost=/usr/local/bin/boss systemd exec-stop-post %i
+`
+
+const timer = `
+[Unit]
+Description=Boss job schedule for %s
+
+[Timer]
+OnCalendar=%s
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`
+
+func writeService(path, unit string) error {
 	f, err := os.Create(path)
 	if err != nil {
 		return err
 	}
-	_, err = f.WriteString(service)
+	_, err = f.WriteString(unit)
 	f.Close()
 	return err
 }
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -14,25 +15,35 @@ import (
 // Install installs the needed systemd files to run containers
 // as proxy to containerd
 func Install() error {
-	path := filepath.Join(Root, serviceName(""))
+	if err := install(serviceName(""), service); err != nil {
+		return err
+	}
+	return install(jobName(""), job)
+}
+
+func install(name, unit string) error {
+	path := filepath.Join(Root, name)
 	// don't re-install it if it already exists
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return writeService(path)
+			return writeService(path, unit)
 		}
 		return err
 	}
 	// check the hashes of the files just to be safe
-	current := getHash([]byte(service))
+	current := getHash([]byte(unit))
 	if getHash(data) != current {
-		return writeService(path)
+		return writeService(path, unit)
 	}
 	return nil
 }
 
-// Remove the boss unit file
+// Remove the boss unit files
 func Remove() error {
+	if err := os.Remove(filepath.Join(Root, jobName(""))); err != nil && !os.IsNotExist(err) {
+		return err
+	}
 	path := filepath.Join(Root, serviceName(""))
 	return os.Remove(path)
 }
@@ -49,10 +60,25 @@ func Stop(ctx context.Context, id string) error {
 	return Command(ctx, "stop", serviceName(id))
 }
 
+// QueueStop queues the stop of the unit without waiting for its task to exit
+func QueueStop(ctx context.Context, id string) error {
+	return Command(ctx, "stop", "--no-block", serviceName(id))
+}
+
 func Disable(ctx context.Context, id string) error {
 	return Command(ctx, "disable", serviceName(id))
 }
 
+// Enabled returns true if the container's unit is enabled
+func Enabled(ctx context.Context, id string) (bool, error) {
+	out, err := exec.CommandContext(ctx, "systemctl", "is-enabled", serviceName(id)).Output()
+	state := strings.TrimSpace(string(out))
+	if err != nil && state == "" {
+		return false, errors.Wrap(err, "unit state")
+	}
+	return state == "enabled", nil
+}
+
 // Command runs a systemd command
 func Command(ctx context.Context, args ...string) error {
 	out, err := exec.CommandContext(ctx, "systemctl", args...).CombinedOutput()
@@ -65,3 +91,11 @@ func Command(ctx context.Context, args ...string) error {
 func serviceName(id string) string {
 	return fmt.Sprintf("boss-v%d@%s.service", Version, id)
 }
+
+func jobName(id string) string {
+	return fmt.Sprintf("boss-job-v%d@%s.service", Version, id)
+}
+
+func timerName(id string) string {
+	return fmt.Sprintf("boss-job-v%d@%s.timer", Version, id)
+}
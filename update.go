@@ -1,15 +1,28 @@
 package main
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
 	"github.com/BurntSushi/toml"
 	"github.com/crosbymichael/boss/api/v1"
 	"github.com/crosbymichael/boss/cmd"
+	"github.com/morikuni/aec"
 	"github.com/urfave/cli"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 var updateCommand = cli.Command{
 	Name:  "update",
 	Usage: "update an existing container's configuration",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "diff",
+			Usage: "show the changes without applying them",
+		},
+	},
 	Action: func(clix *cli.Context) error {
 		var (
 			path = clix.Args().First()
@@ -24,9 +37,62 @@ var updateCommand = cli.Command{
 			return err
 		}
 		defer agent.Close()
-		_, err = agent.Update(ctx, &v1.UpdateRequest{
-			Container: newConfig.Proto(),
+		c := newConfig.Proto()
+		resp, err := agent.Update(ctx, &v1.UpdateRequest{
+			Container: c,
+			DryRun:    clix.Bool("diff"),
 		})
-		return err
+		if err != nil {
+			return err
+		}
+		if clix.Bool("diff") {
+			printDiff(os.Stdout, c.ID, resp.Diff, terminal.IsTerminal(int(os.Stdout.Fd())))
+		}
+		return nil
 	},
 }
+
+// printDiff renders the update diff like a unified diff
+func printDiff(w io.Writer, id string, d *v1.UpdateDiff, color bool) {
+	paint := func(a aec.ANSI, s string) string {
+		if !color {
+			return s
+		}
+		return a.Apply(s)
+	}
+	fmt.Fprintln(w, paint(aec.Bold, fmt.Sprintf("--- %s (current)", id)))
+	fmt.Fprintln(w, paint(aec.Bold, fmt.Sprintf("+++ %s (update)", id)))
+	if d.ImageDigest != d.NewImageDigest {
+		fmt.Fprintln(w, paint(aec.CyanF, "@@ image @@"))
+		fmt.Fprintln(w, paint(aec.RedF, "-digest: "+d.ImageDigest))
+		fmt.Fprintln(w, paint(aec.GreenF, "+digest: "+d.NewImageDigest))
+	}
+	section := ""
+	for _, c := range d.Changes {
+		if s := strings.SplitN(c.Path, ".", 2)[0]; s != section {
+			section = s
+			fmt.Fprintln(w, paint(aec.CyanF, fmt.Sprintf("@@ %s @@", section)))
+		}
+		if c.Old != "" {
+			fmt.Fprintln(w, paint(aec.RedF, fmt.Sprintf("-%s: %s", c.Path, c.Old)))
+		}
+		if c.New != "" {
+			fmt.Fprintln(w, paint(aec.GreenF, fmt.Sprintf("+%s: %s", c.Path, c.New)))
+		}
+	}
+	if !d.NewRevision {
+		fmt.Fprintln(w, "no changes")
+		return
+	}
+	fmt.Fprintf(w, "new revision: yes, restart: %s\n", yesNo(d.Restart))
+	if len(d.Deregister) > 0 {
+		fmt.Fprintf(w, "deregister: %s\n", strings.Join(d.Deregister, ", "))
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -15,6 +15,34 @@ func GetIP(name string) (string, error) {
 	return getIPf(i, ipv4)
 }
 
+// GetIP6 returns the global ipv6 address of the interface
+func GetIP6(name string) (string, error) {
+	i, err := net.InterfaceByName(name)
+	if err != nil {
+		return "", err
+	}
+	return getIPf(i, ipv6)
+}
+
+// GetIPs returns the interface's ipv4 and global ipv6 addresses, ipv4 first
+func GetIPs(name string) ([]string, error) {
+	var ips []string
+	for _, f := range []func(string) (string, error){GetIP, GetIP6} {
+		ip, err := f(name)
+		if err != nil {
+			if err == ErrIPAddressNotFound {
+				continue
+			}
+			return nil, err
+		}
+		ips = append(ips, ip)
+	}
+	if len(ips) == 0 {
+		return nil, ErrIPAddressNotFound
+	}
+	return ips, nil
+}
+
 func getIPf(i *net.Interface, ipfunc func(n *net.IPNet) string) (string, error) {
 	addrs, err := i.Addrs()
 	if err != nil {
@@ -40,3 +68,10 @@ func ipv4(n *net.IPNet) string {
 	}
 	return n.IP.To4().String()
 }
+
+func ipv6(n *net.IPNet) string {
+	if n.IP.To4() != nil || !n.IP.IsGlobalUnicast() {
+		return ""
+	}
+	return n.IP.String()
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/crosbymichael/boss/api/v1"
+	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var volumeCommand = cli.Command{
+	Name:  "volume",
+	Usage: "manage volumes",
+	Subcommands: []cli.Command{
+		volumeCreateCommand,
+		volumeDeleteCommand,
+		volumeExportCommand,
+		volumeListCommand,
+		volumeSnapshotCommand,
+	},
+}
+
+var volumeCreateCommand = cli.Command{
+	Name:      "create",
+	Usage:     "create a volume",
+	ArgsUsage: "<id>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "quota",
+			Usage: "limit the size of the volume, i.e. 10GB",
+		},
+		cli.IntFlag{
+			Name:  "uid",
+			Usage: "owner of the volume",
+		},
+		cli.IntFlag{
+			Name:  "gid",
+			Usage: "group of the volume",
+		},
+	},
+	Action: func(clix *cli.Context) error {
+		var quota int64
+		if q := clix.String("quota"); q != "" {
+			var err error
+			if quota, err = units.RAMInBytes(q); err != nil {
+				return errors.Wrap(err, "quota")
+			}
+		}
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		_, err = agent.CreateVolume(Context(), &v1.CreateVolumeRequest{
+			ID:    clix.Args().First(),
+			Quota: quota,
+			Uid:   uint32(clix.Int("uid")),
+			Gid:   uint32(clix.Int("gid")),
+		})
+		return err
+	},
+}
+
+var volumeListCommand = cli.Command{
+	Name:  "list",
+	Usage: "list volumes with their size and the containers using them",
+	Action: func(clix *cli.Context) error {
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.ListVolumes(Context(), &v1.ListVolumesRequest{})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+		const tfmt = "%s\t%s\t%s\t%s\t%s\n"
+		fmt.Fprint(w, "ID\tSIZE\tQUOTA\tCREATED\tCONTAINERS\n")
+		for _, v := range resp.Volumes {
+			quota := "-"
+			if v.Quota > 0 {
+				quota = fmt.Sprintf("%s (%s)", units.BytesSize(float64(v.Quota)), v.QuotaType)
+			}
+			fmt.Fprintf(w, tfmt,
+				v.ID,
+				units.BytesSize(float64(v.Size_)),
+				quota,
+				v.Created.Local().Format(time.RFC3339),
+				orDash(strings.Join(v.Containers, ",")),
+			)
+		}
+		return w.Flush()
+	},
+}
+
+var volumeDeleteCommand = cli.Command{
+	Name:      "delete",
+	Usage:     "delete a volume that is not used by any container",
+	ArgsUsage: "<id>",
+	Action: func(clix *cli.Context) error {
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		_, err = agent.DeleteVolume(Context(), &v1.DeleteVolumeRequest{
+			ID: clix.Args().First(),
+		})
+		return err
+	},
+}
+
+var volumeSnapshotCommand = cli.Command{
+	Name:      "snapshot",
+	Usage:     "store a volume as an image that can be pushed",
+	ArgsUsage: "<id> <ref>",
+	Action: func(clix *cli.Context) error {
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		resp, err := agent.SnapshotVolume(Context(), &v1.SnapshotVolumeRequest{
+			ID:  clix.Args().First(),
+			Ref: clix.Args().Get(1),
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s %s\n", resp.Digest, units.BytesSize(float64(resp.Size_)))
+		return nil
+	},
+}
+
+var volumeExportCommand = cli.Command{
+	Name:      "export",
+	Usage:     "export a volume as a gzipped tar",
+	ArgsUsage: "<id>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output,o",
+			Usage: "write to a file instead of stdout",
+		},
+	},
+	Action: func(clix *cli.Context) error {
+		var out io.Writer = os.Stdout
+		if path := clix.String("output"); path != "" {
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+		agent, err := Agent(clix)
+		if err != nil {
+			return err
+		}
+		defer agent.Close()
+		stream, err := agent.ExportVolume(Context(), &v1.ExportVolumeRequest{
+			ID: clix.Args().First(),
+		})
+		if err != nil {
+			return err
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := out.Write(resp.Data); err != nil {
+				return err
+			}
+		}
+	},
+}
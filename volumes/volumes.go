@@ -0,0 +1,460 @@
+package volumes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd/archive"
+	"github.com/containerd/containerd/archive/compression"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/pkg/errors"
+)
+
+const (
+	// QuotaLoop limits a volume by mounting a filesystem image of the quota's size
+	QuotaLoop = "loop"
+	// QuotaProject limits a volume with xfs project quotas on the volume root
+	QuotaProject = "project"
+
+	// metadata and filesystem images are kept in hidden directories of the volume root
+	metaDir   = ".volumes"
+	imagesDir = ".images"
+
+	firstProjectID = 1000
+
+	// importPrefix names the directory an import is unpacked into before it replaces the volume's files
+	importPrefix = ".import-"
+)
+
+var ErrNoRoot = errors.New("no volume_root specified")
+
+// Volume is a directory under the volume root mounted into containers
+type Volume struct {
+	ID      string    `json:"id"`
+	Created time.Time `json:"created"`
+	// Quota is the size limit of the volume in bytes, zero for no limit
+	Quota     int64  `json:"quota,omitempty"`
+	QuotaType string `json:"quota_type,omitempty"`
+	ProjectID uint32 `json:"project_id,omitempty"`
+}
+
+// Store manages the volumes under a volume root.
+// Volumes created implicitly by containers are plain directories without metadata
+type Store struct {
+	Root string
+}
+
+// New returns a volume store for the root
+func New(root string) (*Store, error) {
+	if root == "" {
+		return nil, ErrNoRoot
+	}
+	return &Store{
+		Root: root,
+	}, nil
+}
+
+// Path returns the directory of the volume
+func (s *Store) Path(id string) string {
+	return filepath.Join(s.Root, id)
+}
+
+func (s *Store) metaPath(id string) string {
+	return filepath.Join(s.Root, metaDir, id+".json")
+}
+
+func (s *Store) imagePath(id string) string {
+	return filepath.Join(s.Root, imagesDir, id+".img")
+}
+
+// Create a volume owned by uid and gid with an optional quota
+func (s *Store) Create(ctx context.Context, id string, quota int64, quotaType string, uid, gid int) (*Volume, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	path := s.Path(id)
+	if _, err := os.Stat(path); err == nil {
+		return nil, errors.Wrapf(errdefs.ErrAlreadyExists, "volume %s", id)
+	}
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+	v := &Volume{
+		ID:      id,
+		Created: time.Now(),
+	}
+	if quota > 0 {
+		v.Quota = quota
+		v.QuotaType = quotaType
+		if err := s.setQuota(ctx, v); err != nil {
+			s.remove(ctx, v)
+			return nil, errors.Wrap(err, "set quota")
+		}
+	}
+	// the owner is set after a loop image is mounted so that it applies to its root
+	if err := os.Chown(path, uid, gid); err != nil {
+		s.remove(ctx, v)
+		return nil, err
+	}
+	if err := s.save(v); err != nil {
+		s.remove(ctx, v)
+		return nil, err
+	}
+	return v, nil
+}
+
+// Get returns the volume, volumes without metadata have no quota
+func (s *Store) Get(id string) (*Volume, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(s.Path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(errdefs.ErrNotFound, "volume %s", id)
+		}
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(s.metaPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Volume{
+				ID:      id,
+				Created: info.ModTime(),
+			}, nil
+		}
+		return nil, err
+	}
+	var v Volume
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// List all volumes under the root
+func (s *Store) List() ([]*Volume, error) {
+	files, err := ioutil.ReadDir(s.Root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []*Volume
+	for _, f := range files {
+		if !f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		v, err := s.Get(f.Name())
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// Size returns the bytes used by the volume
+func (s *Store) Size(v *Volume) (int64, error) {
+	path := s.Path(v.ID)
+	if v.QuotaType == QuotaLoop {
+		var fs syscall.Statfs_t
+		if err := syscall.Statfs(path, &fs); err != nil {
+			return 0, err
+		}
+		return int64(fs.Blocks-fs.Bfree) * fs.Bsize, nil
+	}
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// Delete removes the volume, its quota and its data
+func (s *Store) Delete(ctx context.Context, id string) error {
+	v, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	return s.remove(ctx, v)
+}
+
+func (s *Store) remove(ctx context.Context, v *Volume) error {
+	if err := s.clearQuota(ctx, v); err != nil {
+		return errors.Wrap(err, "clear quota")
+	}
+	if err := os.RemoveAll(s.Path(v.ID)); err != nil {
+		return err
+	}
+	if err := os.Remove(s.metaPath(v.ID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Mount mounts the filesystem images of loop volumes that are not mounted,
+// they do not survive a reboot
+func (s *Store) Mount(ctx context.Context) error {
+	volumes, err := s.List()
+	if err != nil {
+		return err
+	}
+	for _, v := range volumes {
+		if v.QuotaType != QuotaLoop {
+			continue
+		}
+		mounted, err := isMountpoint(s.Path(v.ID))
+		if err != nil {
+			return err
+		}
+		if mounted {
+			continue
+		}
+		if err := run(ctx, "mount", "-o", "loop", s.imagePath(v.ID), s.Path(v.ID)); err != nil {
+			return errors.Wrapf(err, "mount volume %s", v.ID)
+		}
+	}
+	return nil
+}
+
+// Export writes the volume's files as a gzipped tar
+func (s *Store) Export(ctx context.Context, w io.Writer, id string) error {
+	if _, err := s.Get(id); err != nil {
+		return err
+	}
+	empty, err := ioutil.TempDir("", "boss-volume-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(empty)
+	cw, err := compression.CompressStream(w, compression.Gzip)
+	if err != nil {
+		return err
+	}
+	if err := archive.WriteDiff(ctx, cw, empty, s.Path(id)); err != nil {
+		cw.Close()
+		return err
+	}
+	return cw.Close()
+}
+
+// Import replaces the volume's files with a gzipped tar written by Export
+func (s *Store) Import(ctx context.Context, r io.Reader, id string) error {
+	if _, err := s.Get(id); err != nil {
+		return err
+	}
+	dr, err := compression.DecompressStream(r)
+	if err != nil {
+		return err
+	}
+	defer dr.Close()
+	// the tar is unpacked into a directory inside the volume so that it is on the volume's
+	// filesystem and quota, and only replaces the volume's files once it is complete
+	path := s.Path(id)
+	staging, err := ioutil.TempDir(path, importPrefix)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+	if _, err := archive.Apply(ctx, staging, dr); err != nil {
+		return err
+	}
+	return replace(path, staging)
+}
+
+// replace removes the files of the directory and moves the files of staging, inside it, in their place
+func replace(path, staging string) error {
+	current, err := ioutil.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	for _, f := range current {
+		if f.Name() == filepath.Base(staging) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(path, f.Name())); err != nil {
+			return err
+		}
+	}
+	files, err := ioutil.ReadDir(staging)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := os.Rename(filepath.Join(staging, f.Name()), filepath.Join(path, f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) save(v *Volume) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	path := s.metaPath(v.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), ".volume")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (s *Store) setQuota(ctx context.Context, v *Volume) error {
+	switch v.QuotaType {
+	case QuotaLoop:
+		image := s.imagePath(v.ID)
+		if err := os.MkdirAll(filepath.Dir(image), 0700); err != nil {
+			return err
+		}
+		f, err := os.Create(image)
+		if err != nil {
+			return err
+		}
+		err = f.Truncate(v.Quota)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		if err := run(ctx, "mkfs.ext4", "-q", "-F", image); err != nil {
+			return err
+		}
+		return run(ctx, "mount", "-o", "loop", image, s.Path(v.ID))
+	case QuotaProject:
+		id, err := s.nextProjectID()
+		if err != nil {
+			return err
+		}
+		v.ProjectID = id
+		mnt, err := mountpoint(s.Root)
+		if err != nil {
+			return err
+		}
+		if err := run(ctx, "xfs_quota", "-x", "-c", fmt.Sprintf("project -s -p %s %d", s.Path(v.ID), id), mnt); err != nil {
+			return err
+		}
+		return run(ctx, "xfs_quota", "-x", "-c", fmt.Sprintf("limit -p bhard=%d %d", v.Quota, id), mnt)
+	}
+	return errors.Errorf("unknown quota type %q", v.QuotaType)
+}
+
+func (s *Store) clearQuota(ctx context.Context, v *Volume) error {
+	switch v.QuotaType {
+	case QuotaLoop:
+		mounted, err := isMountpoint(s.Path(v.ID))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if mounted {
+			if err := run(ctx, "umount", s.Path(v.ID)); err != nil {
+				return err
+			}
+		}
+		if err := os.Remove(s.imagePath(v.ID)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	case QuotaProject:
+		if v.ProjectID == 0 {
+			return nil
+		}
+		mnt, err := mountpoint(s.Root)
+		if err != nil {
+			return err
+		}
+		if err := run(ctx, "xfs_quota", "-x", "-c", fmt.Sprintf("limit -p bhard=0 %d", v.ProjectID), mnt); err != nil {
+			return err
+		}
+		return run(ctx, "xfs_quota", "-x", "-c", fmt.Sprintf("project -C -p %s %d", s.Path(v.ID), v.ProjectID), mnt)
+	}
+	return nil
+}
+
+func (s *Store) nextProjectID() (uint32, error) {
+	volumes, err := s.List()
+	if err != nil {
+		return 0, err
+	}
+	id := uint32(firstProjectID)
+	for _, v := range volumes {
+		if v.ProjectID >= id {
+			id = v.ProjectID + 1
+		}
+	}
+	return id, nil
+}
+
+func validID(id string) error {
+	if id == "" || strings.HasPrefix(id, ".") || strings.ContainsRune(id, filepath.Separator) {
+		return errors.Errorf("invalid volume id %q", id)
+	}
+	return nil
+}
+
+// isMountpoint returns true if the path is on a different device than its parent
+func isMountpoint(path string) (bool, error) {
+	var st, parent syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return false, err
+	}
+	if err := syscall.Stat(filepath.Dir(path), &parent); err != nil {
+		return false, err
+	}
+	return st.Dev != parent.Dev, nil
+}
+
+// mountpoint returns the mount that the path is on
+func mountpoint(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	for {
+		mounted, err := isMountpoint(path)
+		if err != nil {
+			return "", err
+		}
+		parent := filepath.Dir(path)
+		if mounted || parent == path {
+			return path, nil
+		}
+		path = parent
+	}
+}
+
+func run(ctx context.Context, name string, args ...string) error {
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "%s: %s", name, strings.TrimSpace(string(out)))
+	}
+	return nil
+}